@@ -1,147 +1,190 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	"github.com/go-chi/chi/v5"
-	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/joho/godotenv"
 
 	"beef-db-be/internal/config"
-	"beef-db-be/internal/handler"
-	"beef-db-be/internal/middleware"
+	"beef-db-be/internal/logger"
+	"beef-db-be/internal/metrics"
 	"beef-db-be/internal/service"
+	"beef-db-be/internal/tracing"
 )
 
 func main() {
+	configPath := flag.String("config", "", "path to the YAML config file (default "+config.DefaultPath+" when present)")
+	printConfig := flag.Bool("print-config", false, "print the effective configuration with secrets redacted, then exit")
+	migrateFlag := flag.Bool("migrate-on-start", false, "apply pending database migrations before serving")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: api [flags] [migrate <command> | seed [seed flags]]\n\nflags:\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
 	// Load environment variables
 	env := os.Getenv("GO_ENV")
 	if env == "" {
 		env = "local" // Mặc định là local nếu không có biến môi trường
 	}
-	if err := godotenv.Load(fmt.Sprintf(".env.%s", env)); err != nil {
-		log.Printf("Warning: .env file not found")
+	envErr := godotenv.Load(fmt.Sprintf(".env.%s", env))
+
+	// Load configuration: defaults, then the YAML file, then environment variables
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		slog.Error("Failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+	// The migrate and seed subcommands only need the database settings
+	validate := cfg.Validate
+	if flag.Arg(0) == "migrate" || flag.Arg(0) == "seed" {
+		validate = cfg.Database.Validate
+	}
+	if *printConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			slog.Error("Failed to print configuration", "error", err)
+			os.Exit(1)
+		}
+		if err := validate(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if err := validate(); err != nil {
+		slog.Error("Invalid configuration", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize logger
+	log := logger.New(os.Stdout, logger.Config{Level: cfg.Log.Level, Format: cfg.Log.Format})
+	slog.SetDefault(log)
+	if envErr != nil {
+		log.Warn(".env file not found", "env", env)
+	}
+
+	// The migrate and seed subcommands run instead of the server
+	switch flag.Arg(0) {
+	case "migrate":
+		os.Exit(runMigrate(cfg.Database, log, flag.Args()[1:]))
+	case "seed":
+		os.Exit(runSeed(cfg, log, flag.Args()[1:]))
+	}
+	if flag.NArg() > 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Initialize tracing; spans are exported by the configured exporter (otlp, stdout or none)
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		ServiceName: cfg.Tracing.ServiceName,
+	})
+	if err != nil {
+		log.Error("Failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+
+	// Apply pending migrations first when asked, so the pool never sees an old schema
+	if *migrateFlag || cfg.Database.MigrateOnStart {
+		if err := migrateOnStart(cfg.Database, log); err != nil {
+			log.Error("Failed to migrate the database", "error", err)
+			os.Exit(1)
+		}
 	}
 
 	// Initialize database connection pool
-	pool, err := config.NewDBPool()
+	pool, err := config.NewDBPool(cfg.Database)
 	if err != nil {
-		log.Fatalf("Failed to create database pool: %v", err)
-	}
-	defer pool.Close()
-
-	// Initialize services
-	userService := service.NewUserService(pool)
-	categoryService := service.NewCategoryService(pool)
-	productService := service.NewProductService(pool)
-	websiteSettingService := service.NewWebsiteSettingService(pool)
-	healthHandler := handler.NewHealthHandler(pool)
-	pageService := service.NewPageService(pool)
-	blogPostService := service.NewBlogPostService(pool)
-
-	// Initialize handlers
-	userHandler := handler.NewUserHandler(userService)
-	categoryHandler := handler.NewCategoryHandler(categoryService)
-	productHandler := handler.NewProductHandler(productService, websiteSettingService, categoryService)
-	websiteSettingHandler := handler.NewWebsiteSettingHandler(websiteSettingService)
-	pageHandler := handler.NewPageHandler(pageService)
-	blogPostHandler := handler.NewBlogPostHandler(blogPostService)
-
-	// Initialize router
-	r := chi.NewRouter()
-
-	// Global middleware
-	r.Use(chimiddleware.Logger)
-	r.Use(chimiddleware.Recoverer)
-	r.Use(chimiddleware.RequestID)
-	r.Use(chimiddleware.RealIP)
-	r.Use(middleware.CORS)
-
-	// Health check endpoint
-	r.Get("/health", healthHandler.CheckHealth)
-
-	// Routes
-	r.Route("/api", func(r chi.Router) {
-		// Public routes
-		r.Post("/auth/signup", userHandler.SignUp)
-		r.Post("/auth/login", userHandler.Login)
-		r.Post("/auth/logout", userHandler.Logout)
-		r.Get("/users/me", userHandler.GetMe) // Public endpoint for getting current user
-
-		// Public category routes
-		r.Get("/categories", categoryHandler.ListCategories)
-		r.Get("/categories/{id}", categoryHandler.GetCategory)
-		r.Get("/categories/slug/{slug}", categoryHandler.GetCategoryBySlug)
-
-		// Public product routes
-		r.Get("/products", productHandler.ListProducts)
-		r.Get("/products/{id}", productHandler.GetProduct)
-		r.Get("/products/slug/{slug}", productHandler.GetProductBySlug)
-		r.Get("/products/by-setting-categories", productHandler.ListProductsBySettingCategories)
-		r.Get("/categories/{categoryId}/products", productHandler.ListProductsByCategoryByID)
-		r.Get("/categories/slug/{categorySlug}/products", productHandler.ListProductsByCategoryBySlug)
-
-		// Public page routes
-		r.Get("/pages", pageHandler.ListPages)
-		r.Get("/pages/{id}", pageHandler.GetPage)
-		r.Get("/pages/slug/{slug}", pageHandler.GetPageBySlug)
-
-		// Public blog post routes
-		r.Get("/blog-posts", blogPostHandler.List)
-		r.Get("/blog-posts/{id}", blogPostHandler.GetByID)
-		r.Get("/blog-posts/slug/{slug}", blogPostHandler.GetBySlug)
-
-		// Public website settings routes
-		r.Get("/settings", websiteSettingHandler.List)
-		r.Get("/settings/{id}", websiteSettingHandler.Get)
-		r.Get("/settings/name/{name}", websiteSettingHandler.GetByName)
-
-		// Admin-only routes
-		r.Group(func(r chi.Router) {
-			r.Use(middleware.RequireAuth(userService))
-
-			// User management
-			r.Get("/users/{id}", userHandler.GetUser)
-			r.Get("/users", userHandler.ListUsers)
-
-			// Category management
-			r.Post("/categories", categoryHandler.CreateCategory)
-			r.Put("/categories/{id}", categoryHandler.UpdateCategory)
-			r.Delete("/categories/{id}", categoryHandler.DeleteCategory)
-
-			// Product management
-			r.Post("/products", productHandler.CreateProduct)
-			r.Put("/products/{id}", productHandler.UpdateProduct)
-			r.Delete("/products/{id}", productHandler.DeleteProduct)
-
-			// Website settings management
-			r.Post("/settings", websiteSettingHandler.Create)
-			r.Put("/settings/name/{name}", websiteSettingHandler.Update)
-			r.Delete("/settings/{id}", websiteSettingHandler.Delete)
-
-			// Page management
-			r.Post("/pages", pageHandler.CreatePage)
-			r.Put("/pages/{id}", pageHandler.UpdatePage)
-			r.Delete("/pages/{id}", pageHandler.DeletePage)
-
-			// Blog post management
-			r.Post("/blog-posts", blogPostHandler.Create)
-			r.Put("/blog-posts/{id}", blogPostHandler.Update)
-			r.Delete("/blog-posts/{id}", blogPostHandler.Delete)
-		})
-	})
+		log.Error("Failed to create database pool", "error", err)
+		os.Exit(1)
+	}
+	metrics.RegisterPool(pool)
+
+	// Build the HTTP routes on the pool
+	healthService := service.NewHealthService(pool)
+	r := newRouter(cfg, pool, healthService, log)
 
 	// Start server
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "80"
+	server := &http.Server{
+		Addr:              cfg.Server.Addr(),
+		Handler:           r,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
+	}
+
+	// Metrics are served on their own port so they stay off the public API
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", metrics.Handler())
+	metricsServer := &http.Server{
+		Addr:              cfg.Metrics.Addr,
+		Handler:           metricsMux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	serverErr := make(chan error, 2)
+	go func() {
+		log.Info("Metrics server starting", "addr", cfg.Metrics.Addr)
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- fmt.Errorf("metrics server: %w", err)
+		}
+	}()
+	go func() {
+		log.Info("Server starting", "port", cfg.Server.Port)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	stop, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	exitCode := 0
+	select {
+	case <-stop.Done():
+		log.Info("Shutdown signal received")
+	case err := <-serverErr:
+		log.Error("Server failed", "error", err)
+		exitCode = 1
+	}
+
+	// Fail readiness first so load balancers stop routing here, then stop accepting
+	// connections and let in-flight requests finish within the grace period
+	healthService.StartDraining()
+	if delay := cfg.Server.ShutdownDrainDelay; delay > 0 {
+		log.Info("Waiting before draining connections", "delay", delay)
+		time.Sleep(delay)
+	}
+
+	ctx, cancelShutdown := context.WithTimeout(context.Background(), cfg.Server.ShutdownGracePeriod)
+	defer cancelShutdown()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.Error("Server did not drain in time", "error", err)
+		exitCode = 1
+	}
+	if err := metricsServer.Shutdown(ctx); err != nil {
+		log.Error("Metrics server did not shut down cleanly", "error", err)
 	}
 
-	log.Printf("Server starting on port %s", port)
-	if err := http.ListenAndServe(":"+port, r); err != nil {
-		log.Fatalf("Server failed to start: %v", err)
+	// Close the pool once no request can use it, then flush the remaining spans
+	pool.Close()
+	if err := shutdownTracing(ctx); err != nil {
+		log.Error("Failed to flush traces", "error", err)
 	}
+
+	log.Info("Server stopped")
+	os.Exit(exitCode)
 }
@@ -0,0 +1,126 @@
+//go:build integration
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"beef-db-be/internal/config"
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/pgtest"
+	"beef-db-be/internal/repository"
+	"beef-db-be/internal/service"
+	"beef-db-be/internal/utils"
+)
+
+func TestMain(m *testing.M) {
+	pgtest.Main(m)
+}
+
+// testServer is the API router served over HTTP on a database of its own
+type testServer struct {
+	*httptest.Server
+	cfg  *config.Config
+	pool *pgxpool.Pool
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	cfg := config.Default()
+	cfg.Env = "test"
+	cfg.Auth.JWTSecret = "test-secret"
+	cfg.Database = pgtest.NewDatabase(t)
+
+	pool, err := config.NewDBPool(cfg.Database)
+	if err != nil {
+		t.Fatalf("NewDBPool: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	srv := httptest.NewServer(newRouter(&cfg, pool, service.NewHealthService(pool), log))
+	t.Cleanup(srv.Close)
+	return &testServer{Server: srv, cfg: &cfg, pool: pool}
+}
+
+// do sends body as JSON, authenticated by token when it is set, and decodes the
+// response envelope. The data is decoded into data when it is not nil.
+func (s *testServer) do(t *testing.T, method, path, token string, body, data any) (int, model.APIResponse) {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("encoding %s %s body: %v", method, path, err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequest(method, s.URL+path, reader)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.AddCookie(&http.Cookie{Name: utils.TokenCookieName, Value: token})
+	}
+
+	resp, err := s.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		model.APIResponse
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		t.Fatalf("%s %s: decoding response: %v", method, path, err)
+	}
+	if data != nil && len(envelope.Data) > 0 {
+		if err := json.Unmarshal(envelope.Data, data); err != nil {
+			t.Fatalf("%s %s: decoding data %s: %v", method, path, envelope.Data, err)
+		}
+	}
+	return resp.StatusCode, envelope.APIResponse
+}
+
+// login signs in through the API and returns the token of the auth cookie
+func (s *testServer) login(t *testing.T, email, password string) string {
+	t.Helper()
+	var resp model.LoginResponse
+	status, body := s.do(t, http.MethodPost, "/api/auth/login", "",
+		model.LoginRequest{Email: email, Password: password}, &resp)
+	if status != http.StatusOK {
+		t.Fatalf("login as %s: status %d, %+v", email, status, body)
+	}
+	return resp.Token
+}
+
+// adminToken creates an admin account directly and signs in as it
+func (s *testServer) adminToken(t *testing.T) string {
+	t.Helper()
+	queries := repository.New(s.pool)
+	tx := repository.NewTxRunner(s.pool)
+	seed := service.NewSeedService(queries, tx,
+		service.NewCategoryService(queries, tx),
+		service.NewProductService(queries, tx),
+		service.NewPageService(queries, tx, s.cfg.Auth),
+		service.NewBlogPostService(queries, tx, s.cfg.Auth),
+		service.NewWebsiteSettingService(queries, tx),
+	)
+	if _, err := seed.EnsureAdmin(context.Background(), "admin@example.com", "secret123"); err != nil {
+		t.Fatalf("EnsureAdmin: %v", err)
+	}
+	return s.login(t, "admin@example.com", "secret123")
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+
+	"beef-db-be/internal/config"
+	"beef-db-be/internal/migration"
+)
+
+const migrateUsage = `usage: api [flags] migrate <command>
+
+commands:
+  up             apply every pending migration
+  down [N]       revert the last N migrations (default 1)
+  status         show the applied version and pending migrations
+  force VERSION  mark VERSION as applied and clear the dirty flag, after fixing a
+                 failed migration by hand`
+
+// runMigrate runs the migrate subcommand and returns the exit code
+func runMigrate(cfg config.DatabaseConfig, log *slog.Logger, args []string) int {
+	run := parseMigrateCommand(args)
+	if run == nil {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	runner, err := migration.NewRunner(cfg, log)
+	if err != nil {
+		log.Error("Failed to set up migrations", "error", err)
+		return 1
+	}
+	defer runner.Close()
+
+	if err := run(runner); err != nil {
+		log.Error("Migration command failed", "command", args[0], "error", err)
+		return 1
+	}
+	if args[0] != "status" {
+		log.Info("Migration command finished", "command", args[0])
+	}
+	return 0
+}
+
+// parseMigrateCommand returns the action of a migrate command line, or nil when it
+// is not valid
+func parseMigrateCommand(args []string) func(*migration.Runner) error {
+	if len(args) == 0 {
+		return nil
+	}
+
+	switch command, rest := args[0], args[1:]; {
+	case command == "up" && len(rest) == 0:
+		return (*migration.Runner).Up
+	case command == "down" && len(rest) <= 1:
+		steps := 1
+		if len(rest) == 1 {
+			n, err := strconv.Atoi(rest[0])
+			if err != nil {
+				return nil
+			}
+			steps = n
+		}
+		return func(r *migration.Runner) error { return r.Down(steps) }
+	case command == "force" && len(rest) == 1:
+		version, err := strconv.Atoi(rest[0])
+		if err != nil {
+			return nil
+		}
+		return func(r *migration.Runner) error { return r.Force(version) }
+	case command == "status" && len(rest) == 0:
+		return printMigrationStatus
+	}
+	return nil
+}
+
+func printMigrationStatus(runner *migration.Runner) error {
+	status, err := runner.Status()
+	if err != nil {
+		return err
+	}
+
+	all, err := migration.List()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("version: %d (latest %d)", status.Version, status.Latest)
+	if status.Dirty {
+		fmt.Print(", dirty")
+	}
+	fmt.Println()
+	for _, m := range all {
+		state := "applied"
+		switch {
+		case m.Version > status.Version:
+			state = "pending"
+		case m.Version == status.Version && status.Dirty:
+			state = "failed"
+		}
+		fmt.Printf("  %06d  %-8s %s\n", m.Version, state, m.Name)
+	}
+	return nil
+}
+
+// migrateOnStart applies pending migrations before the server starts. Replicas
+// starting together wait for each other on the migration lock.
+func migrateOnStart(cfg config.DatabaseConfig, log *slog.Logger) error {
+	runner, err := migration.NewRunner(cfg, log)
+	if err != nil {
+		return err
+	}
+	defer runner.Close()
+
+	if err := runner.Up(); err != nil {
+		return err
+	}
+	status, err := runner.Status()
+	if err != nil {
+		return err
+	}
+	log.Info("Database schema is up to date", "version", status.Version)
+	return nil
+}
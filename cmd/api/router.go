@@ -0,0 +1,44 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"beef-db-be/internal/config"
+	"beef-db-be/internal/repository"
+	"beef-db-be/internal/router"
+	"beef-db-be/internal/service"
+	"beef-db-be/internal/utils"
+)
+
+// newRouter builds the services on pool and returns the routes of the API.
+// healthService is passed in because main also uses it to fail readiness on shutdown.
+func newRouter(cfg *config.Config, pool *pgxpool.Pool, healthService *service.HealthService, log *slog.Logger) http.Handler {
+	queries := repository.New(pool)
+	tx := repository.NewTxRunner(pool)
+	locales := utils.NewLocales(cfg.Locale)
+
+	blogPostService := service.NewBlogPostService(queries, tx, cfg.Auth)
+
+	return router.NewRouter(router.Deps{
+		Config:  cfg,
+		Log:     log,
+		Locales: locales,
+
+		Users:           service.NewUserService(queries, tx, cfg.Auth),
+		Categories:      service.NewCategoryService(queries, tx),
+		Products:        service.NewProductService(queries, tx),
+		WebsiteSettings: service.NewWebsiteSettingService(queries, tx),
+		Pages:           service.NewPageService(queries, tx, cfg.Auth),
+		BlogPosts:       blogPostService,
+		BlogTaxonomy:    service.NewBlogTaxonomyService(queries, tx),
+		Coupons:         service.NewCouponService(queries, tx),
+		Sitemap:         service.NewSitemapService(queries, cfg.Site),
+		Feed:            service.NewFeedService(queries, blogPostService, cfg.Site),
+		Translations:    service.NewTranslationService(queries, locales),
+		Currency:        service.NewCurrencyService(queries, tx),
+		Health:          healthService,
+	})
+}
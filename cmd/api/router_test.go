@@ -0,0 +1,183 @@
+//go:build integration
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"beef-db-be/internal/model"
+)
+
+func TestHealthEndpoints(t *testing.T) {
+	t.Parallel()
+	srv := newTestServer(t)
+
+	for _, path := range []string{"/livez", "/readyz"} {
+		if status, body := srv.do(t, http.MethodGet, path, "", nil, nil); status != http.StatusOK {
+			t.Errorf("GET %s: status %d, %+v", path, status, body)
+		}
+	}
+}
+
+func TestAdminRoutesRequireAnAdmin(t *testing.T) {
+	t.Parallel()
+	srv := newTestServer(t)
+	category := model.CreateCategoryRequest{Name: "Beef"}
+
+	status, _ := srv.do(t, http.MethodPost, "/api/categories", "", category, nil)
+	if status != http.StatusUnauthorized {
+		t.Fatalf("create category without a token: status %d, want %d", status, http.StatusUnauthorized)
+	}
+
+	signUp := model.SignUpRequest{Email: "user@example.com", Password: "secret123"}
+	if status, body := srv.do(t, http.MethodPost, "/api/auth/signup", "", signUp, nil); status != http.StatusCreated {
+		t.Fatalf("sign up: status %d, %+v", status, body)
+	}
+	token := srv.login(t, signUp.Email, signUp.Password)
+
+	status, _ = srv.do(t, http.MethodPost, "/api/categories", token, category, nil)
+	if status != http.StatusForbidden {
+		t.Fatalf("create category as a user: status %d, want %d", status, http.StatusForbidden)
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	t.Parallel()
+	srv := newTestServer(t)
+	token := srv.adminToken(t)
+
+	var category model.Category
+	status, body := srv.do(t, http.MethodPost, "/api/categories", token,
+		model.CreateCategoryRequest{Name: "Bò Úc"}, &category)
+	if status != http.StatusCreated {
+		t.Fatalf("create category: status %d, %+v", status, body)
+	}
+	if category.Slug != "bo-uc" {
+		t.Errorf("category slug = %q, want %q", category.Slug, "bo-uc")
+	}
+
+	var product model.Product
+	status, body = srv.do(t, http.MethodPost, "/api/products", token, model.CreateProductRequest{
+		CategoryID: category.ID,
+		Name:       "Thăn ngoại",
+		Price:      decimal.NewFromInt(450000),
+		PriceSale:  decimal.NewFromInt(399000),
+	}, &product)
+	if status != http.StatusCreated {
+		t.Fatalf("create product: status %d, %+v", status, body)
+	}
+
+	var bySlug model.Product
+	status, body = srv.do(t, http.MethodGet, "/api/products/slug/"+product.Slug, "", nil, &bySlug)
+	if status != http.StatusOK {
+		t.Fatalf("get product by slug: status %d, %+v", status, body)
+	}
+	if bySlug.ID != product.ID || !bySlug.Price.Equal(decimal.NewFromInt(450000)) {
+		t.Errorf("product by slug = %+v, want product %d priced 450000", bySlug, product.ID)
+	}
+
+	setting := model.CreateWebsiteSettingRequest{
+		Name:  "show_product_category",
+		Value: fmt.Sprintf("[%d]", category.ID),
+	}
+	if status, body := srv.do(t, http.MethodPost, "/api/settings", token, setting, nil); status != http.StatusCreated {
+		t.Fatalf("create setting: status %d, %+v", status, body)
+	}
+	if status, _ := srv.do(t, http.MethodPost, "/api/settings", token, setting, nil); status != http.StatusConflict {
+		t.Fatalf("create the setting twice: status %d, want %d", status, http.StatusConflict)
+	}
+
+	var home []model.CategoryProductsResponse
+	status, body = srv.do(t, http.MethodGet, "/api/products/by-setting-categories", "", nil, &home)
+	if status != http.StatusOK {
+		t.Fatalf("list products by setting categories: status %d, %+v", status, body)
+	}
+	if len(home) != 1 || len(home[0].Products) != 1 || home[0].Products[0].ID != product.ID {
+		t.Errorf("products by setting categories = %+v, want product %d", home, product.ID)
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	t.Parallel()
+	srv := newTestServer(t)
+	token := srv.adminToken(t)
+
+	status, body := srv.do(t, http.MethodPost, "/api/products", token, model.CreateProductRequest{Name: "No price"}, nil)
+	if status != http.StatusBadRequest || body.Code != "validation_failed" {
+		t.Fatalf("create an invalid product: status %d, %+v", status, body)
+	}
+}
+
+func TestCouponRedemption(t *testing.T) {
+	t.Parallel()
+	srv := newTestServer(t)
+	token := srv.adminToken(t)
+
+	var category model.Category
+	if status, body := srv.do(t, http.MethodPost, "/api/categories", token,
+		model.CreateCategoryRequest{Name: "Bò Mỹ"}, &category); status != http.StatusCreated {
+		t.Fatalf("create category: status %d, %+v", status, body)
+	}
+	var product model.Product
+	if status, body := srv.do(t, http.MethodPost, "/api/products", token, model.CreateProductRequest{
+		CategoryID: category.ID,
+		Name:       "Ba chỉ",
+		Price:      decimal.NewFromInt(300000),
+	}, &product); status != http.StatusCreated {
+		t.Fatalf("create product: status %d, %+v", status, body)
+	}
+
+	// The window is sent with an offset; it has to be open now, not 7 hours later
+	vietnam := time.FixedZone("ICT", 7*60*60)
+	startsAt := time.Now().Add(-time.Hour).In(vietnam)
+	expiresAt := time.Now().Add(time.Hour).In(vietnam)
+	if status, body := srv.do(t, http.MethodPost, "/api/coupons", token, model.CreateCouponRequest{
+		Code:          "ONCE",
+		DiscountType:  model.DiscountTypePercentage,
+		DiscountValue: decimal.NewFromInt(10),
+		UsageLimit:    1,
+		StartsAt:      &startsAt,
+		ExpiresAt:     &expiresAt,
+	}, nil); status != http.StatusCreated {
+		t.Fatalf("create coupon: status %d, %+v", status, body)
+	}
+
+	cart := model.ValidateCouponRequest{Code: "once", Items: []model.CartItem{{ProductID: product.ID, Quantity: 2}}}
+	var check model.ValidateCouponResponse
+	if status, body := srv.do(t, http.MethodPost, "/api/coupons/validate", "", cart, &check); status != http.StatusOK || !check.Valid {
+		t.Fatalf("validate coupon: status %d, %+v, %+v", status, body, check)
+	}
+
+	// Concurrent redemptions of a coupon with one use left: exactly one gets it
+	var (
+		mu       sync.Mutex
+		statuses = map[int]int{}
+	)
+	t.Run("redeem", func(t *testing.T) {
+		for i := range 5 {
+			t.Run(fmt.Sprint(i), func(t *testing.T) {
+				t.Parallel()
+				status, _ := srv.do(t, http.MethodPost, "/api/admin/coupons/redeem", token, model.RedeemCouponRequest{
+					ValidateCouponRequest: cart,
+					OrderReference:        fmt.Sprintf("order-%d", i),
+				}, nil)
+				mu.Lock()
+				statuses[status]++
+				mu.Unlock()
+			})
+		}
+	})
+	if statuses[http.StatusCreated] != 1 || statuses[http.StatusConflict] != 4 {
+		t.Errorf("redemption statuses = %v, want one %d and four %d", statuses, http.StatusCreated, http.StatusConflict)
+	}
+
+	if status, body := srv.do(t, http.MethodPost, "/api/coupons/validate", "", cart, &check); status != http.StatusOK || check.Valid {
+		t.Errorf("validate a used up coupon: status %d, %+v, %+v", status, body, check)
+	}
+}
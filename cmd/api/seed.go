@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+
+	"beef-db-be/internal/config"
+	"beef-db-be/internal/repository"
+	"beef-db-be/internal/service"
+	"beef-db-be/seeds"
+)
+
+const seedUsage = `usage: api [flags] seed [--admin-email EMAIL] [--admin-password PASSWORD] [--demo] [--reset --confirm-reset]
+
+Creates the initial admin, when an admin email is set, and loads the demo catalog.
+Running it again only adds what is missing. --reset deletes all data first; it needs
+--confirm-reset and is refused unless GO_ENV is local, development or test.
+
+seed flags:`
+
+// resetEnvs are the environments whose database may be wiped by seed --reset
+var resetEnvs = []string{"local", "development", "test"}
+
+// seedOptions is a parsed seed command line
+type seedOptions struct {
+	adminEmail    string
+	adminPassword string
+	demo          bool
+	reset         bool
+	confirmReset  bool
+}
+
+// runSeed runs the seed subcommand and returns the exit code
+func runSeed(cfg *config.Config, log *slog.Logger, args []string) int {
+	opts, ok := parseSeedCommand(cfg.Seed, args)
+	if !ok {
+		return 2
+	}
+	// Reset wipes every table, so it only runs on purpose and only on local databases
+	if opts.reset {
+		if !slices.Contains(resetEnvs, cfg.Env) {
+			log.Error("Refusing to reset the database outside local environments",
+				"env", cfg.Env, "allowed", resetEnvs)
+			return 1
+		}
+		if !opts.confirmReset {
+			log.Error("Refusing to reset the database without --confirm-reset", "database", cfg.Database.Name)
+			return 1
+		}
+	}
+
+	ctx := context.Background()
+	pool, err := config.NewDBPool(cfg.Database)
+	if err != nil {
+		log.Error("Failed to create database pool", "error", err)
+		return 1
+	}
+	defer pool.Close()
+
+	queries := repository.New(pool)
+	tx := repository.NewTxRunner(pool)
+	seedService := service.NewSeedService(queries, tx,
+		service.NewCategoryService(queries, tx),
+		service.NewProductService(queries, tx),
+		service.NewPageService(queries, tx, cfg.Auth),
+		service.NewBlogPostService(queries, tx, cfg.Auth),
+		service.NewWebsiteSettingService(queries, tx),
+	)
+
+	if opts.reset {
+		if err := seedService.Reset(ctx); err != nil {
+			log.Error("Failed to reset the database", "error", err)
+			return 1
+		}
+		log.Warn("Deleted all data", "database", cfg.Database.Name)
+	}
+
+	var authorID int64
+	if opts.adminEmail != "" {
+		admin, err := seedService.EnsureAdmin(ctx, opts.adminEmail, opts.adminPassword)
+		if err != nil {
+			log.Error("Failed to seed the admin", "error", err)
+			return 1
+		}
+		authorID = admin.User.ID
+		log.Info("Admin is ready", "email", admin.User.Email,
+			"created", admin.Created, "promoted", admin.Promoted)
+	}
+
+	if opts.demo {
+		catalog, err := seeds.Demo()
+		if err != nil {
+			log.Error("Failed to read the demo catalog", "error", err)
+			return 1
+		}
+		result, err := seedService.LoadDemo(ctx, *catalog, authorID)
+		if err != nil {
+			log.Error("Failed to load the demo catalog", "error", err)
+			return 1
+		}
+		log.Info("Demo catalog loaded", "version", result.Version,
+			"created", result.Created, "skipped", result.Skipped)
+	}
+	return 0
+}
+
+// parseSeedCommand reads the seed flags over the configured admin account. It prints
+// the usage and returns false when the command line is invalid or asks for nothing.
+func parseSeedCommand(cfg config.SeedConfig, args []string) (seedOptions, bool) {
+	opts := seedOptions{adminEmail: cfg.AdminEmail, adminPassword: cfg.AdminPassword}
+
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&opts.adminEmail, "admin-email", opts.adminEmail, "email of the admin account (SEED_ADMIN_EMAIL)")
+	fs.StringVar(&opts.adminPassword, "admin-password", opts.adminPassword,
+		"password of the admin account when it is created (SEED_ADMIN_PASSWORD)")
+	fs.BoolVar(&opts.demo, "demo", false, "load the demo catalog from "+seeds.DemoFile)
+	fs.BoolVar(&opts.reset, "reset", false,
+		"delete all data first, keeping the schema; only when GO_ENV is local, development or test")
+	fs.BoolVar(&opts.confirmReset, "confirm-reset", false, "confirm that --reset may delete all data")
+	usage := func() {
+		fs.SetOutput(os.Stderr)
+		fmt.Fprintln(os.Stderr, seedUsage)
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		usage()
+		return opts, false
+	}
+	if fs.NArg() > 0 || (opts.adminEmail == "" && !opts.demo && !opts.reset) {
+		usage()
+		return opts, false
+	}
+	return opts, true
+}
@@ -0,0 +1,40 @@
+// Package buildinfo reports which build of the API is running.
+package buildinfo
+
+import (
+	"runtime/debug"
+	"time"
+)
+
+// Version and Commit are set at build time, e.g.
+//
+//	go build -ldflags "-X beef-db-be/internal/buildinfo.Version=v1.2.0 -X beef-db-be/internal/buildinfo.Commit=abc123"
+//
+// Without them Commit falls back to the VCS revision Go embeds in the binary.
+var (
+	Version = "dev"
+	Commit  = ""
+)
+
+// StartedAt is when the process started
+var StartedAt = time.Now()
+
+// GetCommit returns Commit, or the embedded VCS revision when it was not set
+func GetCommit() string {
+	if Commit != "" {
+		return Commit
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				return setting.Value
+			}
+		}
+	}
+	return "unknown"
+}
+
+// Uptime is how long the process has been running
+func Uptime() time.Duration {
+	return time.Since(StartedAt)
+}
@@ -0,0 +1,416 @@
+// Package config loads the typed configuration of the API from a YAML file and
+// environment variables, and opens the database pool it describes.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPath is read when no config file is given. It may be absent, in which case
+// the defaults and environment variables are used alone.
+const DefaultPath = "configs/config.yaml"
+
+const redacted = "[REDACTED]"
+
+// Config is the whole configuration of the API. Values come from the defaults, then
+// the YAML file, then the environment variable named in each env tag.
+type Config struct {
+	// Env is the deployment environment, e.g. local, development or production
+	Env      string         `yaml:"env" env:"GO_ENV"`
+	Server   ServerConfig   `yaml:"server"`
+	Site     SiteConfig     `yaml:"site"`
+	Database DatabaseConfig `yaml:"database"`
+	Auth     AuthConfig     `yaml:"auth"`
+	CORS     CORSConfig     `yaml:"cors"`
+	Locale   LocaleConfig   `yaml:"locale"`
+	Log      LogConfig      `yaml:"log"`
+	Tracing  TracingConfig  `yaml:"tracing"`
+	Metrics  MetricsConfig  `yaml:"metrics"`
+	Seed     SeedConfig     `yaml:"seed"`
+}
+
+// ServerConfig configures the HTTP server and its shutdown
+type ServerConfig struct {
+	Port              int           `yaml:"port" env:"PORT"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"SERVER_READ_TIMEOUT"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT"`
+	// MaxHeaderBytes caps the size of request headers, including the request line
+	MaxHeaderBytes int `yaml:"max_header_bytes" env:"SERVER_MAX_HEADER_BYTES"`
+	// ShutdownDrainDelay is how long to keep serving after readiness fails, so load
+	// balancers notice before connections are drained
+	ShutdownDrainDelay time.Duration `yaml:"shutdown_drain_delay" env:"SHUTDOWN_DRAIN_DELAY"`
+	// ShutdownGracePeriod is the longest wait for in-flight requests on shutdown
+	ShutdownGracePeriod time.Duration `yaml:"shutdown_grace_period" env:"SHUTDOWN_GRACE_PERIOD"`
+}
+
+// Addr is the listen address of the server
+func (c ServerConfig) Addr() string {
+	return ":" + strconv.Itoa(c.Port)
+}
+
+// SiteConfig describes the public site the API serves
+type SiteConfig struct {
+	// URL is the base of absolute links in feeds and sitemaps, e.g.
+	// https://beefsupplier.store. The site_url website setting overrides it.
+	URL string `yaml:"url" env:"SITE_URL"`
+}
+
+// DatabaseConfig configures the PostgreSQL connection pool
+type DatabaseConfig struct {
+	Host     string `yaml:"host" env:"DB_HOST"`
+	Port     int    `yaml:"port" env:"DB_PORT"`
+	User     string `yaml:"user" env:"DB_USER"`
+	Password string `yaml:"password" env:"DB_PASSWORD" secret:"true"`
+	Name     string `yaml:"name" env:"DB_NAME"`
+	// SSLMode is a libpq sslmode such as disable, require or verify-full
+	SSLMode  string `yaml:"sslmode" env:"DB_SSLMODE"`
+	MaxConns int32  `yaml:"max_conns" env:"DB_MAX_CONNS"`
+	MinConns int32  `yaml:"min_conns" env:"DB_MIN_CONNS"`
+	// MigrateOnStart applies pending migrations before the server starts
+	MigrateOnStart bool `yaml:"migrate_on_start" env:"DB_MIGRATE_ON_START"`
+}
+
+// AuthConfig configures login tokens, preview tokens and the auth cookie
+type AuthConfig struct {
+	// JWTSecret signs login and preview tokens. It is required.
+	JWTSecret        string `yaml:"jwt_secret" env:"JWT_SECRET" secret:"true"`
+	TokenExpiryHours int    `yaml:"token_expiry_hours" env:"JWT_EXPIRY_HOURS"`
+	// CookieDomain is the domain of the auth cookie, e.g. .example.com to share it
+	// between subdomains
+	CookieDomain string `yaml:"cookie_domain" env:"COOKIE_DOMAIN"`
+	// CookieSecure sends the auth cookie over HTTPS only
+	CookieSecure bool `yaml:"cookie_secure" env:"COOKIE_SECURE"`
+}
+
+// TokenExpiry is how long a login token, and the cookie holding it, stays valid
+func (c AuthConfig) TokenExpiry() time.Duration {
+	return time.Duration(c.TokenExpiryHours) * time.Hour
+}
+
+// CORSConfig lists the origins allowed to call the API from a browser
+type CORSConfig struct {
+	AllowedOrigins []string `yaml:"allowed_origins" env:"ALLOWED_ORIGINS"`
+}
+
+// LocaleConfig lists the content locales. The default locale is the one stored in
+// the entity tables themselves.
+type LocaleConfig struct {
+	Default   string   `yaml:"default" env:"DEFAULT_LOCALE"`
+	Supported []string `yaml:"supported" env:"SUPPORTED_LOCALES"`
+}
+
+// LogConfig configures the application logger
+type LogConfig struct {
+	// Level is debug, info, warn or error
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+	// Format is json or text. It defaults to json in production and text elsewhere.
+	Format string `yaml:"format" env:"LOG_FORMAT"`
+}
+
+// TracingConfig configures OpenTelemetry tracing
+type TracingConfig struct {
+	// Exporter is otlp, stdout, console or none
+	Exporter    string `yaml:"exporter" env:"OTEL_TRACES_EXPORTER"`
+	ServiceName string `yaml:"service_name" env:"OTEL_SERVICE_NAME"`
+}
+
+// MetricsConfig configures the internal Prometheus metrics server
+type MetricsConfig struct {
+	Addr string `yaml:"addr" env:"METRICS_ADDR"`
+}
+
+// SeedConfig configures the seed command
+type SeedConfig struct {
+	// AdminEmail and AdminPassword are the initial admin account created by seed
+	AdminEmail    string `yaml:"admin_email" env:"SEED_ADMIN_EMAIL"`
+	AdminPassword string `yaml:"admin_password" env:"SEED_ADMIN_PASSWORD" secret:"true"`
+}
+
+// Default returns the configuration used when neither the file nor the environment
+// sets a value
+func Default() Config {
+	return Config{
+		Env: "local",
+		Server: ServerConfig{
+			Port:                80,
+			ReadHeaderTimeout:   5 * time.Second,
+			ReadTimeout:         15 * time.Second,
+			WriteTimeout:        60 * time.Second,
+			IdleTimeout:         120 * time.Second,
+			MaxHeaderBytes:      64 << 10,
+			ShutdownDrainDelay:  5 * time.Second,
+			ShutdownGracePeriod: 30 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Host:     "localhost",
+			Port:     5432,
+			SSLMode:  "require",
+			MaxConns: 25,
+			MinConns: 5,
+		},
+		Auth: AuthConfig{
+			TokenExpiryHours: 24,
+			CookieDomain:     "localhost",
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: []string{"http://localhost:3000"},
+		},
+		Locale: LocaleConfig{
+			Default:   "vi",
+			Supported: []string{"vi", "en"},
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			ServiceName: "beef-db-be",
+		},
+		Metrics: MetricsConfig{
+			Addr: ":9090",
+		},
+	}
+}
+
+// Load reads the YAML file at path over the defaults and applies the environment
+// variables on top. An empty path reads DefaultPath if it exists. The result is not
+// validated, so that it can still be printed; call Validate before using it.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	optional := path == ""
+	if optional {
+		path = DefaultPath
+	}
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		// Unknown keys are rejected so a misspelt setting does not silently fall back
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("error parsing config file %s: %w", path, err)
+		}
+	case optional && errors.Is(err, os.ErrNotExist):
+	default:
+		return nil, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	if err := applyEnv(reflect.ValueOf(&cfg).Elem()); err != nil {
+		return nil, err
+	}
+
+	if cfg.Log.Format == "" {
+		cfg.Log.Format = "text"
+		if cfg.Env == "production" {
+			cfg.Log.Format = "json"
+		}
+	}
+
+	return &cfg, nil
+}
+
+// Validate reports every invalid setting at once, naming them by environment variable
+func (c *Config) Validate() error {
+	var p problems
+
+	p.check(validPort(c.Server.Port), "PORT must be between 1 and 65535, got %d", c.Server.Port)
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"SERVER_READ_HEADER_TIMEOUT", c.Server.ReadHeaderTimeout},
+		{"SERVER_READ_TIMEOUT", c.Server.ReadTimeout},
+		{"SERVER_WRITE_TIMEOUT", c.Server.WriteTimeout},
+		{"SERVER_IDLE_TIMEOUT", c.Server.IdleTimeout},
+		{"SHUTDOWN_DRAIN_DELAY", c.Server.ShutdownDrainDelay},
+		{"SHUTDOWN_GRACE_PERIOD", c.Server.ShutdownGracePeriod},
+	} {
+		p.check(d.value >= 0, "%s must not be negative", d.name)
+	}
+	p.check(c.Server.MaxHeaderBytes >= 1<<10 && c.Server.MaxHeaderBytes <= 1<<20,
+		"SERVER_MAX_HEADER_BYTES must be between 1024 and 1048576, got %d", c.Server.MaxHeaderBytes)
+
+	if c.Site.URL != "" {
+		u, err := url.Parse(c.Site.URL)
+		p.check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "",
+			"SITE_URL must be an absolute http or https URL, got %q", c.Site.URL)
+	}
+
+	c.Database.validate(&p)
+
+	p.check(c.Auth.JWTSecret != "", "JWT_SECRET is required")
+	p.check(c.Auth.TokenExpiryHours > 0, "JWT_EXPIRY_HOURS must be positive")
+	if c.Env == "production" {
+		// The local defaults would break logins or send the cookie over plain HTTP
+		p.check(c.Auth.CookieDomain != "" && c.Auth.CookieDomain != "localhost",
+			"COOKIE_DOMAIN must be set to the site domain in production, got %q", c.Auth.CookieDomain)
+		p.check(c.Auth.CookieSecure, "COOKIE_SECURE must be true in production")
+	}
+
+	p.check(c.Locale.Default != "", "DEFAULT_LOCALE is required")
+
+	p.check(slices.Contains([]string{"debug", "info", "warn", "warning", "error"}, strings.ToLower(c.Log.Level)),
+		"LOG_LEVEL must be debug, info, warn or error, got %q", c.Log.Level)
+	p.check(slices.Contains([]string{"json", "text"}, strings.ToLower(c.Log.Format)),
+		"LOG_FORMAT must be json or text, got %q", c.Log.Format)
+
+	p.check(slices.Contains([]string{"", "none", "otlp", "stdout", "console"}, strings.ToLower(c.Tracing.Exporter)),
+		"OTEL_TRACES_EXPORTER must be otlp, stdout or none, got %q", c.Tracing.Exporter)
+
+	p.check(c.Metrics.Addr != "", "METRICS_ADDR is required")
+
+	return p.err()
+}
+
+// Validate reports invalid database settings only, for commands that need nothing else
+func (c DatabaseConfig) Validate() error {
+	var p problems
+	c.validate(&p)
+	return p.err()
+}
+
+func (c DatabaseConfig) validate(p *problems) {
+	p.check(c.Host != "", "DB_HOST is required")
+	p.check(validPort(c.Port), "DB_PORT must be between 1 and 65535, got %d", c.Port)
+	p.check(c.User != "", "DB_USER is required")
+	p.check(c.Name != "", "DB_NAME is required")
+	p.check(slices.Contains([]string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}, c.SSLMode),
+		"DB_SSLMODE must be disable, allow, prefer, require, verify-ca or verify-full, got %q", c.SSLMode)
+	p.check(c.MaxConns > 0, "DB_MAX_CONNS must be positive")
+	p.check(c.MinConns >= 0 && c.MinConns <= c.MaxConns, "DB_MIN_CONNS must be between 0 and DB_MAX_CONNS")
+}
+
+// problems collects validation errors
+type problems []error
+
+func (p *problems) check(ok bool, format string, args ...any) {
+	if !ok {
+		*p = append(*p, fmt.Errorf(format, args...))
+	}
+}
+
+func (p problems) err() error {
+	if len(p) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(p...))
+	}
+	return nil
+}
+
+// Redacted returns a copy of the configuration with every secret replaced, safe to
+// print or log
+func (c Config) Redacted() Config {
+	redactSecrets(reflect.ValueOf(&c).Elem())
+	return c
+}
+
+// Print writes the redacted configuration to w as YAML
+func (c Config) Print(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c.Redacted()); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
+
+// applyEnv sets each field with an env tag from its environment variable when the
+// variable is set, descending into nested sections
+func applyEnv(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			if err := applyEnv(field); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := t.Field(i).Tag.Get("env")
+		value, ok := os.LookupEnv(name)
+		if name == "" || !ok {
+			continue
+		}
+		if err := setField(field, strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// setField parses value into field. Durations are written like 30s and lists are
+// comma separated.
+func setField(field reflect.Value, value string) error {
+	switch field.Interface().(type) {
+	case time.Duration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	case []string:
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
+
+// redactSecrets replaces the non-empty fields tagged secret
+func redactSecrets(v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			redactSecrets(field)
+			continue
+		}
+		if t.Field(i).Tag.Get("secret") == "true" && field.String() != "" {
+			field.SetString(redacted)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// valid returns a configuration passing Validate
+func valid() Config {
+	cfg := Default()
+	cfg.Database.User = "beef"
+	cfg.Database.Name = "beef"
+	cfg.Auth.JWTSecret = "secret"
+	cfg.Log.Format = "text"
+	return cfg
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(*Config)
+		wantErr string
+	}{
+		{name: "defaults", change: func(*Config) {}},
+		{
+			name: "production with cookie settings",
+			change: func(c *Config) {
+				c.Env = "production"
+				c.Auth.CookieDomain = ".beefsupplier.store"
+				c.Auth.CookieSecure = true
+			},
+		},
+		{
+			name:    "production with the local cookie domain",
+			change:  func(c *Config) { c.Env = "production"; c.Auth.CookieSecure = true },
+			wantErr: "COOKIE_DOMAIN",
+		},
+		{
+			name:    "production without secure cookies",
+			change:  func(c *Config) { c.Env = "production"; c.Auth.CookieDomain = ".beefsupplier.store" },
+			wantErr: "COOKIE_SECURE",
+		},
+		{
+			name:   "site URL",
+			change: func(c *Config) { c.Site.URL = "https://beefsupplier.store" },
+		},
+		{
+			name:    "relative site URL",
+			change:  func(c *Config) { c.Site.URL = "beefsupplier.store" },
+			wantErr: "SITE_URL",
+		},
+		{
+			name:    "header limit too large",
+			change:  func(c *Config) { c.Server.MaxHeaderBytes = 4 << 20 },
+			wantErr: "SERVER_MAX_HEADER_BYTES",
+		},
+		{
+			name:    "missing secret",
+			change:  func(c *Config) { c.Auth.JWTSecret = "" },
+			wantErr: "JWT_SECRET",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.change(&cfg)
+			err := cfg.Validate()
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Validate: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("Validate = %v, want an error about %s", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -3,31 +3,51 @@ package config
 import (
 	"context"
 	"fmt"
-	"os"
+	"net/url"
+	"strconv"
 
+	"github.com/exaring/otelpgx"
+	pgxdecimal "github.com/jackc/pgx-shopspring-decimal"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-// NewDBPool creates a new connection pool to the database
-func NewDBPool() (*pgxpool.Pool, error) {
-	// Construct connection string
-	connString := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=require",
-		os.Getenv("DB_USER"),
-		os.Getenv("DB_PASSWORD"),
-		os.Getenv("DB_HOST"),
-		os.Getenv("DB_PORT"),
-		os.Getenv("DB_NAME"),
-	)
+// ConnString returns the connection URL of the database, with the user and password escaped
+func (c DatabaseConfig) ConnString() string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     c.Host + ":" + strconv.Itoa(c.Port),
+		Path:     "/" + c.Name,
+		RawQuery: url.Values{"sslmode": {c.SSLMode}}.Encode(),
+	}
+	return u.String()
+}
 
+// NewDBPool creates a new connection pool to the database
+func NewDBPool(cfg DatabaseConfig) (*pgxpool.Pool, error) {
 	// Create connection pool
-	config, err := pgxpool.ParseConfig(connString)
+	config, err := pgxpool.ParseConfig(cfg.ConnString())
 	if err != nil {
 		return nil, fmt.Errorf("error parsing database config: %v", err)
 	}
 
 	// Set pool configuration
-	config.MaxConns = 25
-	config.MinConns = 5
+	config.MaxConns = cfg.MaxConns
+	config.MinConns = cfg.MinConns
+
+	// TIMESTAMP columns hold UTC, so CURRENT_TIMESTAMP has to be read in UTC when it
+	// is compared with them
+	config.ConnConfig.RuntimeParams["timezone"] = "UTC"
+
+	// Trace every query as a child span of the request
+	config.ConnConfig.Tracer = otelpgx.NewTracer()
+
+	// Scan and encode NUMERIC columns as exact decimals for money
+	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+		pgxdecimal.Register(conn.TypeMap())
+		return nil
+	}
 
 	// Create the connection pool
 	pool, err := pgxpool.NewWithConfig(context.Background(), config)
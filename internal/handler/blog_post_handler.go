@@ -1,39 +1,34 @@
 package handler
 
 import (
-	"encoding/json"
 	"net/http"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
 
+	"beef-db-be/internal/middleware"
 	"beef-db-be/internal/model"
-	"beef-db-be/internal/service"
 	"beef-db-be/internal/utils"
 )
 
 type BlogPostHandler struct {
-	service *service.BlogPostService
+	service BlogPostService
 }
 
-func NewBlogPostHandler(service *service.BlogPostService) *BlogPostHandler {
+func NewBlogPostHandler(service BlogPostService) *BlogPostHandler {
 	return &BlogPostHandler{service: service}
 }
 
 func (h *BlogPostHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req model.CreateBlogPostRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Invalid request body", []model.ValidationError{
-				model.NewValidationError("body", "Invalid JSON format"),
-			}))
+	if !utils.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
-	post, err := h.service.Create(r.Context(), req)
+	authorID, _ := middleware.GetUserID(r)
+	post, err := h.service.Create(r.Context(), req, authorID)
 	if err != nil {
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to create blog post", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to create blog post")
 		return
 	}
 
@@ -51,15 +46,9 @@ func (h *BlogPostHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	post, err := h.service.GetByID(r.Context(), id)
+	post, err := h.service.GetPublishedByID(r.Context(), id)
 	if err != nil {
-		if err == service.ErrNotFound {
-			utils.SendResponse(w, http.StatusNotFound,
-				model.NewErrorResponse("Blog post not found", err.Error()))
-			return
-		}
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to get blog post", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to get blog post")
 		return
 	}
 
@@ -77,15 +66,13 @@ func (h *BlogPostHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	post, err := h.service.GetBySlug(r.Context(), slug)
+	post, moved, err := h.service.GetBySlug(r.Context(), slug)
 	if err != nil {
-		if err == service.ErrNotFound {
-			utils.SendResponse(w, http.StatusNotFound,
-				model.NewErrorResponse("Blog post not found", err.Error()))
-			return
-		}
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to get blog post", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to get blog post")
+		return
+	}
+	if moved {
+		respondWithSlugRedirect(w, r, post.Slug, "Blog post has moved to a new slug", post)
 		return
 	}
 
@@ -96,10 +83,29 @@ func (h *BlogPostHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
 func (h *BlogPostHandler) List(w http.ResponseWriter, r *http.Request) {
 	pagination := utils.GetPaginationFromRequest(r)
 
+	filter := model.BlogPostFilter{
+		Tag:      r.URL.Query().Get("tag"),
+		Category: r.URL.Query().Get("category"),
+	}
+
+	posts, totalCount, err := h.service.ListPublished(r.Context(), pagination.GetLimit(), pagination.GetOffset(), filter)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to list blog posts")
+		return
+	}
+
+	paginatedResp := model.NewPaginatedResponse(posts, totalCount, pagination.Page, pagination.PageSize)
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Blog posts retrieved successfully", paginatedResp))
+}
+
+// AdminList handles listing blog posts of every status for admins
+func (h *BlogPostHandler) AdminList(w http.ResponseWriter, r *http.Request) {
+	pagination := utils.GetPaginationFromRequest(r)
+
 	posts, totalCount, err := h.service.List(r.Context(), pagination.GetLimit(), pagination.GetOffset())
 	if err != nil {
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to list blog posts", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to list blog posts")
 		return
 	}
 
@@ -108,7 +114,8 @@ func (h *BlogPostHandler) List(w http.ResponseWriter, r *http.Request) {
 		model.NewSuccessResponse("Blog posts retrieved successfully", paginatedResp))
 }
 
-func (h *BlogPostHandler) Update(w http.ResponseWriter, r *http.Request) {
+// AdminGetByID handles retrieving a blog post of any status for admins
+func (h *BlogPostHandler) AdminGetByID(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 	if err != nil {
 		utils.SendResponse(w, http.StatusBadRequest,
@@ -118,23 +125,76 @@ func (h *BlogPostHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var req model.UpdateBlogPostRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	post, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to get blog post")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Blog post retrieved successfully", post))
+}
+
+// CreatePreviewToken handles issuing a preview link token for an unpublished blog post
+func (h *BlogPostHandler) CreatePreviewToken(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
 		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Invalid request body", []model.ValidationError{
-				model.NewValidationError("body", "Invalid JSON format"),
+			model.NewErrorResponse("Invalid ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
 			}))
 		return
 	}
 
-	if err := h.service.Update(r.Context(), id, req); err != nil {
-		if err == service.ErrNotFound {
-			utils.SendResponse(w, http.StatusNotFound,
-				model.NewErrorResponse("Blog post not found", err.Error()))
-			return
-		}
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to update blog post", err.Error()))
+	token, err := h.service.CreatePreviewToken(r.Context(), id)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to create preview token")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusCreated,
+		model.NewSuccessResponse("Preview token created successfully", token))
+}
+
+// Preview handles retrieving a blog post through a preview token, whatever its status
+func (h *BlogPostHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid preview token", []model.ValidationError{
+				model.NewValidationError("token", "Token is required"),
+			}))
+		return
+	}
+
+	post, err := h.service.GetPreview(r.Context(), token)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to get blog post preview")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Blog post retrieved successfully", post))
+}
+
+func (h *BlogPostHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+
+	var req model.UpdateBlogPostRequest
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	authorID, _ := middleware.GetUserID(r)
+	if err := h.service.Update(r.Context(), id, req, authorID); err != nil {
+		respondWithServiceError(w, r, err, "Failed to update blog post")
 		return
 	}
 
@@ -153,16 +213,118 @@ func (h *BlogPostHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.Delete(r.Context(), id); err != nil {
-		if err == service.ErrNotFound {
-			utils.SendResponse(w, http.StatusNotFound,
-				model.NewErrorResponse("Blog post not found", err.Error()))
-			return
-		}
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to delete blog post", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to delete blog post")
 		return
 	}
 
 	utils.SendResponse(w, http.StatusOK,
 		model.NewSuccessResponse("Blog post deleted successfully", nil))
 }
+
+// ListRevisions handles listing the revisions of a blog post, newest first
+func (h *BlogPostHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+
+	pagination := utils.GetPaginationFromRequest(r)
+	revisions, totalCount, err := h.service.ListRevisions(r.Context(), id, pagination)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to list revisions")
+		return
+	}
+
+	paginatedResp := model.NewPaginatedResponse(revisions, totalCount, pagination.Page, pagination.PageSize)
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Revisions retrieved successfully", paginatedResp))
+}
+
+// GetRevision handles retrieving a single revision of a blog post
+func (h *BlogPostHandler) GetRevision(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+	revisionID, err := strconv.ParseInt(chi.URLParam(r, "revisionId"), 10, 64)
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid revision ID", []model.ValidationError{
+				model.NewValidationError("revisionId", "Must be a valid number"),
+			}))
+		return
+	}
+
+	revision, err := h.service.GetRevision(r.Context(), id, revisionID)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to get revision")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Revision retrieved successfully", revision))
+}
+
+// DiffRevisions handles comparing the revisions given by the from and to query parameters
+func (h *BlogPostHandler) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+
+	fromID, toID, ok := parseRevisionRange(w, r)
+	if !ok {
+		return
+	}
+
+	diff, err := h.service.DiffRevisions(r.Context(), id, fromID, toID)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to diff revisions")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Revisions compared successfully", diff))
+}
+
+// RestoreRevision handles restoring an old revision as the current version of a blog post
+func (h *BlogPostHandler) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+	revisionID, err := strconv.ParseInt(chi.URLParam(r, "revisionId"), 10, 64)
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid revision ID", []model.ValidationError{
+				model.NewValidationError("revisionId", "Must be a valid number"),
+			}))
+		return
+	}
+
+	authorID, _ := middleware.GetUserID(r)
+	post, err := h.service.RestoreRevision(r.Context(), id, revisionID, authorID)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to restore revision")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Revision restored successfully", post))
+}
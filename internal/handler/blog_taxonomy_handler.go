@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/utils"
+)
+
+type BlogTaxonomyHandler struct {
+	taxonomyService BlogTaxonomyService
+}
+
+func NewBlogTaxonomyHandler(taxonomyService BlogTaxonomyService) *BlogTaxonomyHandler {
+	return &BlogTaxonomyHandler{
+		taxonomyService: taxonomyService,
+	}
+}
+
+// CreateCategory handles blog category creation
+func (h *BlogTaxonomyHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	var req model.CreateBlogCategoryRequest
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	category, err := h.taxonomyService.CreateCategory(r.Context(), req)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to create blog category")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusCreated,
+		model.NewSuccessResponse("Blog category created successfully", category))
+}
+
+// ListCategories handles listing blog categories with their post counts
+func (h *BlogTaxonomyHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.taxonomyService.ListCategories(r.Context())
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to list blog categories")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Blog categories retrieved successfully", categories))
+}
+
+// UpdateCategory handles updating a blog category
+func (h *BlogTaxonomyHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid blog category ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+
+	var req model.UpdateBlogCategoryRequest
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	category, err := h.taxonomyService.UpdateCategory(r.Context(), int32(id), req)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to update blog category")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Blog category updated successfully", category))
+}
+
+// DeleteCategory handles deleting a blog category
+func (h *BlogTaxonomyHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid blog category ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+
+	if err := h.taxonomyService.DeleteCategory(r.Context(), int32(id)); err != nil {
+		respondWithServiceError(w, r, err, "Failed to delete blog category")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Blog category deleted successfully", nil))
+}
+
+// ListTags handles listing blog tags with their post counts
+func (h *BlogTaxonomyHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.taxonomyService.ListTags(r.Context())
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to list blog tags")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Blog tags retrieved successfully", tags))
+}
+
+// DeleteTag handles deleting a blog tag, which also removes it from every post
+func (h *BlogTaxonomyHandler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid blog tag ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+
+	if err := h.taxonomyService.DeleteTag(r.Context(), int32(id)); err != nil {
+		respondWithServiceError(w, r, err, "Failed to delete blog tag")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Blog tag deleted successfully", nil))
+}
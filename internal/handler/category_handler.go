@@ -1,22 +1,20 @@
 package handler
 
 import (
-	"encoding/json"
 	"net/http"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
 
 	"beef-db-be/internal/model"
-	"beef-db-be/internal/service"
 	"beef-db-be/internal/utils"
 )
 
 type CategoryHandler struct {
-	categoryService *service.CategoryService
+	categoryService CategoryService
 }
 
-func NewCategoryHandler(categoryService *service.CategoryService) *CategoryHandler {
+func NewCategoryHandler(categoryService CategoryService) *CategoryHandler {
 	return &CategoryHandler{
 		categoryService: categoryService,
 	}
@@ -25,18 +23,13 @@ func NewCategoryHandler(categoryService *service.CategoryService) *CategoryHandl
 // CreateCategory handles category creation
 func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
 	var req model.CreateCategoryRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Invalid request body", []model.ValidationError{
-				model.NewValidationError("body", "Invalid JSON format"),
-			}))
+	if !utils.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
 	category, err := h.categoryService.CreateCategory(r.Context(), req)
 	if err != nil {
-		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Failed to create category", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to create category")
 		return
 	}
 
@@ -58,8 +51,7 @@ func (h *CategoryHandler) GetCategory(w http.ResponseWriter, r *http.Request) {
 
 	category, err := h.categoryService.GetCategory(r.Context(), id)
 	if err != nil {
-		utils.SendResponse(w, http.StatusNotFound,
-			model.NewErrorResponse("Category not found", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to get category")
 		return
 	}
 
@@ -72,8 +64,7 @@ func (h *CategoryHandler) GetCategoryBySlug(w http.ResponseWriter, r *http.Reque
 	slug := chi.URLParam(r, "slug")
 	category, err := h.categoryService.GetCategoryBySlug(r.Context(), slug)
 	if err != nil {
-		utils.SendResponse(w, http.StatusNotFound,
-			model.NewErrorResponse("Category not found", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to get category")
 		return
 	}
 
@@ -85,8 +76,7 @@ func (h *CategoryHandler) GetCategoryBySlug(w http.ResponseWriter, r *http.Reque
 func (h *CategoryHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
 	categories, err := h.categoryService.ListCategories(r.Context())
 	if err != nil {
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to retrieve categories", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to retrieve categories")
 		return
 	}
 
@@ -107,18 +97,13 @@ func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request)
 	}
 
 	var req model.UpdateCategoryRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Invalid request body", []model.ValidationError{
-				model.NewValidationError("body", "Invalid JSON format"),
-			}))
+	if !utils.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
 	category, err := h.categoryService.UpdateCategory(r.Context(), id, req)
 	if err != nil {
-		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Failed to update category", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to update category")
 		return
 	}
 
@@ -139,8 +124,7 @@ func (h *CategoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := h.categoryService.DeleteCategory(r.Context(), id); err != nil {
-		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Failed to delete category", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to delete category")
 		return
 	}
 
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"beef-db-be/internal/config"
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/utils"
+)
+
+// CouponHandler handles HTTP requests related to coupons
+type CouponHandler struct {
+	couponService CouponService
+	auth          config.AuthConfig
+}
+
+// NewCouponHandler creates a new CouponHandler instance. auth validates the optional
+// login cookie of coupon checks.
+func NewCouponHandler(couponService CouponService, auth config.AuthConfig) *CouponHandler {
+	return &CouponHandler{
+		couponService: couponService,
+		auth:          auth,
+	}
+}
+
+// CreateCoupon handles coupon creation
+func (h *CouponHandler) CreateCoupon(w http.ResponseWriter, r *http.Request) {
+	var req model.CreateCouponRequest
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	coupon, err := h.couponService.CreateCoupon(r.Context(), req)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to create coupon")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusCreated,
+		model.NewSuccessResponse("Coupon created successfully", coupon))
+}
+
+// GetCoupon retrieves a coupon by ID
+func (h *CouponHandler) GetCoupon(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid coupon ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+
+	coupon, err := h.couponService.GetCoupon(r.Context(), id)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to get coupon")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Coupon retrieved successfully", coupon))
+}
+
+// ListCoupons retrieves a paginated list of coupons
+func (h *CouponHandler) ListCoupons(w http.ResponseWriter, r *http.Request) {
+	pagination := utils.GetPaginationFromRequest(r)
+
+	coupons, totalCount, err := h.couponService.ListCoupons(r.Context(), pagination)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to retrieve coupons")
+		return
+	}
+
+	paginatedResp := model.NewPaginatedResponse(coupons, totalCount, pagination.Page, pagination.PageSize)
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Coupons retrieved successfully", paginatedResp))
+}
+
+// UpdateCoupon updates a coupon
+func (h *CouponHandler) UpdateCoupon(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid coupon ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+
+	var req model.UpdateCouponRequest
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	coupon, err := h.couponService.UpdateCoupon(r.Context(), id, req)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to update coupon")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Coupon updated successfully", coupon))
+}
+
+// DeleteCoupon deletes a coupon
+func (h *CouponHandler) DeleteCoupon(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid coupon ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+
+	if err := h.couponService.DeleteCoupon(r.Context(), id); err != nil {
+		respondWithServiceError(w, r, err, "Failed to delete coupon")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Coupon deleted successfully", nil))
+}
+
+// ValidateCoupon checks a coupon code against the submitted cart and returns the discount breakdown
+func (h *CouponHandler) ValidateCoupon(w http.ResponseWriter, r *http.Request) {
+	var req model.ValidateCouponRequest
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	// The endpoint is public; a logged-in user is only needed for per-user limits
+	var userID int64
+	if cookie, err := r.Cookie(utils.TokenCookieName); err == nil {
+		if claims, err := utils.ValidateJWT(h.auth, cookie.Value); err == nil {
+			userID = claims.UserID
+		}
+	}
+
+	result, err := h.couponService.ValidateCoupon(r.Context(), userID, req)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to validate coupon")
+		return
+	}
+
+	message := "Coupon applied successfully"
+	if !result.Valid {
+		message = "Coupon cannot be applied"
+	}
+	utils.SendResponse(w, http.StatusOK, model.NewSuccessResponse(message, result))
+}
+
+// RedeemCoupon records a coupon use for a placed order, which counts towards the usage
+// limits of the coupon
+func (h *CouponHandler) RedeemCoupon(w http.ResponseWriter, r *http.Request) {
+	var req model.RedeemCouponRequest
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	redemption, err := h.couponService.RedeemCoupon(r.Context(), req)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to redeem coupon")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusCreated,
+		model.NewSuccessResponse("Coupon redeemed successfully", redemption))
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"beef-db-be/internal/middleware"
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/utils"
+)
+
+type CurrencyHandler struct {
+	currencyService CurrencyService
+}
+
+func NewCurrencyHandler(currencyService CurrencyService) *CurrencyHandler {
+	return &CurrencyHandler{
+		currencyService: currencyService,
+	}
+}
+
+// ListRates handles listing the base currency and the exchange rates currently in effect
+func (h *CurrencyHandler) ListRates(w http.ResponseWriter, r *http.Request) {
+	rates, err := h.currencyService.ListCurrentRates(r.Context())
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to list exchange rates")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Exchange rates retrieved successfully", rates))
+}
+
+// ListRateHistory handles listing every rate of a currency, newest first
+func (h *CurrencyHandler) ListRateHistory(w http.ResponseWriter, r *http.Request) {
+	pagination := utils.GetPaginationFromRequest(r)
+
+	rates, totalCount, err := h.currencyService.ListRateHistory(r.Context(), chi.URLParam(r, "currency"), pagination)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to list exchange rate history")
+		return
+	}
+
+	paginatedResp := model.NewPaginatedResponse(rates, totalCount, pagination.Page, pagination.PageSize)
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Exchange rate history retrieved successfully", paginatedResp))
+}
+
+// CreateRate handles setting a new exchange rate for a currency
+func (h *CurrencyHandler) CreateRate(w http.ResponseWriter, r *http.Request) {
+	var req model.CreateExchangeRateRequest
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	userID, _ := middleware.GetUserID(r)
+	rate, err := h.currencyService.CreateRate(r.Context(), userID, req)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to create exchange rate")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusCreated,
+		model.NewSuccessResponse("Exchange rate created successfully", rate))
+}
+
+// DeleteRate handles removing an exchange rate entered by mistake
+func (h *CurrencyHandler) DeleteRate(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+
+	if err := h.currencyService.DeleteRate(r.Context(), id); err != nil {
+		respondWithServiceError(w, r, err, "Failed to delete exchange rate")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Exchange rate deleted successfully", nil))
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"beef-db-be/internal/logger"
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/service"
+	"beef-db-be/internal/utils"
+)
+
+// Error codes used when a service returns a bare sentinel error instead of a *service.Error
+const (
+	codeNotFound     = "not_found"
+	codeConflict     = "conflict"
+	codeValidation   = "validation_failed"
+	codeUnauthorized = "unauthorized"
+	codeForbidden    = "forbidden"
+	codeInternal     = "internal_error"
+)
+
+// respondWithServiceError maps an error returned by a service to an error response.
+// Typed service errors keep their own code and message. Anything else is logged and
+// reported as a 500 with fallbackMessage so driver errors never leak to clients.
+func respondWithServiceError(w http.ResponseWriter, r *http.Request, err error, fallbackMessage string) {
+	status, code := statusForError(err)
+	if status == http.StatusInternalServerError {
+		logger.FromContext(r.Context()).Error(fallbackMessage, "error", err)
+		utils.SendResponse(w, status,
+			model.NewCodedErrorResponse(codeInternal, fallbackMessage, nil))
+		return
+	}
+
+	message := fallbackMessage
+	var fieldErrors interface{}
+
+	var serviceErr *service.Error
+	if errors.As(err, &serviceErr) {
+		message = serviceErr.Message
+		if serviceErr.Code != "" {
+			code = serviceErr.Code
+		}
+		if serviceErr.Field != "" {
+			fieldErrors = []model.ValidationError{
+				model.NewValidationError(serviceErr.Field, serviceErr.Message),
+			}
+		}
+	}
+
+	utils.SendResponse(w, status, model.NewCodedErrorResponse(code, message, fieldErrors))
+}
+
+// statusForError returns the HTTP status and default error code for an error kind
+func statusForError(err error) (int, string) {
+	switch {
+	case errors.Is(err, service.ErrNotFound):
+		return http.StatusNotFound, codeNotFound
+	case errors.Is(err, service.ErrConflict):
+		return http.StatusConflict, codeConflict
+	case errors.Is(err, service.ErrInvalidInput):
+		return http.StatusBadRequest, codeValidation
+	case errors.Is(err, service.ErrUnauthorized):
+		return http.StatusUnauthorized, codeUnauthorized
+	case errors.Is(err, service.ErrForbidden):
+		return http.StatusForbidden, codeForbidden
+	default:
+		return http.StatusInternalServerError, codeInternal
+	}
+}
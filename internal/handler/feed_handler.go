@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type FeedHandler struct {
+	feedService FeedService
+}
+
+func NewFeedHandler(feedService FeedService) *FeedHandler {
+	return &FeedHandler{
+		feedService: feedService,
+	}
+}
+
+// GetRSS handles serving the RSS 2.0 feed of the blog
+func (h *FeedHandler) GetRSS(w http.ResponseWriter, r *http.Request) {
+	feed, lastModified, err := h.feedService.GetRSS(r.Context())
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to build feed")
+		return
+	}
+
+	writeFeed(w, r, "application/rss+xml; charset=utf-8", feed, lastModified)
+}
+
+// GetAtom handles serving the Atom 1.0 feed of the blog
+func (h *FeedHandler) GetAtom(w http.ResponseWriter, r *http.Request) {
+	feed, lastModified, err := h.feedService.GetAtom(r.Context())
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to build feed")
+		return
+	}
+
+	writeFeed(w, r, "application/atom+xml; charset=utf-8", feed, lastModified)
+}
+
+// writeFeed encodes a feed and answers 304 Not Modified when the client already has it,
+// judged by If-None-Match against the body's ETag, or else by If-Modified-Since
+func writeFeed(w http.ResponseWriter, r *http.Request, contentType string, feed interface{}, lastModified time.Time) {
+	var body bytes.Buffer
+	body.WriteString(xml.Header)
+	if err := xml.NewEncoder(&body).Encode(feed); err != nil {
+		respondWithServiceError(w, r, err, "Failed to build feed")
+		return
+	}
+
+	sum := sha256.Sum256(body.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(r, etag, lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(body.Bytes())
+}
+
+// notModified reports whether a conditional GET matches the current representation.
+// If-Modified-Since is only consulted when there is no If-None-Match.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
@@ -3,25 +3,23 @@ package handler
 import (
 	"net/http"
 
-	"github.com/jackc/pgx/v5/pgxpool"
-
 	"beef-db-be/internal/model"
 	"beef-db-be/internal/utils"
 )
 
 type HealthHandler struct {
-	pool *pgxpool.Pool
+	healthService HealthService
 }
 
-func NewHealthHandler(pool *pgxpool.Pool) *HealthHandler {
+func NewHealthHandler(healthService HealthService) *HealthHandler {
 	return &HealthHandler{
-		pool: pool,
+		healthService: healthService,
 	}
 }
 
 func (h *HealthHandler) CheckHealth(w http.ResponseWriter, r *http.Request) {
 	// Check database connection
-	if err := h.pool.Ping(r.Context()); err != nil {
+	if err := h.healthService.Ping(r.Context()); err != nil {
 		utils.SendResponse(w, http.StatusServiceUnavailable,
 			model.NewErrorResponse("Database connection failed", err.Error()))
 		return
@@ -30,3 +28,36 @@ func (h *HealthHandler) CheckHealth(w http.ResponseWriter, r *http.Request) {
 	utils.SendResponse(w, http.StatusOK,
 		model.NewSuccessResponse("Service is healthy", nil))
 }
+
+// Livez reports that the process is up. It checks no dependencies, so a database
+// outage does not get the process restarted.
+func (h *HealthHandler) Livez(w http.ResponseWriter, r *http.Request) {
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Service is alive", map[string]string{"status": model.HealthStatusOK}))
+}
+
+// Readyz reports whether the service can take traffic, answering 503 when any check
+// fails. Failure messages are left out; they are in the admin health details.
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	report := h.healthService.Ready(r.Context())
+	for i := range report.Checks {
+		report.Checks[i].Message = ""
+	}
+
+	if report.Status != model.HealthStatusOK {
+		utils.SendResponse(w, http.StatusServiceUnavailable,
+			model.NewErrorResponse("Service is not ready", report))
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Service is ready", report))
+}
+
+// GetDetails handles the admin view of dependency checks, pool statistics and build information
+func (h *HealthHandler) GetDetails(w http.ResponseWriter, r *http.Request) {
+	details := h.healthService.Details(r.Context())
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Health details retrieved successfully", details))
+}
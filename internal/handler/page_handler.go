@@ -1,22 +1,21 @@
 package handler
 
 import (
-	"encoding/json"
 	"net/http"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
 
+	"beef-db-be/internal/middleware"
 	"beef-db-be/internal/model"
-	"beef-db-be/internal/service"
 	"beef-db-be/internal/utils"
 )
 
 type PageHandler struct {
-	pageService *service.PageService
+	pageService PageService
 }
 
-func NewPageHandler(pageService *service.PageService) *PageHandler {
+func NewPageHandler(pageService PageService) *PageHandler {
 	return &PageHandler{
 		pageService: pageService,
 	}
@@ -24,18 +23,14 @@ func NewPageHandler(pageService *service.PageService) *PageHandler {
 
 func (h *PageHandler) CreatePage(w http.ResponseWriter, r *http.Request) {
 	var req model.CreatePageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Invalid request body", []model.ValidationError{
-				model.NewValidationError("body", "Invalid JSON format"),
-			}))
+	if !utils.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
-	page, err := h.pageService.CreatePage(r.Context(), req)
+	authorID, _ := middleware.GetUserID(r)
+	page, err := h.pageService.CreatePage(r.Context(), req, authorID)
 	if err != nil {
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to create page", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to create page")
 		return
 	}
 
@@ -54,15 +49,9 @@ func (h *PageHandler) GetPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	page, err := h.pageService.GetPage(r.Context(), int32(id))
+	page, err := h.pageService.GetPublishedPage(r.Context(), int32(id))
 	if err != nil {
-		if err == service.ErrNotFound {
-			utils.SendResponse(w, http.StatusNotFound,
-				model.NewErrorResponse("Page not found", err.Error()))
-			return
-		}
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to get page", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to get page")
 		return
 	}
 
@@ -73,15 +62,13 @@ func (h *PageHandler) GetPage(w http.ResponseWriter, r *http.Request) {
 func (h *PageHandler) GetPageBySlug(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
 
-	page, err := h.pageService.GetPageBySlug(r.Context(), slug)
+	page, moved, err := h.pageService.GetPageBySlug(r.Context(), slug)
 	if err != nil {
-		if err == service.ErrNotFound {
-			utils.SendResponse(w, http.StatusNotFound,
-				model.NewErrorResponse("Page not found", err.Error()))
-			return
-		}
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to get page", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to get page")
+		return
+	}
+	if moved {
+		respondWithSlugRedirect(w, r, page.Slug, "Page has moved to a new slug", page)
 		return
 	}
 
@@ -93,10 +80,24 @@ func (h *PageHandler) GetPageBySlug(w http.ResponseWriter, r *http.Request) {
 func (h *PageHandler) ListPages(w http.ResponseWriter, r *http.Request) {
 	pagination := utils.GetPaginationFromRequest(r)
 
+	pages, totalCount, err := h.pageService.ListPublishedPages(r.Context(), pagination)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to list pages")
+		return
+	}
+
+	paginatedResp := model.NewPaginatedResponse(pages, totalCount, pagination.Page, pagination.PageSize)
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Pages retrieved successfully", paginatedResp))
+}
+
+// AdminListPages handles listing pages of every status for admins
+func (h *PageHandler) AdminListPages(w http.ResponseWriter, r *http.Request) {
+	pagination := utils.GetPaginationFromRequest(r)
+
 	pages, totalCount, err := h.pageService.ListPages(r.Context(), pagination)
 	if err != nil {
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to list pages", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to list pages")
 		return
 	}
 
@@ -105,6 +106,69 @@ func (h *PageHandler) ListPages(w http.ResponseWriter, r *http.Request) {
 		model.NewSuccessResponse("Pages retrieved successfully", paginatedResp))
 }
 
+// AdminGetPage handles retrieving a page of any status for admins
+func (h *PageHandler) AdminGetPage(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid page ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+
+	page, err := h.pageService.GetPage(r.Context(), int32(id))
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to get page")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Page retrieved successfully", page))
+}
+
+// CreatePagePreviewToken handles issuing a preview link token for an unpublished page
+func (h *PageHandler) CreatePagePreviewToken(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid page ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+
+	token, err := h.pageService.CreatePreviewToken(r.Context(), int32(id))
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to create preview token")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusCreated,
+		model.NewSuccessResponse("Preview token created successfully", token))
+}
+
+// PreviewPage handles retrieving a page through a preview token, whatever its status
+func (h *PageHandler) PreviewPage(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid preview token", []model.ValidationError{
+				model.NewValidationError("token", "Token is required"),
+			}))
+		return
+	}
+
+	page, err := h.pageService.GetPreview(r.Context(), token)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to get page preview")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Page retrieved successfully", page))
+}
+
 func (h *PageHandler) UpdatePage(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.Atoi(idStr)
@@ -117,22 +181,13 @@ func (h *PageHandler) UpdatePage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req model.UpdatePageRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Invalid request body", []model.ValidationError{
-				model.NewValidationError("body", "Invalid JSON format"),
-			}))
+	if !utils.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
-	if err := h.pageService.UpdatePage(r.Context(), int32(id), req); err != nil {
-		if err == service.ErrNotFound {
-			utils.SendResponse(w, http.StatusNotFound,
-				model.NewErrorResponse("Page not found", err.Error()))
-			return
-		}
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to update page", err.Error()))
+	authorID, _ := middleware.GetUserID(r)
+	if err := h.pageService.UpdatePage(r.Context(), int32(id), req, authorID); err != nil {
+		respondWithServiceError(w, r, err, "Failed to update page")
 		return
 	}
 
@@ -152,16 +207,118 @@ func (h *PageHandler) DeletePage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.pageService.DeletePage(r.Context(), int32(id)); err != nil {
-		if err == service.ErrNotFound {
-			utils.SendResponse(w, http.StatusNotFound,
-				model.NewErrorResponse("Page not found", err.Error()))
-			return
-		}
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to delete page", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to delete page")
 		return
 	}
 
 	utils.SendResponse(w, http.StatusOK,
 		model.NewSuccessResponse("Page deleted successfully", nil))
 }
+
+// ListPageRevisions handles listing the revisions of a page, newest first
+func (h *PageHandler) ListPageRevisions(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid page ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+
+	pagination := utils.GetPaginationFromRequest(r)
+	revisions, totalCount, err := h.pageService.ListPageRevisions(r.Context(), int32(id), pagination)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to list revisions")
+		return
+	}
+
+	paginatedResp := model.NewPaginatedResponse(revisions, totalCount, pagination.Page, pagination.PageSize)
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Revisions retrieved successfully", paginatedResp))
+}
+
+// GetPageRevision handles retrieving a single revision of a page
+func (h *PageHandler) GetPageRevision(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid page ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+	revisionID, err := strconv.Atoi(chi.URLParam(r, "revisionId"))
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid revision ID", []model.ValidationError{
+				model.NewValidationError("revisionId", "Must be a valid number"),
+			}))
+		return
+	}
+
+	revision, err := h.pageService.GetPageRevision(r.Context(), int32(id), int32(revisionID))
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to get revision")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Revision retrieved successfully", revision))
+}
+
+// DiffPageRevisions handles comparing the revisions given by the from and to query parameters
+func (h *PageHandler) DiffPageRevisions(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid page ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+
+	fromID, toID, ok := parseRevisionRange(w, r)
+	if !ok {
+		return
+	}
+
+	diff, err := h.pageService.DiffPageRevisions(r.Context(), int32(id), int32(fromID), int32(toID))
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to diff revisions")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Revisions compared successfully", diff))
+}
+
+// RestorePageRevision handles restoring an old revision as the current version of a page
+func (h *PageHandler) RestorePageRevision(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid page ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+	revisionID, err := strconv.Atoi(chi.URLParam(r, "revisionId"))
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid revision ID", []model.ValidationError{
+				model.NewValidationError("revisionId", "Must be a valid number"),
+			}))
+		return
+	}
+
+	authorID, _ := middleware.GetUserID(r)
+	page, err := h.pageService.RestorePageRevision(r.Context(), int32(id), int32(revisionID), authorID)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to restore revision")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Revision restored successfully", page))
+}
@@ -8,19 +8,18 @@ import (
 	"github.com/go-chi/chi/v5"
 
 	"beef-db-be/internal/model"
-	"beef-db-be/internal/service"
 	"beef-db-be/internal/utils"
 )
 
 // ProductHandler handles HTTP requests related to products
 type ProductHandler struct {
-	productService  *service.ProductService
-	websiteService  *service.WebsiteSettingService
-	categoryService *service.CategoryService
+	productService  ProductService
+	websiteService  WebsiteSettingService
+	categoryService CategoryService
 }
 
 // NewProductHandler creates a new ProductHandler instance
-func NewProductHandler(productService *service.ProductService, websiteService *service.WebsiteSettingService, categoryService *service.CategoryService) *ProductHandler {
+func NewProductHandler(productService ProductService, websiteService WebsiteSettingService, categoryService CategoryService) *ProductHandler {
 	return &ProductHandler{
 		productService:  productService,
 		websiteService:  websiteService,
@@ -31,18 +30,13 @@ func NewProductHandler(productService *service.ProductService, websiteService *s
 // CreateProduct handles product creation
 func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 	var req model.CreateProductRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Invalid request body", []model.ValidationError{
-				model.NewValidationError("body", "Invalid JSON format"),
-			}))
+	if !utils.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
 	product, err := h.productService.CreateProduct(r.Context(), req)
 	if err != nil {
-		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Failed to create product", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to create product")
 		return
 	}
 
@@ -64,8 +58,7 @@ func (h *ProductHandler) GetProduct(w http.ResponseWriter, r *http.Request) {
 
 	product, err := h.productService.GetProduct(r.Context(), id)
 	if err != nil {
-		utils.SendResponse(w, http.StatusNotFound,
-			model.NewErrorResponse("Product not found", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to get product")
 		return
 	}
 
@@ -76,10 +69,13 @@ func (h *ProductHandler) GetProduct(w http.ResponseWriter, r *http.Request) {
 // GetProductBySlug retrieves a product by slug
 func (h *ProductHandler) GetProductBySlug(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
-	product, err := h.productService.GetProductBySlug(r.Context(), slug)
+	product, moved, err := h.productService.GetProductBySlug(r.Context(), slug)
 	if err != nil {
-		utils.SendResponse(w, http.StatusNotFound,
-			model.NewErrorResponse("Product not found", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to get product")
+		return
+	}
+	if moved {
+		respondWithSlugRedirect(w, r, product.Slug, "Product has moved to a new slug", product)
 		return
 	}
 
@@ -93,8 +89,7 @@ func (h *ProductHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
 
 	products, totalCount, err := h.productService.ListProducts(r.Context(), pagination)
 	if err != nil {
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to retrieve products", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to retrieve products")
 		return
 	}
 	paginatedResp := model.NewPaginatedResponse(products, totalCount, pagination.Page, pagination.PageSize)
@@ -118,8 +113,7 @@ func (h *ProductHandler) ListProductsByCategoryByID(w http.ResponseWriter, r *ht
 
 	products, totalCount, err := h.productService.ListProductsByCategoryID(r.Context(), categoryID, pagination)
 	if err != nil {
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to retrieve products", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to retrieve products")
 		return
 	}
 
@@ -144,16 +138,14 @@ func (h *ProductHandler) ListProductsByCategoryBySlug(w http.ResponseWriter, r *
 	// First get the category information
 	category, err := h.categoryService.GetCategoryBySlug(r.Context(), categorySlug)
 	if err != nil {
-		utils.SendResponse(w, http.StatusNotFound,
-			model.NewErrorResponse("Category not found", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to get category")
 		return
 	}
 
 	// Then get the products for this category
 	products, totalCount, err := h.productService.ListProductsByCategorySlug(r.Context(), categorySlug, pagination)
 	if err != nil {
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to retrieve products", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to retrieve products")
 		return
 	}
 
@@ -181,18 +173,13 @@ func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req model.UpdateProductRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Invalid request body", []model.ValidationError{
-				model.NewValidationError("body", "Invalid JSON format"),
-			}))
+	if !utils.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
 	product, err := h.productService.UpdateProduct(r.Context(), id, req)
 	if err != nil {
-		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Failed to update product", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to update product")
 		return
 	}
 
@@ -213,8 +200,7 @@ func (h *ProductHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.productService.DeleteProduct(r.Context(), id); err != nil {
-		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Failed to delete product", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to delete product")
 		return
 	}
 
@@ -230,24 +216,21 @@ func (h *ProductHandler) ListProductsBySettingCategories(w http.ResponseWriter,
 	setting, err := h.websiteService.GetByName(ctx, "show_product_category")
 
 	if err != nil {
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to get website settings", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to get website settings")
 		return
 	}
 
 	// Parse category IDs from the setting value
 	var categoryIDs []int
 	if err := json.Unmarshal([]byte(setting.Value), &categoryIDs); err != nil {
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Invalid category IDs in settings", err.Error()))
+		respondWithServiceError(w, r, err, "Invalid category IDs in settings")
 		return
 	}
 
 	// Get products by category IDs
 	categories, err := h.productService.GetProductsByCategoryIDs(ctx, categoryIDs)
 	if err != nil {
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to get products", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to get products")
 		return
 	}
 	utils.SendResponse(w, http.StatusOK,
@@ -0,0 +1,302 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/service"
+	"beef-db-be/internal/utils"
+)
+
+// maxImportSize limits the size of an uploaded import file
+const maxImportSize = 10 << 20 // 10 MB
+
+// productCSVHeader is the column order used for CSV exports and the expected columns for imports
+var productCSVHeader = []string{
+	"category_slug",
+	"name",
+	"slug",
+	"description",
+	"price",
+	"price_sale",
+	"unit_of_measurement",
+	"image_url",
+	"thumb_url",
+}
+
+// ImportProducts handles bulk product upserts from a CSV or JSON upload.
+// The format is taken from the "format" query parameter, the uploaded file extension
+// or the Content-Type header. Pass dry_run=true to validate without saving.
+func (h *ProductHandler) ImportProducts(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	body, format, err := importSource(r)
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid import file", []model.ValidationError{
+				model.NewValidationError("file", err.Error()),
+			}))
+		return
+	}
+	defer body.Close()
+
+	var rows []model.ProductImportRow
+	switch format {
+	case "csv":
+		rows, err = parseProductCSV(body)
+	case "json":
+		err = json.NewDecoder(body).Decode(&rows)
+	default:
+		err = fmt.Errorf("unsupported format %q, expected csv or json", format)
+	}
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid import file", []model.ValidationError{
+				model.NewValidationError("file", err.Error()),
+			}))
+		return
+	}
+
+	if len(rows) == 0 {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid import file", []model.ValidationError{
+				model.NewValidationError("file", "No products found"),
+			}))
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+	result, err := h.productService.ImportProducts(r.Context(), rows, dryRun)
+	if err != nil {
+		if errors.Is(err, service.ErrImportInvalid) {
+			utils.SendResponse(w, http.StatusUnprocessableEntity, model.APIResponse{
+				Status:  "error",
+				Message: "Import contains invalid rows",
+				Code:    "import_invalid",
+				Data:    result,
+				Errors:  result.Errors,
+			})
+			return
+		}
+		respondWithServiceError(w, r, err, "Failed to import products")
+		return
+	}
+
+	message := "Products imported successfully"
+	if dryRun {
+		message = "Dry run completed, no changes were saved"
+	}
+	utils.SendResponse(w, http.StatusOK, model.NewSuccessResponse(message, result))
+}
+
+// ExportProducts streams the full product catalog as CSV or JSON
+func (h *ProductHandler) ExportProducts(w http.ResponseWriter, r *http.Request) {
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid export format", []model.ValidationError{
+				model.NewValidationError("format", "Must be csv or json"),
+			}))
+		return
+	}
+
+	filename := fmt.Sprintf("products-%s.%s", time.Now().Format("20060102-150405"), format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	ew := &exportWriter{w: w}
+	var err error
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		err = h.exportCSV(ew, r)
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+		err = h.exportJSON(ew, r)
+	}
+
+	if err != nil {
+		if ew.written == 0 {
+			// Nothing reached the client yet, so it still gets an error response
+			w.Header().Del("Content-Disposition")
+			respondWithServiceError(w, r, err, "Failed to export products")
+			return
+		}
+		// Part of the file is already sent, so the only option left is to abort the stream
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// exportWriter counts the bytes of an export that were written to the response
+type exportWriter struct {
+	w       io.Writer
+	written int64
+}
+
+func (ew *exportWriter) Write(p []byte) (int, error) {
+	n, err := ew.w.Write(p)
+	ew.written += int64(n)
+	return n, err
+}
+
+// exportCSV buffers the header row with the first products, so a failing first batch
+// leaves the response unwritten
+func (h *ProductHandler) exportCSV(w io.Writer, r *http.Request) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(productCSVHeader); err != nil {
+		return err
+	}
+
+	err := h.productService.ExportProducts(r.Context(), func(p model.ProductImportRow) error {
+		return cw.Write([]string{
+			p.CategorySlug,
+			p.Name,
+			p.Slug,
+			p.Description,
+			p.Price.StringFixed(2),
+			p.PriceSale.StringFixed(2),
+			p.UnitOfMeasurement,
+			p.ImageURL,
+			p.ThumbURL,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// exportJSON writes the opening bracket with the first product, so a failing first
+// batch leaves the response unwritten
+func (h *ProductHandler) exportJSON(w io.Writer, r *http.Request) error {
+	enc := json.NewEncoder(w)
+	separator := "["
+	err := h.productService.ExportProducts(r.Context(), func(p model.ProductImportRow) error {
+		if _, err := io.WriteString(w, separator); err != nil {
+			return err
+		}
+		separator = ","
+		return enc.Encode(p)
+	})
+	if err != nil {
+		return err
+	}
+
+	if separator == "[" {
+		_, err = io.WriteString(w, "[]\n")
+		return err
+	}
+	_, err = io.WriteString(w, "]\n")
+	return err
+}
+
+// importSource returns the uploaded file body and its format. Both multipart uploads
+// (field "file") and raw request bodies are accepted.
+func importSource(r *http.Request) (io.ReadCloser, string, error) {
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	contentType := r.Header.Get("Content-Type")
+
+	if strings.HasPrefix(contentType, "multipart/form-data") {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			return nil, "", errors.New("multipart upload must contain a 'file' field")
+		}
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+		}
+		return file, format, nil
+	}
+
+	if format == "" {
+		switch {
+		case strings.Contains(contentType, "csv"):
+			format = "csv"
+		case strings.Contains(contentType, "json"):
+			format = "json"
+		}
+	}
+	return r.Body, format, nil
+}
+
+// parseProductCSV reads product rows from CSV, mapping columns by header name
+func parseProductCSV(r io.Reader) ([]model.ProductImportRow, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, required := range []string{"category_slug", "name", "slug", "price"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing required column %q", required)
+		}
+	}
+
+	var rows []model.ProductImportRow
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		get := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		price, err := parseCSVDecimal(get("price"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid price: %w", line, err)
+		}
+		priceSale, err := parseCSVDecimal(get("price_sale"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid price_sale: %w", line, err)
+		}
+
+		rows = append(rows, model.ProductImportRow{
+			CategorySlug:      get("category_slug"),
+			Name:              get("name"),
+			Slug:              get("slug"),
+			Description:       get("description"),
+			Price:             price,
+			PriceSale:         priceSale,
+			UnitOfMeasurement: get("unit_of_measurement"),
+			ImageURL:          get("image_url"),
+			ThumbURL:          get("thumb_url"),
+		})
+	}
+
+	return rows, nil
+}
+
+func parseCSVDecimal(s string) (decimal.Decimal, error) {
+	if s == "" {
+		return decimal.Zero, nil
+	}
+	return decimal.NewFromString(s)
+}
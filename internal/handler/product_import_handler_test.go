@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"beef-db-be/internal/model"
+)
+
+// fakeExport passes rows to the export and then fails with err, when it is set
+type fakeExport struct {
+	ProductService
+	rows []model.ProductImportRow
+	err  error
+}
+
+func (f fakeExport) ExportProducts(ctx context.Context, fn func(model.ProductImportRow) error) error {
+	for _, row := range f.rows {
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return f.err
+}
+
+func export(t *testing.T, service ProductService, format string) (rec *httptest.ResponseRecorder, aborted bool) {
+	t.Helper()
+	h := NewProductHandler(service, nil, nil)
+	rec = httptest.NewRecorder()
+	defer func() {
+		if v := recover(); v != nil {
+			if v != http.ErrAbortHandler {
+				panic(v)
+			}
+			aborted = true
+		}
+	}()
+	h.ExportProducts(rec, httptest.NewRequest(http.MethodGet, "/api/admin/products/export?format="+format, nil))
+	return rec, false
+}
+
+func TestExportProducts(t *testing.T) {
+	row := model.ProductImportRow{CategorySlug: "beef", Name: "Ribeye", Slug: "ribeye"}
+
+	for _, format := range []string{"csv", "json"} {
+		t.Run(format, func(t *testing.T) {
+			rec, aborted := export(t, fakeExport{rows: []model.ProductImportRow{row}}, format)
+			if aborted || rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "ribeye") {
+				t.Errorf("export: aborted %v, status %d, body %q", aborted, rec.Code, rec.Body)
+			}
+			if rec.Header().Get("Content-Disposition") == "" {
+				t.Error("export is not sent as an attachment")
+			}
+
+			rec, aborted = export(t, fakeExport{}, format)
+			if aborted || rec.Code != http.StatusOK {
+				t.Errorf("empty export: aborted %v, status %d", aborted, rec.Code)
+			}
+		})
+	}
+}
+
+func TestExportProductsFailingBeforeAnyRow(t *testing.T) {
+	for _, format := range []string{"csv", "json"} {
+		t.Run(format, func(t *testing.T) {
+			rec, aborted := export(t, fakeExport{err: errors.New("connection reset")}, format)
+			if aborted {
+				t.Fatal("export aborted the response instead of answering with an error")
+			}
+			if rec.Code != http.StatusInternalServerError {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+			}
+			if got := rec.Header().Get("Content-Disposition"); got != "" {
+				t.Errorf("Content-Disposition = %q, want none on an error", got)
+			}
+		})
+	}
+}
+
+func TestExportProductsFailingMidStream(t *testing.T) {
+	// More rows than the CSV writer buffers, so part of the file reached the client
+	rows := make([]model.ProductImportRow, 200)
+	for i := range rows {
+		rows[i] = model.ProductImportRow{Name: strings.Repeat("Ribeye ", 10), Slug: "ribeye"}
+	}
+
+	for _, format := range []string{"csv", "json"} {
+		t.Run(format, func(t *testing.T) {
+			if _, aborted := export(t, fakeExport{rows: rows, err: errors.New("connection reset")}, format); !aborted {
+				t.Error("export did not abort the partly sent response")
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/utils"
+)
+
+// parseRevisionRange reads the from and to revision IDs of a diff request. It writes a
+// 400 response and returns false when either is missing or not a number.
+func parseRevisionRange(w http.ResponseWriter, r *http.Request) (fromID, toID int64, ok bool) {
+	var errs []model.ValidationError
+
+	fromID, err := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+	if err != nil {
+		errs = append(errs, model.NewValidationError("from", "Must be a valid revision ID"))
+	}
+	toID, err = strconv.ParseInt(r.URL.Query().Get("to"), 10, 64)
+	if err != nil {
+		errs = append(errs, model.NewValidationError("to", "Must be a valid revision ID"))
+	}
+
+	if len(errs) > 0 {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid revision range", errs))
+		return 0, 0, false
+	}
+	return fromID, toID, true
+}
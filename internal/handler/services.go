@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/service"
+)
+
+// The handlers depend on the interfaces below rather than on the services, so the HTTP
+// layer can be built in tests with fakes. Each lists what the handlers call.
+
+// UserService signs users up and in and looks up accounts
+type UserService interface {
+	GetUser(ctx context.Context, id int64) (*model.User, error)
+	ListUsers(ctx context.Context) ([]model.User, error)
+	Login(ctx context.Context, req model.LoginRequest) (*model.LoginResponse, error)
+	SignUp(ctx context.Context, req model.SignUpRequest) (*model.User, error)
+}
+
+// CategoryService manages the product categories
+type CategoryService interface {
+	CreateCategory(ctx context.Context, req model.CreateCategoryRequest) (*model.Category, error)
+	DeleteCategory(ctx context.Context, id int) error
+	GetCategory(ctx context.Context, id int) (*model.Category, error)
+	GetCategoryBySlug(ctx context.Context, slug string) (*model.Category, error)
+	ListCategories(ctx context.Context) ([]model.Category, error)
+	UpdateCategory(ctx context.Context, id int, req model.UpdateCategoryRequest) (*model.Category, error)
+}
+
+// ProductService manages the product catalog and its CSV import and export
+type ProductService interface {
+	CreateProduct(ctx context.Context, req model.CreateProductRequest) (*model.Product, error)
+	DeleteProduct(ctx context.Context, id int) error
+	ExportProducts(ctx context.Context, fn func(model.ProductImportRow) error) error
+	GetProduct(ctx context.Context, id int) (*model.Product, error)
+	GetProductBySlug(ctx context.Context, slug string) (product *model.Product, moved bool, err error)
+	GetProductsByCategoryIDs(ctx context.Context, categoryIDs []int) ([]model.CategoryProductsResponse, error)
+	ImportProducts(ctx context.Context, rows []model.ProductImportRow, dryRun bool) (*model.ProductImportResult, error)
+	ListProducts(ctx context.Context, pagination model.Pagination) ([]model.Product, int64, error)
+	ListProductsByCategoryID(ctx context.Context, categoryID int64, pagination model.Pagination) ([]model.Product, int64, error)
+	ListProductsByCategorySlug(ctx context.Context, categorySlug string, pagination model.Pagination) ([]model.Product, int64, error)
+	UpdateProduct(ctx context.Context, id int, req model.UpdateProductRequest) (*model.Product, error)
+}
+
+// WebsiteSettingService manages the named website settings
+type WebsiteSettingService interface {
+	Create(ctx context.Context, req model.CreateWebsiteSettingRequest) (*model.WebsiteSettingResponse, error)
+	Delete(ctx context.Context, id int32) error
+	Get(ctx context.Context, id int32) (*model.WebsiteSettingResponse, error)
+	GetByName(ctx context.Context, name string) (*model.WebsiteSettingResponse, error)
+	List(ctx context.Context) (*model.WebsiteSettingsResponse, error)
+	Update(ctx context.Context, name string, req model.UpdateWebsiteSettingRequest) error
+}
+
+// PageService manages the CMS pages, their revisions and previews
+type PageService interface {
+	CreatePage(ctx context.Context, req model.CreatePageRequest, authorID int64) (*model.Page, error)
+	CreatePreviewToken(ctx context.Context, id int32) (*model.PreviewTokenResponse, error)
+	DeletePage(ctx context.Context, id int32) error
+	DiffPageRevisions(ctx context.Context, id, fromID, toID int32) (*model.RevisionDiff, error)
+	GetPage(ctx context.Context, id int32) (*model.Page, error)
+	GetPageBySlug(ctx context.Context, slug string) (page *model.Page, moved bool, err error)
+	GetPageRevision(ctx context.Context, id, revisionID int32) (*model.ContentRevision, error)
+	GetPreview(ctx context.Context, token string) (*model.Page, error)
+	GetPublishedPage(ctx context.Context, id int32) (*model.Page, error)
+	ListPageRevisions(ctx context.Context, id int32, pagination model.Pagination) ([]model.ContentRevision, int64, error)
+	ListPages(ctx context.Context, pagination model.Pagination) ([]model.Page, int64, error)
+	ListPublishedPages(ctx context.Context, pagination model.Pagination) ([]model.Page, int64, error)
+	RestorePageRevision(ctx context.Context, id, revisionID int32, authorID int64) (*model.Page, error)
+	UpdatePage(ctx context.Context, id int32, req model.UpdatePageRequest, authorID int64) error
+}
+
+// BlogPostService manages the blog posts, their revisions and previews
+type BlogPostService interface {
+	Create(ctx context.Context, req model.CreateBlogPostRequest, authorID int64) (*model.BlogPost, error)
+	CreatePreviewToken(ctx context.Context, id int64) (*model.PreviewTokenResponse, error)
+	Delete(ctx context.Context, id int64) error
+	DiffRevisions(ctx context.Context, id, fromID, toID int64) (*model.RevisionDiff, error)
+	GetByID(ctx context.Context, id int64) (*model.BlogPost, error)
+	GetBySlug(ctx context.Context, slug string) (post *model.BlogPost, moved bool, err error)
+	GetPreview(ctx context.Context, token string) (*model.BlogPost, error)
+	GetPublishedByID(ctx context.Context, id int64) (*model.BlogPost, error)
+	GetRevision(ctx context.Context, id, revisionID int64) (*model.ContentRevision, error)
+	List(ctx context.Context, limit, offset int) ([]model.BlogPost, int64, error)
+	ListPublished(ctx context.Context, limit, offset int, filter model.BlogPostFilter) ([]model.BlogPost, int64, error)
+	ListRevisions(ctx context.Context, id int64, pagination model.Pagination) ([]model.ContentRevision, int64, error)
+	RestoreRevision(ctx context.Context, id, revisionID, authorID int64) (*model.BlogPost, error)
+	Update(ctx context.Context, id int64, req model.UpdateBlogPostRequest, authorID int64) error
+}
+
+// BlogTaxonomyService manages the blog categories and tags
+type BlogTaxonomyService interface {
+	CreateCategory(ctx context.Context, req model.CreateBlogCategoryRequest) (*model.BlogCategory, error)
+	DeleteCategory(ctx context.Context, id int32) error
+	DeleteTag(ctx context.Context, id int32) error
+	ListCategories(ctx context.Context) ([]model.BlogCategoryDetail, error)
+	ListTags(ctx context.Context) ([]model.BlogTagDetail, error)
+	UpdateCategory(ctx context.Context, id int32, req model.UpdateBlogCategoryRequest) (*model.BlogCategory, error)
+}
+
+// CouponService manages coupons and checks them against a cart
+type CouponService interface {
+	CreateCoupon(ctx context.Context, req model.CreateCouponRequest) (*model.Coupon, error)
+	DeleteCoupon(ctx context.Context, id int) error
+	GetCoupon(ctx context.Context, id int) (*model.Coupon, error)
+	ListCoupons(ctx context.Context, pagination model.Pagination) ([]model.Coupon, int64, error)
+	RedeemCoupon(ctx context.Context, req model.RedeemCouponRequest) (*model.CouponRedemption, error)
+	UpdateCoupon(ctx context.Context, id int, req model.UpdateCouponRequest) (*model.Coupon, error)
+	ValidateCoupon(ctx context.Context, userID int64, req model.ValidateCouponRequest) (*model.ValidateCouponResponse, error)
+}
+
+// SitemapService builds sitemap.xml and robots.txt
+type SitemapService interface {
+	GetRobotsTxt(ctx context.Context) (string, error)
+	GetSitemap(ctx context.Context) (*model.SitemapURLSet, *model.SitemapIndex, error)
+	GetSitemapSection(ctx context.Context, section string, page int) (*model.SitemapURLSet, error)
+}
+
+// FeedService builds the RSS and Atom feeds of the blog
+type FeedService interface {
+	GetAtom(ctx context.Context) (*model.AtomFeed, time.Time, error)
+	GetRSS(ctx context.Context) (*model.RSSFeed, time.Time, error)
+}
+
+// TranslationService manages the translations of the catalog and the content
+type TranslationService interface {
+	DeleteTranslation(ctx context.Context, resourceType string, id int64, locale string) error
+	ListMissingTranslations(ctx context.Context, locale, resourceType string, pagination model.Pagination) ([]model.MissingTranslation, int64, error)
+	ListTranslations(ctx context.Context, resourceType string, id int64) ([]model.Translation, error)
+	UpsertTranslation(ctx context.Context, resourceType string, id int64, locale string, req model.UpsertTranslationRequest) (*model.Translation, error)
+}
+
+// CurrencyService manages the exchange rates
+type CurrencyService interface {
+	CreateRate(ctx context.Context, userID int64, req model.CreateExchangeRateRequest) (*model.ExchangeRate, error)
+	DeleteRate(ctx context.Context, id int) error
+	ListCurrentRates(ctx context.Context) (*model.ExchangeRatesResponse, error)
+	ListRateHistory(ctx context.Context, currency string, pagination model.Pagination) ([]model.ExchangeRate, int64, error)
+}
+
+// HealthService checks the dependencies of the API
+type HealthService interface {
+	Details(ctx context.Context) model.HealthDetails
+	Ping(ctx context.Context) error
+	Ready(ctx context.Context) model.HealthReport
+}
+
+var (
+	_ UserService           = (*service.UserService)(nil)
+	_ CategoryService       = (*service.CategoryService)(nil)
+	_ ProductService        = (*service.ProductService)(nil)
+	_ WebsiteSettingService = (*service.WebsiteSettingService)(nil)
+	_ PageService           = (*service.PageService)(nil)
+	_ BlogPostService       = (*service.BlogPostService)(nil)
+	_ BlogTaxonomyService   = (*service.BlogTaxonomyService)(nil)
+	_ CouponService         = (*service.CouponService)(nil)
+	_ SitemapService        = (*service.SitemapService)(nil)
+	_ FeedService           = (*service.FeedService)(nil)
+	_ TranslationService    = (*service.TranslationService)(nil)
+	_ CurrencyService       = (*service.CurrencyService)(nil)
+	_ HealthService         = (*service.HealthService)(nil)
+)
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"beef-db-be/internal/logger"
+)
+
+type SitemapHandler struct {
+	sitemapService SitemapService
+}
+
+func NewSitemapHandler(sitemapService SitemapService) *SitemapHandler {
+	return &SitemapHandler{
+		sitemapService: sitemapService,
+	}
+}
+
+// GetSitemap handles serving sitemap.xml, which is a sitemap index on large sites
+func (h *SitemapHandler) GetSitemap(w http.ResponseWriter, r *http.Request) {
+	urlset, index, err := h.sitemapService.GetSitemap(r.Context())
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to build sitemap")
+		return
+	}
+
+	if index != nil {
+		writeXML(w, r, index)
+		return
+	}
+	writeXML(w, r, urlset)
+}
+
+// GetSitemapSection handles serving one file of the sitemap index, e.g. /sitemaps/products-2.xml
+func (h *SitemapHandler) GetSitemapSection(w http.ResponseWriter, r *http.Request) {
+	page, err := strconv.Atoi(chi.URLParam(r, "page"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	urlset, err := h.sitemapService.GetSitemapSection(r.Context(), chi.URLParam(r, "section"), page)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to build sitemap")
+		return
+	}
+
+	writeXML(w, r, urlset)
+}
+
+// GetRobotsTxt handles serving robots.txt
+func (h *SitemapHandler) GetRobotsTxt(w http.ResponseWriter, r *http.Request) {
+	robots, err := h.sitemapService.GetRobotsTxt(r.Context())
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to build robots.txt")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(robots))
+}
+
+func writeXML(w http.ResponseWriter, r *http.Request, v interface{}) {
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(xml.Header))
+	if err := xml.NewEncoder(w).Encode(v); err != nil {
+		logger.FromContext(r.Context()).Error("Failed to encode XML response", "error", err)
+	}
+}
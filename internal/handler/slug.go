@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/utils"
+)
+
+// respondWithSlugRedirect answers a request made with an outdated slug. It sends a
+// 301 whose Location points at the same route with the current slug, and still
+// includes the resource in the body for clients that do not follow redirects.
+func respondWithSlugRedirect(w http.ResponseWriter, r *http.Request, currentSlug, message string, data interface{}) {
+	requested := url.PathEscape(chi.URLParam(r, "slug"))
+	location := strings.TrimSuffix(r.URL.EscapedPath(), requested) + url.PathEscape(currentSlug)
+	if r.URL.RawQuery != "" {
+		location += "?" + r.URL.RawQuery
+	}
+
+	w.Header().Set("Location", location)
+	utils.SendResponse(w, http.StatusMovedPermanently, model.NewSuccessResponse(message, data))
+}
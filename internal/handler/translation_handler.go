@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/utils"
+)
+
+// TranslationHandler serves the admin translation endpoints. The {resource} URL
+// parameter is one of product, category, blog_post or page.
+type TranslationHandler struct {
+	translationService TranslationService
+}
+
+func NewTranslationHandler(translationService TranslationService) *TranslationHandler {
+	return &TranslationHandler{
+		translationService: translationService,
+	}
+}
+
+// ListTranslations handles listing the translations of a resource
+func (h *TranslationHandler) ListTranslations(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+
+	translations, err := h.translationService.ListTranslations(r.Context(), chi.URLParam(r, "resource"), id)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to list translations")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Translations retrieved successfully", translations))
+}
+
+// UpsertTranslation handles adding or replacing the translation of a resource in a locale
+func (h *TranslationHandler) UpsertTranslation(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+
+	var req model.UpsertTranslationRequest
+	if !utils.DecodeAndValidate(w, r, &req) {
+		return
+	}
+
+	translation, err := h.translationService.UpsertTranslation(r.Context(), chi.URLParam(r, "resource"), id, chi.URLParam(r, "locale"), req)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to save translation")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Translation saved successfully", translation))
+}
+
+// DeleteTranslation handles removing the translation of a resource in a locale
+func (h *TranslationHandler) DeleteTranslation(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.SendResponse(w, http.StatusBadRequest,
+			model.NewErrorResponse("Invalid ID", []model.ValidationError{
+				model.NewValidationError("id", "Must be a valid number"),
+			}))
+		return
+	}
+
+	if err := h.translationService.DeleteTranslation(r.Context(), chi.URLParam(r, "resource"), id, chi.URLParam(r, "locale")); err != nil {
+		respondWithServiceError(w, r, err, "Failed to delete translation")
+		return
+	}
+
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Translation deleted successfully", nil))
+}
+
+// ListMissingTranslations handles the report of resources without a translation,
+// optionally narrowed by the locale and resource query parameters
+func (h *TranslationHandler) ListMissingTranslations(w http.ResponseWriter, r *http.Request) {
+	pagination := utils.GetPaginationFromRequest(r)
+	query := r.URL.Query()
+
+	missing, totalCount, err := h.translationService.ListMissingTranslations(r.Context(), query.Get("locale"), query.Get("resource"), pagination)
+	if err != nil {
+		respondWithServiceError(w, r, err, "Failed to list missing translations")
+		return
+	}
+
+	paginatedResp := model.NewPaginatedResponse(missing, totalCount, pagination.Page, pagination.PageSize)
+	utils.SendResponse(w, http.StatusOK,
+		model.NewSuccessResponse("Missing translations retrieved successfully", paginatedResp))
+}
@@ -1,56 +1,42 @@
 package handler
 
 import (
-	"encoding/json"
-	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
 
+	"beef-db-be/internal/config"
 	"beef-db-be/internal/model"
-	"beef-db-be/internal/service"
 	"beef-db-be/internal/utils"
 )
 
 type UserHandler struct {
-	userService *service.UserService
+	userService UserService
+	auth        config.AuthConfig
 }
 
-func NewUserHandler(userService *service.UserService) *UserHandler {
+func NewUserHandler(userService UserService, auth config.AuthConfig) *UserHandler {
 	return &UserHandler{
 		userService: userService,
+		auth:        auth,
 	}
 }
 
 func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req model.LoginRequest
-	fmt.Println("Login error:")
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Invalid request body", []model.ValidationError{
-				model.NewValidationError("body", "Invalid JSON format"),
-			}))
+	if !utils.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
 	resp, err := h.userService.Login(r.Context(), req)
 	if err != nil {
-		utils.SendResponse(w, http.StatusUnauthorized,
-			model.NewErrorResponse("Login failed", err.Error()))
-		return
-	}
-
-	// Generate JWT token
-	token, err := utils.GenerateJWT(resp.User.ID)
-	if err != nil {
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to generate token", err.Error()))
+		respondWithServiceError(w, r, err, "Login failed")
 		return
 	}
 
 	// Set JWT cookie
-	utils.SetJWTCookie(w, token)
+	utils.SetJWTCookie(w, h.auth, resp.Token)
 
 	utils.SendResponse(w, http.StatusOK,
 		model.NewSuccessResponse("Login successful", resp))
@@ -58,7 +44,7 @@ func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 
 func (h *UserHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	// Clear the JWT cookie
-	utils.ClearJWTCookie(w)
+	utils.ClearJWTCookie(w, h.auth)
 
 	utils.SendResponse(w, http.StatusOK,
 		model.NewSuccessResponse("Successfully logged out", nil))
@@ -77,8 +63,7 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.userService.GetUser(r.Context(), id)
 	if err != nil {
-		utils.SendResponse(w, http.StatusNotFound,
-			model.NewErrorResponse("User not found", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to get user")
 		return
 	}
 
@@ -89,8 +74,7 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 	users, err := h.userService.ListUsers(r.Context())
 	if err != nil {
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to retrieve users", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to retrieve users")
 		return
 	}
 
@@ -101,18 +85,13 @@ func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 // SignUp handles user registration
 func (h *UserHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 	var req model.SignUpRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Invalid request body", []model.ValidationError{
-				model.NewValidationError("body", "Invalid JSON format"),
-			}))
+	if !utils.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
 	user, err := h.userService.SignUp(r.Context(), req)
 	if err != nil {
-		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Sign up failed", err.Error()))
+		respondWithServiceError(w, r, err, "Sign up failed")
 		return
 	}
 
@@ -133,7 +112,7 @@ func (h *UserHandler) GetMe(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate JWT token
-	claims, err := utils.ValidateJWT(cookie.Value)
+	claims, err := utils.ValidateJWT(h.auth, cookie.Value)
 	if err != nil {
 		utils.SendResponse(w, http.StatusUnauthorized,
 			model.NewErrorResponse("Authentication failed", "Invalid or expired token"))
@@ -143,8 +122,7 @@ func (h *UserHandler) GetMe(w http.ResponseWriter, r *http.Request) {
 	// Get user from database
 	user, err := h.userService.GetUser(r.Context(), claims.UserID)
 	if err != nil {
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to retrieve user", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to retrieve user")
 		return
 	}
 
@@ -1,55 +1,37 @@
 package handler
 
 import (
-	"encoding/json"
 	"net/http"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/go-playground/validator/v10"
 
 	"beef-db-be/internal/model"
-	"beef-db-be/internal/service"
 	"beef-db-be/internal/utils"
 )
 
 // WebsiteSettingHandler handles HTTP requests for website settings
 type WebsiteSettingHandler struct {
-	service   *service.WebsiteSettingService
-	validator *validator.Validate
+	service WebsiteSettingService
 }
 
 // NewWebsiteSettingHandler creates a new website setting handler
-func NewWebsiteSettingHandler(service *service.WebsiteSettingService) *WebsiteSettingHandler {
+func NewWebsiteSettingHandler(service WebsiteSettingService) *WebsiteSettingHandler {
 	return &WebsiteSettingHandler{
-		service:   service,
-		validator: validator.New(),
+		service: service,
 	}
 }
 
 // Create handles the creation of a new website setting
 func (h *WebsiteSettingHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req model.CreateWebsiteSettingRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Invalid request payload", []model.ValidationError{
-				model.NewValidationError("body", "Invalid JSON format"),
-			}))
-		return
-	}
-
-	if err := h.validator.Struct(req); err != nil {
-		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Validation failed", []model.ValidationError{
-				model.NewValidationError("body", "Validation failed"),
-			}))
+	if !utils.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
 	setting, err := h.service.Create(r.Context(), req)
 	if err != nil {
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to create setting", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to create setting")
 		return
 	}
 
@@ -73,10 +55,7 @@ func (h *WebsiteSettingHandler) Get(w http.ResponseWriter, r *http.Request) {
 
 	setting, err := h.service.Get(r.Context(), int32(id))
 	if err != nil {
-		utils.SendResponse(w, http.StatusNotFound,
-			model.NewErrorResponse("Setting not found", []model.ValidationError{
-				model.NewValidationError("id", "Setting not found"),
-			}))
+		respondWithServiceError(w, r, err, "Failed to get setting")
 		return
 	}
 
@@ -100,10 +79,7 @@ func (h *WebsiteSettingHandler) GetByName(w http.ResponseWriter, r *http.Request
 
 	setting, err := h.service.GetByName(r.Context(), name)
 	if err != nil {
-		utils.SendResponse(w, http.StatusNotFound,
-			model.NewErrorResponse("Setting not found", []model.ValidationError{
-				model.NewValidationError("name", "Setting not found"),
-			}))
+		respondWithServiceError(w, r, err, "Failed to get setting")
 		return
 	}
 
@@ -118,8 +94,7 @@ func (h *WebsiteSettingHandler) GetByName(w http.ResponseWriter, r *http.Request
 func (h *WebsiteSettingHandler) List(w http.ResponseWriter, r *http.Request) {
 	settings, err := h.service.List(r.Context())
 	if err != nil {
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to retrieve settings", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to retrieve settings")
 		return
 	}
 
@@ -142,25 +117,12 @@ func (h *WebsiteSettingHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req model.UpdateWebsiteSettingRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Invalid request payload", []model.ValidationError{
-				model.NewValidationError("body", "Invalid JSON format"),
-			}))
-		return
-	}
-
-	if err := h.validator.Struct(req); err != nil {
-		utils.SendResponse(w, http.StatusBadRequest,
-			model.NewErrorResponse("Validation failed", []model.ValidationError{
-				model.NewValidationError("body", "Validation failed"),
-			}))
+	if !utils.DecodeAndValidate(w, r, &req) {
 		return
 	}
 
 	if err := h.service.Update(r.Context(), name, req); err != nil {
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to update setting", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to update setting")
 		return
 	}
 
@@ -182,8 +144,7 @@ func (h *WebsiteSettingHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.Delete(r.Context(), int32(id)); err != nil {
-		utils.SendResponse(w, http.StatusInternalServerError,
-			model.NewErrorResponse("Failed to delete setting", err.Error()))
+		respondWithServiceError(w, r, err, "Failed to delete setting")
 		return
 	}
 
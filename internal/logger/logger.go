@@ -0,0 +1,77 @@
+// Package logger builds the structured application logger and carries the
+// request-scoped logger through contexts.
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Config selects the output of the logger
+type Config struct {
+	// Level is one of debug, info, warn or error. Anything else means info.
+	Level string
+	// Format is json or text
+	Format string
+}
+
+// redactedKeys are attribute key fragments whose values are never written
+var redactedKeys = []string{"password", "secret", "token", "authorization", "cookie", "api_key", "apikey"}
+
+const redacted = "[REDACTED]"
+
+type contextKey struct{}
+
+// New returns a logger writing to w. Values of attributes named like secrets, such
+// as password or jwt_secret, are replaced with [REDACTED].
+func New(w io.Writer, cfg Config) *slog.Logger {
+	opts := &slog.HandlerOptions{
+		Level:       ParseLevel(cfg.Level),
+		ReplaceAttr: redact,
+	}
+
+	if strings.EqualFold(cfg.Format, "json") {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// ParseLevel converts a level name to a slog level, defaulting to info
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// WithLogger returns a context carrying l, the logger of the current request
+func WithLogger(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger set by WithLogger, or the default logger. Handlers
+// and services log through it so lines carry the request ID and user ID.
+func FromContext(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return l
+	}
+	return slog.Default()
+}
+
+func redact(groups []string, a slog.Attr) slog.Attr {
+	key := strings.ToLower(a.Key)
+	for _, fragment := range redactedKeys {
+		if strings.Contains(key, fragment) {
+			return slog.String(a.Key, redacted)
+		}
+	}
+	return a
+}
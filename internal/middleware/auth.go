@@ -4,24 +4,28 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"os"
 	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
 
+	"beef-db-be/internal/config"
 	"beef-db-be/internal/model"
-	"beef-db-be/internal/service"
 	"beef-db-be/internal/utils"
 )
 
 type contextKey string
 
+// UserLookup loads the account a token was issued to, e.g. a *service.UserService
+type UserLookup interface {
+	GetUser(ctx context.Context, id int64) (*model.User, error)
+}
+
 const (
 	UserContextKey contextKey = "user"
 	UserIDKey      contextKey = "user_id"
 )
 
-func AuthMiddleware(userService *service.UserService) func(next http.Handler) http.Handler {
+func AuthMiddleware(userService UserLookup, cfg config.AuthConfig) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
@@ -40,7 +44,7 @@ func AuthMiddleware(userService *service.UserService) func(next http.Handler) ht
 				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 					return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 				}
-				return []byte(os.Getenv("JWT_SECRET")), nil
+				return []byte(cfg.JWTSecret), nil
 			})
 
 			if err != nil {
@@ -62,6 +66,7 @@ func AuthMiddleware(userService *service.UserService) func(next http.Handler) ht
 				}
 
 				ctx := context.WithValue(r.Context(), UserContextKey, user)
+				ctx = withLoggedUser(ctx, int64(userID))
 				next.ServeHTTP(w, r.WithContext(ctx))
 			} else {
 				http.Error(w, "Invalid token", http.StatusUnauthorized)
@@ -93,18 +98,17 @@ func RequireRole(roles ...model.Role) func(next http.Handler) http.Handler {
 }
 
 // RequireAuth is a middleware that checks for a valid JWT token in cookies and ensures admin role
-func RequireAuth(userService *service.UserService) func(next http.Handler) http.Handler {
+func RequireAuth(userService UserLookup, cfg config.AuthConfig) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			cookie, err := r.Cookie(utils.TokenCookieName)
-			fmt.Println("err", err)
 			if err != nil {
 				utils.SendResponse(w, http.StatusUnauthorized,
 					model.NewErrorResponse("Authentication required", "No authentication token provided"))
 				return
 			}
 
-			claims, err := utils.ValidateJWT(cookie.Value)
+			claims, err := utils.ValidateJWT(cfg, cookie.Value)
 			if err != nil {
 				utils.SendResponse(w, http.StatusUnauthorized,
 					model.NewErrorResponse("Authentication failed", "Invalid or expired token"))
@@ -129,6 +133,7 @@ func RequireAuth(userService *service.UserService) func(next http.Handler) http.
 			// Add user ID and user object to request context
 			ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
 			ctx = context.WithValue(ctx, UserContextKey, user)
+			ctx = withLoggedUser(ctx, claims.UserID)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
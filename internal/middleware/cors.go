@@ -1,45 +1,38 @@
 package middleware
 
 import (
-	"fmt"
 	"net/http"
-	"os"
-	"strings"
-)
+	"slices"
 
-// CORS middleware to handle cross-origin requests
-func CORS(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Get allowed origins from environment variable or use default
-		allowedOrigins := os.Getenv("ALLOWED_ORIGINS")
-		if allowedOrigins == "" {
-			allowedOrigins = "http://localhost:3000" // Default to React's development server
-		}
-		fmt.Println("Allowed origin:", allowedOrigins)
+	"beef-db-be/internal/config"
+)
 
-		// Get the origin from the request
-		origin := r.Header.Get("Origin")
+// CORS middleware to handle cross-origin requests from the configured origins
+func CORS(cfg config.CORSConfig) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Get the origin from the request
+			origin := r.Header.Get("Origin")
 
-		// Check if the origin is allowed
-		for _, allowedOrigin := range strings.Split(allowedOrigins, ",") {
-			if origin == strings.TrimSpace(allowedOrigin) {
+			// Check if the origin is allowed
+			if origin != "" && slices.Contains(cfg.AllowedOrigins, origin) {
 				w.Header().Set("Access-Control-Allow-Origin", origin)
-				break
 			}
-		}
 
-		// Set other CORS headers
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, X-CSRF-Token")
-		w.Header().Set("Access-Control-Allow-Credentials", "true")
-		w.Header().Set("Access-Control-Max-Age", "300") // 5 minutes
+			// Set other CORS headers
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, X-CSRF-Token")
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+			w.Header().Set("Access-Control-Expose-Headers", TraceIDHeader)
+			w.Header().Set("Access-Control-Max-Age", "300") // 5 minutes
 
-		// Handle preflight requests
-		if r.Method == http.MethodOptions {
-			w.WriteHeader(http.StatusNoContent)
-			return
-		}
+			// Handle preflight requests
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
 
-		next.ServeHTTP(w, r)
-	})
+			next.ServeHTTP(w, r)
+		})
+	}
 }
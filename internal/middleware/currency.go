@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+
+	"beef-db-be/internal/utils"
+)
+
+// Currency stores the currency requested with the currency query parameter in the
+// request context. Services that return prices validate and convert to it.
+func Currency(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		currency := utils.NormalizeCurrency(r.URL.Query().Get("currency"))
+		if currency == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(utils.WithCurrency(r.Context(), currency)))
+	})
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+
+	"beef-db-be/internal/utils"
+)
+
+// Locale negotiates the content locale of each request among locales, from the lang
+// query parameter or the Accept-Language header, and stores it in the request context
+func Locale(locales utils.Locales) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			locale := locales.Negotiate(r.URL.Query().Get("lang"), r.Header.Get("Accept-Language"))
+
+			w.Header().Set("Content-Language", locale)
+			w.Header().Add("Vary", "Accept-Language")
+			next.ServeHTTP(w, r.WithContext(utils.WithLocale(r.Context(), locales, locale)))
+		})
+	}
+}
+
+// GetLocale returns the locale negotiated for the request
+func GetLocale(r *http.Request) string {
+	return utils.LocaleFromContext(r.Context())
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"beef-db-be/internal/logger"
+	"beef-db-be/internal/tracing"
+)
+
+type requestLogKey struct{}
+
+// requestLog collects what inner middleware learns about a request, such as the
+// authenticated user, for the access log line written when the request ends
+type requestLog struct {
+	userID int64
+}
+
+// RequestLogger stores a logger carrying the request ID in the request context and
+// writes one access log line per request with its route pattern, status and latency.
+// It must run after chi's RequestID and the Tracing middleware.
+func RequestLogger(base *slog.Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			entry := &requestLog{}
+
+			reqLogger := base.With("request_id", chimiddleware.GetReqID(r.Context()))
+			if traceID := tracing.TraceID(r.Context()); traceID != "" {
+				reqLogger = reqLogger.With("trace_id", traceID)
+			}
+			ctx := logger.WithLogger(r.Context(), reqLogger)
+			ctx = context.WithValue(ctx, requestLogKey{}, entry)
+
+			ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			attrs := []any{
+				"method", r.Method,
+				"path", r.URL.Path,
+				"route", routePattern(r),
+				"status", status,
+				"bytes", ww.BytesWritten(),
+				"latency_ms", float64(time.Since(start).Microseconds()) / 1000,
+				"remote_ip", r.RemoteAddr,
+			}
+			if entry.userID != 0 {
+				attrs = append(attrs, "user_id", entry.userID)
+			}
+
+			level := slog.LevelInfo
+			switch {
+			case status >= http.StatusInternalServerError:
+				level = slog.LevelError
+			case status >= http.StatusBadRequest:
+				level = slog.LevelWarn
+			}
+			reqLogger.Log(r.Context(), level, "request", attrs...)
+		})
+	}
+}
+
+// withLoggedUser records the authenticated user for the access log and adds it to the
+// request logger and the request span
+func withLoggedUser(ctx context.Context, userID int64) context.Context {
+	if entry, ok := ctx.Value(requestLogKey{}).(*requestLog); ok {
+		entry.userID = userID
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int64("enduser.id", userID))
+	return logger.WithLogger(ctx, logger.FromContext(ctx).With("user_id", userID))
+}
+
+// routePattern is the chi route that matched, e.g. /api/products/{id}
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		return rctx.RoutePattern()
+	}
+	return ""
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import "testing"
+
+func TestMethodLabel(t *testing.T) {
+	tests := map[string]string{
+		"GET":     "GET",
+		"DELETE":  "DELETE",
+		"OPTIONS": "OPTIONS",
+		"get":     "OTHER",
+		"PURGE":   "OTHER",
+		"X-1234":  "OTHER",
+	}
+	for method, want := range tests {
+		if got := methodLabel(method); got != want {
+			t.Errorf("methodLabel(%q) = %q, want %q", method, got, want)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Package migration applies the embedded SQL migrations with golang-migrate, keeping
+// the schema_migrations table compatible with the migrate CLI.
+package migration
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	pgxmigrate "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"beef-db-be/internal/config"
+	"beef-db-be/migrations"
+)
+
+// lockTimeout bounds the wait for the migration advisory lock, which another replica
+// holds while it migrates
+const lockTimeout = 5 * time.Minute
+
+var fileName = regexp.MustCompile(`^(\d+)_(.+)\.up\.sql$`)
+
+// Migration is one embedded migration
+type Migration struct {
+	Version uint
+	Name    string
+}
+
+// Status compares the database with the embedded migrations
+type Status struct {
+	// Version is the last applied migration, 0 when none was applied
+	Version uint
+	// Dirty is set when the last migration failed part way and needs fixing by hand
+	Dirty bool
+	// Latest is the newest embedded migration
+	Latest uint
+	// Pending lists the embedded migrations after Version
+	Pending []Migration
+}
+
+// Runner applies the embedded migrations to one database. Every change holds a
+// PostgreSQL advisory lock, so replicas started together migrate one at a time.
+type Runner struct {
+	m *migrate.Migrate
+}
+
+// NewRunner connects to the database on its own connection, separate from the pool
+func NewRunner(cfg config.DatabaseConfig, log *slog.Logger) (*Runner, error) {
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("error reading embedded migrations: %w", err)
+	}
+
+	db, err := sql.Open("pgx", cfg.ConnString())
+	if err != nil {
+		return nil, fmt.Errorf("error opening migration connection: %w", err)
+	}
+	driver, err := pgxmigrate.WithInstance(db, &pgxmigrate.Config{})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error connecting for migrations: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, cfg.Name, driver)
+	if err != nil {
+		driver.Close()
+		return nil, fmt.Errorf("error setting up migrations: %w", err)
+	}
+	m.LockTimeout = lockTimeout
+	m.Log = migrateLogger{log: log}
+
+	return &Runner{m: m}, nil
+}
+
+// Up applies every pending migration
+func (r *Runner) Up() error {
+	if err := r.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return r.describe(err)
+	}
+	return nil
+}
+
+// Down reverts the last steps migrations
+func (r *Runner) Down(steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("steps must be at least 1, got %d", steps)
+	}
+	if err := r.m.Steps(-steps); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return r.describe(err)
+	}
+	return nil
+}
+
+// Force records version as applied and clears the dirty flag without running any
+// migration. It is used after fixing a failed migration by hand.
+func (r *Runner) Force(version int) error {
+	if err := r.m.Force(version); err != nil {
+		return r.describe(err)
+	}
+	return nil
+}
+
+// Status reports the applied version and the pending migrations
+func (r *Runner) Status() (*Status, error) {
+	all, err := List()
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{}
+	version, dirty, err := r.m.Version()
+	switch {
+	case errors.Is(err, migrate.ErrNilVersion):
+	case err != nil:
+		return nil, fmt.Errorf("error reading schema version: %w", err)
+	default:
+		status.Version, status.Dirty = version, dirty
+	}
+
+	for _, m := range all {
+		if m.Version > status.Version {
+			status.Pending = append(status.Pending, m)
+		}
+	}
+	if len(all) > 0 {
+		status.Latest = all[len(all)-1].Version
+	}
+	return status, nil
+}
+
+// Close releases the migration connection
+func (r *Runner) Close() error {
+	sourceErr, dbErr := r.m.Close()
+	return errors.Join(sourceErr, dbErr)
+}
+
+// describe adds what to do next to the errors that need a person to act
+func (r *Runner) describe(err error) error {
+	var dirty migrate.ErrDirty
+	switch {
+	case errors.As(err, &dirty):
+		return fmt.Errorf("database is dirty at version %d after a failed migration: fix the schema by hand, "+
+			"then run `migrate force VERSION` with the last version that applied fully: %w", dirty.Version, err)
+	case errors.Is(err, migrate.ErrLockTimeout):
+		return fmt.Errorf("another instance held the migration lock for over %s: %w", lockTimeout, err)
+	}
+	return fmt.Errorf("migration failed: %w", err)
+}
+
+// List returns the embedded migrations ordered by version
+func List() ([]Migration, error) {
+	names, err := fs.Glob(migrations.FS, "*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Migration, 0, len(names))
+	for _, name := range names {
+		match := fileName.FindStringSubmatch(name)
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file name %s", name)
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected migration file name %s: %w", name, err)
+		}
+		result = append(result, Migration{Version: uint(version), Name: match[2]})
+	}
+	slices.SortFunc(result, func(a, b Migration) int {
+		return int(a.Version) - int(b.Version)
+	})
+	return result, nil
+}
+
+// LatestVersion is the version of the newest embedded migration, the schema version
+// this build expects
+func LatestVersion() uint {
+	all, err := List()
+	if err != nil || len(all) == 0 {
+		return 0
+	}
+	return all[len(all)-1].Version
+}
+
+// migrateLogger writes golang-migrate progress through slog
+type migrateLogger struct {
+	log *slog.Logger
+}
+
+func (l migrateLogger) Printf(format string, v ...any) {
+	l.log.Info(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (l migrateLogger) Verbose() bool {
+	return false
+}
@@ -2,30 +2,63 @@ package model
 
 import "time"
 
+// BlogPost is a blog post. ContentHTML is Content rendered and sanitized for display,
+// and ReadingTime is an estimate in minutes.
 type BlogPost struct {
-	ID          int64     `json:"id"`
-	Title       string    `json:"title"`
-	Slug        string    `json:"slug"`
-	Description string    `json:"description"`
-	Content     string    `json:"content"`
-	ImageURL    string    `json:"image_url,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID            int64          `json:"id"`
+	Title         string         `json:"title"`
+	Slug          string         `json:"slug"`
+	Description   string         `json:"description"`
+	Content       string         `json:"content"`
+	ContentHTML   string         `json:"content_html"`
+	ContentFormat ContentFormat  `json:"content_format"`
+	Excerpt       string         `json:"excerpt"`
+	ReadingTime   int            `json:"reading_time"`
+	ImageURL      string         `json:"image_url,omitempty"`
+	Status        PublishStatus  `json:"status"`
+	PublishedAt   *time.Time     `json:"published_at,omitempty"`
+	AuthorID      *int64         `json:"author_id,omitempty"`
+	Categories    []BlogCategory `json:"categories"`
+	Tags          []BlogTag      `json:"tags"`
+	SEO           SEOMetadata    `json:"seo"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
 }
 
+// CreateBlogPostRequest creates a blog post. ContentFormat defaults to html.
 type CreateBlogPostRequest struct {
-	Title       string `json:"title" validate:"required"`
-	Slug        string `json:"slug" validate:"required"`
-	Description string `json:"description" validate:"required"`
-	Content     string `json:"content" validate:"required"`
-	ImageURL    string `json:"image_url,omitempty"`
+	Title         string        `json:"title" validate:"required,max=255"`
+	Slug          string        `json:"slug" validate:"omitempty,slug,max=255"`
+	Description   string        `json:"description" validate:"required"`
+	Content       string        `json:"content" validate:"required"`
+	ContentFormat ContentFormat `json:"content_format" validate:"omitempty,oneof=markdown html"`
+	ImageURL      string        `json:"image_url,omitempty" validate:"omitempty,weburl,max=255"`
+	Status        PublishStatus `json:"status" validate:"omitempty,oneof=draft scheduled published archived"`
+	PublishedAt   *time.Time    `json:"published_at"`
+	CategoryIDs   []int         `json:"category_ids"`
+	Tags          []string      `json:"tags" validate:"omitempty,dive,required,max=100"`
+	SEO           SEOMetadata   `json:"seo"`
 }
 
+// UpdateBlogPostRequest updates a blog post. An empty ContentFormat keeps the current one.
 type UpdateBlogPostRequest struct {
-	Title       string `json:"title" validate:"required"`
-	Slug        string `json:"slug" validate:"required"`
-	Description string `json:"description" validate:"required"`
-	Content     string `json:"content" validate:"required"`
-	ImageURL    string `json:"image_url,omitempty"`
+	Title         string        `json:"title" validate:"required,max=255"`
+	Slug          string        `json:"slug" validate:"omitempty,slug,max=255"`
+	Description   string        `json:"description" validate:"required"`
+	Content       string        `json:"content" validate:"required"`
+	ContentFormat ContentFormat `json:"content_format" validate:"omitempty,oneof=markdown html"`
+	ImageURL      string        `json:"image_url,omitempty" validate:"omitempty,weburl,max=255"`
+	Status        PublishStatus `json:"status" validate:"omitempty,oneof=draft scheduled published archived"`
+	PublishedAt   *time.Time    `json:"published_at"`
+	CategoryIDs   []int         `json:"category_ids"`
+	Tags          []string      `json:"tags" validate:"omitempty,dive,required,max=100"`
+	SEO           SEOMetadata   `json:"seo"`
+}
+
+// BlogPostFilter narrows the public blog post list to a tag and/or category slug
+type BlogPostFilter struct {
+	Tag      string
+	Category string
 }
 
 type BlogPostResponse struct {
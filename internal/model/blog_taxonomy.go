@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+// BlogCategory is a section of the blog such as "recipes" or "news"
+type BlogCategory struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description,omitempty"`
+}
+
+// BlogCategoryDetail is a blog category with the number of published posts in it
+type BlogCategoryDetail struct {
+	BlogCategory
+	PostCount int64     `json:"post_count"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateBlogCategoryRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Slug        string `json:"slug" validate:"omitempty,slug,max=150"`
+	Description string `json:"description"`
+}
+
+type UpdateBlogCategoryRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Slug        string `json:"slug" validate:"omitempty,slug,max=150"`
+	Description string `json:"description"`
+}
+
+// BlogTag is a free-form label on blog posts
+type BlogTag struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// BlogTagDetail is a blog tag with the number of published posts carrying it
+type BlogTagDetail struct {
+	BlogTag
+	PostCount int64 `json:"post_count"`
+}
@@ -13,6 +13,7 @@ type GVA_MODEL struct {
 type APIResponse struct {
 	Status  string      `json:"status"`
 	Message string      `json:"message"`
+	Code    string      `json:"code,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
 	Errors  interface{} `json:"errors,omitempty"`
 }
@@ -94,6 +95,16 @@ func NewErrorResponse(message string, errors interface{}) APIResponse {
 	}
 }
 
+// NewCodedErrorResponse creates a new error response with a machine-readable error code
+func NewCodedErrorResponse(code, message string, errors interface{}) APIResponse {
+	return APIResponse{
+		Status:  "error",
+		Message: message,
+		Code:    code,
+		Errors:  errors,
+	}
+}
+
 // NewValidationError creates a new validation error
 func NewValidationError(field, message string) ValidationError {
 	return ValidationError{
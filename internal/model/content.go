@@ -0,0 +1,9 @@
+package model
+
+// ContentFormat is the markup language page and blog post content is written in
+type ContentFormat string
+
+const (
+	ContentFormatMarkdown ContentFormat = "markdown"
+	ContentFormatHTML     ContentFormat = "html"
+)
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DiscountType defines how a coupon discount is calculated
+type DiscountType string
+
+const (
+	DiscountTypePercentage DiscountType = "percentage"
+	DiscountTypeFixed      DiscountType = "fixed"
+)
+
+// Coupon represents a discount code
+type Coupon struct {
+	ID                int             `json:"id"`
+	Code              string          `json:"code"`
+	Description       string          `json:"description"`
+	DiscountType      DiscountType    `json:"discount_type"`
+	DiscountValue     decimal.Decimal `json:"discount_value"`
+	MaxDiscount       decimal.Decimal `json:"max_discount"`
+	MinOrderValue     decimal.Decimal `json:"min_order_value"`
+	UsageLimit        int             `json:"usage_limit"`
+	UsageLimitPerUser int             `json:"usage_limit_per_user"`
+	TimesUsed         int64           `json:"times_used"`
+	StartsAt          *time.Time      `json:"starts_at,omitempty"`
+	ExpiresAt         *time.Time      `json:"expires_at,omitempty"`
+	IsActive          bool            `json:"is_active"`
+	CategoryIDs       []int           `json:"category_ids"`
+	ProductIDs        []int           `json:"product_ids"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+}
+
+// CreateCouponRequest represents the request to create a coupon.
+// A zero MaxDiscount, UsageLimit or UsageLimitPerUser means no limit.
+type CreateCouponRequest struct {
+	Code              string          `json:"code" validate:"required,max=50"`
+	Description       string          `json:"description"`
+	DiscountType      DiscountType    `json:"discount_type" validate:"required,oneof=percentage fixed"`
+	DiscountValue     decimal.Decimal `json:"discount_value" validate:"required,gt=0"`
+	MaxDiscount       decimal.Decimal `json:"max_discount" validate:"gte=0"`
+	MinOrderValue     decimal.Decimal `json:"min_order_value" validate:"gte=0"`
+	UsageLimit        int             `json:"usage_limit" validate:"gte=0"`
+	UsageLimitPerUser int             `json:"usage_limit_per_user" validate:"gte=0"`
+	StartsAt          *time.Time      `json:"starts_at"`
+	ExpiresAt         *time.Time      `json:"expires_at"`
+	IsActive          *bool           `json:"is_active"`
+	CategoryIDs       []int           `json:"category_ids"`
+	ProductIDs        []int           `json:"product_ids"`
+}
+
+// UpdateCouponRequest represents the request to update a coupon.
+// A zero MaxDiscount, UsageLimit or UsageLimitPerUser means no limit.
+type UpdateCouponRequest struct {
+	Code              string          `json:"code" validate:"required,max=50"`
+	Description       string          `json:"description"`
+	DiscountType      DiscountType    `json:"discount_type" validate:"required,oneof=percentage fixed"`
+	DiscountValue     decimal.Decimal `json:"discount_value" validate:"required,gt=0"`
+	MaxDiscount       decimal.Decimal `json:"max_discount" validate:"gte=0"`
+	MinOrderValue     decimal.Decimal `json:"min_order_value" validate:"gte=0"`
+	UsageLimit        int             `json:"usage_limit" validate:"gte=0"`
+	UsageLimitPerUser int             `json:"usage_limit_per_user" validate:"gte=0"`
+	StartsAt          *time.Time      `json:"starts_at"`
+	ExpiresAt         *time.Time      `json:"expires_at"`
+	IsActive          *bool           `json:"is_active"`
+	CategoryIDs       []int           `json:"category_ids"`
+	ProductIDs        []int           `json:"product_ids"`
+}
+
+// CartItem represents a single line of a cart submitted for coupon validation
+type CartItem struct {
+	ProductID int `json:"product_id" validate:"required"`
+	Quantity  int `json:"quantity" validate:"required,gt=0"`
+}
+
+// ValidateCouponRequest represents the request to validate a coupon against a cart
+type ValidateCouponRequest struct {
+	Code  string     `json:"code" validate:"required"`
+	Items []CartItem `json:"items" validate:"required,min=1,dive"`
+}
+
+// CouponLineItem represents the discount breakdown for a single cart line
+type CouponLineItem struct {
+	ProductID int             `json:"product_id"`
+	Name      string          `json:"name"`
+	Quantity  int             `json:"quantity"`
+	UnitPrice decimal.Decimal `json:"unit_price"`
+	LineTotal decimal.Decimal `json:"line_total"`
+	Eligible  bool            `json:"eligible"`
+	Discount  decimal.Decimal `json:"discount"`
+}
+
+// ValidateCouponResponse represents the result of validating a coupon against a cart
+type ValidateCouponResponse struct {
+	Code             string           `json:"code"`
+	Valid            bool             `json:"valid"`
+	Reason           string           `json:"reason,omitempty"`
+	DiscountType     DiscountType     `json:"discount_type,omitempty"`
+	DiscountValue    decimal.Decimal  `json:"discount_value"`
+	Subtotal         decimal.Decimal  `json:"subtotal"`
+	EligibleSubtotal decimal.Decimal  `json:"eligible_subtotal"`
+	Discount         decimal.Decimal  `json:"discount"`
+	Total            decimal.Decimal  `json:"total"`
+	Items            []CouponLineItem `json:"items"`
+}
+
+// RedeemCouponRequest represents the request to record a coupon use when an order is
+// placed. UserID is the customer placing the order, zero for guests.
+type RedeemCouponRequest struct {
+	ValidateCouponRequest
+	UserID         int64  `json:"user_id" validate:"gte=0"`
+	OrderReference string `json:"order_reference" validate:"required,max=100"`
+}
+
+// CouponRedemption represents a recorded coupon use and the discount it granted
+type CouponRedemption struct {
+	ID             int    `json:"id"`
+	CouponID       int    `json:"coupon_id"`
+	UserID         int64  `json:"user_id,omitempty"`
+	OrderReference string `json:"order_reference"`
+	ValidateCouponResponse
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ExchangeRate is the value of one unit of Currency in the base currency, e.g. a USD
+// rate of 25400 with base currency VND. A rate applies from EffectiveAt until a newer
+// rate of the same currency takes effect.
+type ExchangeRate struct {
+	ID          int             `json:"id"`
+	Currency    string          `json:"currency"`
+	Rate        decimal.Decimal `json:"rate"`
+	EffectiveAt time.Time       `json:"effective_at"`
+	CreatedBy   *int64          `json:"created_by,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// CreateExchangeRateRequest sets a new rate for a currency. EffectiveAt defaults to now.
+type CreateExchangeRateRequest struct {
+	Currency    string          `json:"currency" validate:"required,len=3,alpha"`
+	Rate        decimal.Decimal `json:"rate" validate:"required,gt=0"`
+	EffectiveAt *time.Time      `json:"effective_at"`
+}
+
+// ExchangeRatesResponse lists the rates currently in effect against the base currency
+type ExchangeRatesResponse struct {
+	BaseCurrency string         `json:"base_currency"`
+	Rates        []ExchangeRate `json:"rates"`
+}
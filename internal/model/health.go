@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// Health check statuses
+const (
+	HealthStatusOK   = "ok"
+	HealthStatusFail = "fail"
+)
+
+// HealthCheck is the result of checking one dependency
+type HealthCheck struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	Message   string  `json:"message,omitempty"`
+	LatencyMS float64 `json:"latency_ms"`
+}
+
+// HealthReport is the readiness of the service: ok only when every check is ok
+type HealthReport struct {
+	Status string        `json:"status"`
+	Checks []HealthCheck `json:"checks"`
+}
+
+// PoolStats are the statistics of the database connection pool
+type PoolStats struct {
+	AcquiredConns        int32   `json:"acquired_conns"`
+	IdleConns            int32   `json:"idle_conns"`
+	TotalConns           int32   `json:"total_conns"`
+	MaxConns             int32   `json:"max_conns"`
+	AcquireCount         int64   `json:"acquire_count"`
+	EmptyAcquireCount    int64   `json:"empty_acquire_count"`
+	CanceledAcquireCount int64   `json:"canceled_acquire_count"`
+	AcquireDurationMS    float64 `json:"acquire_duration_ms"`
+}
+
+// HealthDetails is the admin view of the service health
+type HealthDetails struct {
+	HealthReport
+	Version       string    `json:"version"`
+	Commit        string    `json:"commit"`
+	StartedAt     time.Time `json:"started_at"`
+	UptimeSeconds int64     `json:"uptime_seconds"`
+	Pool          PoolStats `json:"pool"`
+}
@@ -2,22 +2,38 @@ package model
 
 import "time"
 
+// Page is a static page. ContentHTML is Content rendered and sanitized for display.
 type Page struct {
-	ID        int64     `json:"id"`
-	Title     string    `json:"title"`
-	Slug      string    `json:"slug"`
-	Content   string    `json:"content"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            int64         `json:"id"`
+	Title         string        `json:"title"`
+	Slug          string        `json:"slug"`
+	Content       string        `json:"content"`
+	ContentHTML   string        `json:"content_html"`
+	ContentFormat ContentFormat `json:"content_format"`
+	Status        PublishStatus `json:"status"`
+	PublishedAt   *time.Time    `json:"published_at,omitempty"`
+	SEO           SEOMetadata   `json:"seo"`
+	CreatedAt     time.Time     `json:"created_at"`
 }
 
+// CreatePageRequest creates a page. ContentFormat defaults to html.
 type CreatePageRequest struct {
-	Title   string `json:"title" validate:"required"`
-	Slug    string `json:"slug" validate:"required"`
-	Content string `json:"content" validate:"required"`
+	Title         string        `json:"title" validate:"required,max=255"`
+	Slug          string        `json:"slug" validate:"omitempty,slug,max=255"`
+	Content       string        `json:"content" validate:"required"`
+	ContentFormat ContentFormat `json:"content_format" validate:"omitempty,oneof=markdown html"`
+	Status        PublishStatus `json:"status" validate:"omitempty,oneof=draft scheduled published archived"`
+	PublishedAt   *time.Time    `json:"published_at"`
+	SEO           SEOMetadata   `json:"seo"`
 }
 
+// UpdatePageRequest updates a page. An empty ContentFormat keeps the current one.
 type UpdatePageRequest struct {
-	Title   string `json:"title" validate:"required"`
-	Slug    string `json:"slug" validate:"required"`
-	Content string `json:"content" validate:"required"`
+	Title         string        `json:"title" validate:"required,max=255"`
+	Slug          string        `json:"slug" validate:"omitempty,slug,max=255"`
+	Content       string        `json:"content" validate:"required"`
+	ContentFormat ContentFormat `json:"content_format" validate:"omitempty,oneof=markdown html"`
+	Status        PublishStatus `json:"status" validate:"omitempty,oneof=draft scheduled published archived"`
+	PublishedAt   *time.Time    `json:"published_at"`
+	SEO           SEOMetadata   `json:"seo"`
 }
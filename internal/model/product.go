@@ -1,74 +1,89 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 // Category represents a product category
 type Category struct {
-	ID          int       `json:"id"`
-	Name        string    `json:"name"`
-	Slug        string    `json:"slug"`
-	Description string    `json:"description,omitempty"`
-	ImageURL    string    `json:"image_url,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          int         `json:"id"`
+	Name        string      `json:"name"`
+	Slug        string      `json:"slug"`
+	Description string      `json:"description,omitempty"`
+	ImageURL    string      `json:"image_url,omitempty"`
+	SEO         SEOMetadata `json:"seo"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
 }
 
 // CreateCategoryRequest represents the request to create a category
 type CreateCategoryRequest struct {
-	Name        string `json:"name" validate:"required"`
-	Slug        string `json:"slug" validate:"required"`
-	Description string `json:"description"`
-	ImageURL    string `json:"image_url"`
+	Name        string      `json:"name" validate:"required,max=100"`
+	Slug        string      `json:"slug" validate:"omitempty,slug,max=150"`
+	Description string      `json:"description"`
+	ImageURL    string      `json:"image_url" validate:"omitempty,weburl,max=255"`
+	SEO         SEOMetadata `json:"seo"`
 }
 
 // UpdateCategoryRequest represents the request to update a category
 type UpdateCategoryRequest struct {
-	Name        string `json:"name" validate:"required"`
-	Slug        string `json:"slug" validate:"required"`
-	Description string `json:"description"`
-	ImageURL    string `json:"image_url"`
+	Name        string      `json:"name" validate:"required,max=100"`
+	Slug        string      `json:"slug" validate:"omitempty,slug,max=150"`
+	Description string      `json:"description"`
+	ImageURL    string      `json:"image_url" validate:"omitempty,weburl,max=255"`
+	SEO         SEOMetadata `json:"seo"`
 }
 
-// Product represents a product in the system
+// Product represents a product in the system. Price and PriceSale are in Currency,
+// the base currency unless another one was requested. SEO and UpdatedAt are only set
+// on single product responses, not in lists.
 type Product struct {
-	ID                int       `json:"id"`
-	CategoryID        int       `json:"category_id"`
-	Name              string    `json:"name"`
-	Slug              string    `json:"slug"`
-	Description       string    `json:"description"`
-	Price             float64   `json:"price"`
-	PriceSale         float64   `json:"price_sale,omitempty"`
-	ImageURL          string    `json:"image_url"`
-	ThumbURL          string    `json:"thumb_url"`
-	CreatedAt         time.Time `json:"created_at"`
-	CategoryName      string    `json:"category_name"`
-	CategorySlug      string    `json:"category_slug"`
-	UnitOfMeasurement string    `json:"unit_of_measurement"`
+	ID                int             `json:"id"`
+	CategoryID        int             `json:"category_id"`
+	Name              string          `json:"name"`
+	Slug              string          `json:"slug"`
+	Description       string          `json:"description"`
+	Price             decimal.Decimal `json:"price"`
+	PriceSale         decimal.Decimal `json:"price_sale"`
+	Currency          string          `json:"currency"`
+	ImageURL          string          `json:"image_url"`
+	ThumbURL          string          `json:"thumb_url"`
+	SEO               *SEOMetadata    `json:"seo,omitempty"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         *time.Time      `json:"updated_at,omitempty"`
+	CategoryName      string          `json:"category_name"`
+	CategorySlug      string          `json:"category_slug"`
+	UnitOfMeasurement string          `json:"unit_of_measurement"`
 }
 
 // CreateProductRequest represents the request body for product creation
 type CreateProductRequest struct {
-	CategoryID        int     `json:"category_id" validate:"required"`
-	Name              string  `json:"name" validate:"required"`
-	Slug              string  `json:"slug" validate:"required"`
-	Description       string  `json:"description"`
-	Price             float64 `json:"price" validate:"required,gt=0"`
-	PriceSale         float64 `json:"price_sale,omitempty" validate:"omitempty,gtefield=0"`
-	ImageURL          string  `json:"image_url"`
-	ThumbURL          string  `json:"thumb_url"`
-	UnitOfMeasurement string  `json:"unit_of_measurement"`
+	CategoryID        int             `json:"category_id" validate:"required,gt=0"`
+	Name              string          `json:"name" validate:"required,max=150"`
+	Slug              string          `json:"slug" validate:"omitempty,slug,max=200"`
+	Description       string          `json:"description"`
+	Price             decimal.Decimal `json:"price" validate:"required,gt=0"`
+	PriceSale         decimal.Decimal `json:"price_sale" validate:"omitempty,gte=0,ltfield=Price"`
+	ImageURL          string          `json:"image_url" validate:"omitempty,weburl,max=255"`
+	ThumbURL          string          `json:"thumb_url" validate:"omitempty,weburl,max=255"`
+	UnitOfMeasurement string          `json:"unit_of_measurement" validate:"max=50"`
+	SEO               SEOMetadata     `json:"seo"`
 }
 
 // UpdateProductRequest represents the request body for product update
 type UpdateProductRequest struct {
-	CategoryID        int     `json:"category_id" validate:"required"`
-	Name              string  `json:"name" validate:"required"`
-	Slug              string  `json:"slug" validate:"required"`
-	Description       string  `json:"description"`
-	Price             float64 `json:"price" validate:"required,gt=0"`
-	PriceSale         float64 `json:"price_sale,omitempty" validate:"omitempty,gtefield=0"`
-	ImageURL          string  `json:"image_url"`
-	ThumbURL          string  `json:"thumb_url"`
-	UnitOfMeasurement string  `json:"unit_of_measurement"`
+	CategoryID        int             `json:"category_id" validate:"required,gt=0"`
+	Name              string          `json:"name" validate:"required,max=150"`
+	Slug              string          `json:"slug" validate:"omitempty,slug,max=200"`
+	Description       string          `json:"description"`
+	Price             decimal.Decimal `json:"price" validate:"required,gt=0"`
+	PriceSale         decimal.Decimal `json:"price_sale" validate:"omitempty,gte=0,ltfield=Price"`
+	ImageURL          string          `json:"image_url" validate:"omitempty,weburl,max=255"`
+	ThumbURL          string          `json:"thumb_url" validate:"omitempty,weburl,max=255"`
+	UnitOfMeasurement string          `json:"unit_of_measurement" validate:"max=50"`
+	SEO               SEOMetadata     `json:"seo"`
 }
 
 // CategoryProductsResponse represents a category with its products
@@ -90,3 +105,32 @@ type CategoryWithProductsResponse struct {
 	Products   []Product         `json:"products"`
 	Pagination PaginatedResponse `json:"pagination"`
 }
+
+// ProductImportRow represents a single product row in a bulk import or export
+type ProductImportRow struct {
+	CategorySlug      string          `json:"category_slug" validate:"required"`
+	Name              string          `json:"name" validate:"required,max=150"`
+	Slug              string          `json:"slug" validate:"required,slug,max=200"`
+	Description       string          `json:"description"`
+	Price             decimal.Decimal `json:"price" validate:"required,gt=0"`
+	PriceSale         decimal.Decimal `json:"price_sale" validate:"omitempty,gte=0,ltfield=Price"`
+	UnitOfMeasurement string          `json:"unit_of_measurement" validate:"max=50"`
+	ImageURL          string          `json:"image_url" validate:"omitempty,weburl,max=255"`
+	ThumbURL          string          `json:"thumb_url" validate:"omitempty,weburl,max=255"`
+}
+
+// ProductImportRowError represents the validation errors of a single import row
+type ProductImportRowError struct {
+	Row    int               `json:"row"`
+	Slug   string            `json:"slug,omitempty"`
+	Errors []ValidationError `json:"errors"`
+}
+
+// ProductImportResult represents the outcome of a bulk product import
+type ProductImportResult struct {
+	DryRun    bool                    `json:"dry_run"`
+	TotalRows int                     `json:"total_rows"`
+	Created   int                     `json:"created"`
+	Updated   int                     `json:"updated"`
+	Errors    []ProductImportRowError `json:"errors"`
+}
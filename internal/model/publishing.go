@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// PublishStatus is the publishing state of a blog post or page
+type PublishStatus string
+
+const (
+	// PublishStatusDraft is only visible to admins
+	PublishStatusDraft PublishStatus = "draft"
+	// PublishStatusScheduled becomes public once published_at has passed
+	PublishStatusScheduled PublishStatus = "scheduled"
+	// PublishStatusPublished is public from published_at onwards
+	PublishStatusPublished PublishStatus = "published"
+	// PublishStatusArchived is hidden from the public but kept for admins
+	PublishStatusArchived PublishStatus = "archived"
+)
+
+// PreviewTokenResponse is returned when an admin requests a preview link for unpublished content
+type PreviewTokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// ContentRevision is a saved version of a blog post or page
+type ContentRevision struct {
+	ID            int64         `json:"id"`
+	ResourceType  string        `json:"resource_type"`
+	ResourceID    int64         `json:"resource_id"`
+	Title         string        `json:"title"`
+	Slug          string        `json:"slug"`
+	Description   string        `json:"description,omitempty"`
+	Content       string        `json:"content"`
+	ContentFormat ContentFormat `json:"content_format"`
+	AuthorID      *int64        `json:"author_id,omitempty"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+// DiffOp is the kind of change a diff line represents
+type DiffOp string
+
+const (
+	DiffOpEqual  DiffOp = "equal"
+	DiffOpInsert DiffOp = "insert"
+	DiffOpDelete DiffOp = "delete"
+)
+
+// DiffLine is one line of a line-based diff
+type DiffLine struct {
+	Op   DiffOp `json:"op"`
+	Text string `json:"text"`
+}
+
+// FieldDiff holds the line changes of a single field between two revisions
+type FieldDiff struct {
+	Field   string     `json:"field"`
+	Changes []DiffLine `json:"changes"`
+}
+
+// RevisionDiff lists the fields that differ between two revisions
+type RevisionDiff struct {
+	FromRevisionID int64       `json:"from_revision_id"`
+	ToRevisionID   int64       `json:"to_revision_id"`
+	Fields         []FieldDiff `json:"fields"`
+}
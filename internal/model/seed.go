@@ -0,0 +1,37 @@
+package model
+
+// DemoCatalog is the demo data set loaded by the seed command. Products and the
+// show_product_category setting refer to categories by slug, since IDs depend on
+// the database.
+type DemoCatalog struct {
+	// Version is bumped whenever the fixture changes
+	Version             int                           `json:"version"`
+	Categories          []CreateCategoryRequest       `json:"categories"`
+	Products            []DemoProduct                 `json:"products"`
+	Pages               []CreatePageRequest           `json:"pages"`
+	BlogPosts           []CreateBlogPostRequest       `json:"blog_posts"`
+	ShowProductCategory []string                      `json:"show_product_category"`
+	Settings            []CreateWebsiteSettingRequest `json:"settings"`
+}
+
+// DemoProduct is a product of the demo catalog, placed in a category by slug
+type DemoProduct struct {
+	CategorySlug string `json:"category" validate:"required"`
+	CreateProductRequest
+}
+
+// SeedAdminResult tells what the seed command did for the initial admin
+type SeedAdminResult struct {
+	User User `json:"user"`
+	// Created is set when the account did not exist
+	Created bool `json:"created"`
+	// Promoted is set when an existing account was made an admin
+	Promoted bool `json:"promoted"`
+}
+
+// SeedResult counts what a seed run created and what already existed
+type SeedResult struct {
+	Version int `json:"version"`
+	Created int `json:"created"`
+	Skipped int `json:"skipped"`
+}
@@ -0,0 +1,10 @@
+package model
+
+// SEOMetadata holds the search engine fields of a product, category, blog post or page.
+// Empty fields fall back to the resource's own title, description, URL and image.
+type SEOMetadata struct {
+	MetaTitle       string `json:"meta_title" validate:"max=255"`
+	MetaDescription string `json:"meta_description" validate:"max=500"`
+	CanonicalURL    string `json:"canonical_url" validate:"omitempty,weburl,max=500"`
+	OGImageURL      string `json:"og_image_url" validate:"omitempty,weburl,max=500"`
+}
@@ -0,0 +1,31 @@
+package model
+
+import "encoding/xml"
+
+// SitemapNamespace is the XML namespace of sitemap and sitemap index documents
+const SitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// SitemapURL is a page listed in a sitemap. LastMod is a W3C date.
+type SitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// SitemapURLSet is a sitemap document
+type SitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []SitemapURL `xml:"url"`
+}
+
+// SitemapRef is a sitemap file listed in a sitemap index
+type SitemapRef struct {
+	Loc string `xml:"loc"`
+}
+
+// SitemapIndex is a sitemap index document, used when the site has too many URLs for one sitemap
+type SitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	Xmlns    string       `xml:"xmlns,attr"`
+	Sitemaps []SitemapRef `xml:"sitemap"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// Translation is the text of a product, category, blog post or page in one locale.
+// Products and categories use Name and Description, blog posts use Title, Description
+// and Content, and pages use Title and Content.
+type Translation struct {
+	ResourceType string    `json:"resource_type"`
+	ResourceID   int64     `json:"resource_id"`
+	Locale       string    `json:"locale"`
+	Name         string    `json:"name,omitempty"`
+	Title        string    `json:"title,omitempty"`
+	Description  string    `json:"description,omitempty"`
+	Content      string    `json:"content,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// UpsertTranslationRequest adds or replaces a translation. Fields that do not apply to
+// the resource are ignored.
+type UpsertTranslationRequest struct {
+	Name        string `json:"name" validate:"max=150"`
+	Title       string `json:"title" validate:"max=255"`
+	Description string `json:"description"`
+	Content     string `json:"content"`
+}
+
+// MissingTranslation is a resource that has no translation in Locale. Label is its
+// name or title in the default locale.
+type MissingTranslation struct {
+	ResourceType string `json:"resource_type"`
+	ResourceID   int64  `json:"resource_id"`
+	Slug         string `json:"slug"`
+	Label        string `json:"label"`
+	Locale       string `json:"locale"`
+}
@@ -16,8 +16,8 @@ type User struct {
 
 // SignUpRequest represents the request body for user registration
 type SignUpRequest struct {
-	Password string `json:"password" validate:"required,min=6"`
-	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=6,max=72"`
+	Email    string `json:"email" validate:"required,email,max=255"`
 }
 
 // LoginRequest represents the request body for user login
@@ -9,7 +9,7 @@ type WebsiteSetting struct {
 
 // CreateWebsiteSettingRequest represents the request to create a website setting
 type CreateWebsiteSettingRequest struct {
-	Name  string `json:"name" validate:"required"`
+	Name  string `json:"name" validate:"required,max=255"`
 	Value string `json:"value" validate:"required"`
 }
 
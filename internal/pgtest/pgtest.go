@@ -0,0 +1,318 @@
+// Package pgtest runs tests against a disposable PostgreSQL server. Main starts one
+// server per test binary from the embedded-postgres binaries and applies migrations/
+// to a template database; NewDatabase then gives every test its own copy of it.
+//
+// The binaries are downloaded once into ~/.embedded-postgres-go, or the directory
+// named by PGTEST_CACHE, and reused offline afterwards. PGTEST_BINARIES points at
+// already extracted binaries instead, PGTEST_BINARY_REPOSITORY at a Maven mirror to
+// download from, and TEST_DATABASE_URL uses an existing server, whose user must be
+// allowed to create databases.
+//
+// When the binaries are neither present nor downloadable, e.g. offline, the tests
+// using a database are skipped with the reason, unless PGTEST_REQUIRE is set.
+package pgtest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"beef-db-be/internal/config"
+	"beef-db-be/internal/migration"
+)
+
+var (
+	// templateName is the database holding the migrated, empty schema. It is named
+	// after the process, since go test runs the packages side by side.
+	templateName = fmt.Sprintf("pgtest_template_%d", os.Getpid())
+	// admin connects to the maintenance database of the server, set by Main
+	admin config.DatabaseConfig
+	// skipReason is set when the tests run without a server
+	skipReason string
+
+	// cloneMu serialises the copies, since PostgreSQL refuses to copy a template
+	// while another copy of it is being made
+	cloneMu sync.Mutex
+	counter atomic.Int64
+)
+
+// errNoBinaries is returned by start when there are no binaries to run a server from
+var errNoBinaries = errors.New("PostgreSQL binaries are not available")
+
+// Main starts the server, runs the tests and stops the server again. Call it from the
+// TestMain of every package using NewDatabase:
+//
+//	func TestMain(m *testing.M) { pgtest.Main(m) }
+//
+// With -short, or without binaries to run, no server is started and the tests using
+// a database are skipped.
+func Main(m *testing.M) {
+	flag.Parse()
+	if testing.Short() {
+		skipReason = "needs PostgreSQL, skipped in short mode"
+		os.Exit(m.Run())
+	}
+
+	stop, err := start()
+	if errors.Is(err, errNoBinaries) && os.Getenv("PGTEST_REQUIRE") == "" {
+		skipReason = fmt.Sprintf("needs PostgreSQL: %v", err)
+		fmt.Fprintln(os.Stderr, "pgtest: skipping the database tests:", err)
+		os.Exit(m.Run())
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "pgtest:", err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	if err := stop(); err != nil {
+		fmt.Fprintln(os.Stderr, "pgtest:", err)
+	}
+	os.Exit(code)
+}
+
+// NewDatabase creates a database holding the migrated schema and no rows, and drops
+// it when the test ends
+func NewDatabase(t testing.TB) config.DatabaseConfig {
+	t.Helper()
+	if skipReason != "" {
+		t.Skip(skipReason)
+	}
+	if admin.Name == "" {
+		t.Fatal("pgtest: the server is not running, call pgtest.Main from TestMain")
+	}
+
+	cfg := admin
+	cfg.Name = fmt.Sprintf("pgtest_%d_%d", os.Getpid(), counter.Add(1))
+
+	cloneMu.Lock()
+	err := exec(context.Background(), admin, fmt.Sprintf("CREATE DATABASE %s TEMPLATE %s",
+		pgx.Identifier{cfg.Name}.Sanitize(), pgx.Identifier{templateName}.Sanitize()))
+	cloneMu.Unlock()
+	if err != nil {
+		t.Fatalf("pgtest: %v", err)
+	}
+
+	t.Cleanup(func() {
+		err := exec(context.Background(), admin, fmt.Sprintf("DROP DATABASE IF EXISTS %s WITH (FORCE)",
+			pgx.Identifier{cfg.Name}.Sanitize()))
+		if err != nil {
+			t.Errorf("pgtest: %v", err)
+		}
+	})
+	return cfg
+}
+
+// NewPool returns a pool on a database of its own, see NewDatabase. The pool is
+// closed when the test ends.
+func NewPool(t testing.TB) *pgxpool.Pool {
+	t.Helper()
+	cfg := NewDatabase(t)
+
+	pool, err := config.NewDBPool(cfg)
+	if err != nil {
+		t.Fatalf("pgtest: %v", err)
+	}
+	// Registered after NewDatabase, so it runs before the database is dropped
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+// start starts or connects to the server and prepares the template database
+func start() (stop func() error, err error) {
+	if dsn := os.Getenv("TEST_DATABASE_URL"); dsn != "" {
+		admin, err = parseURL(dsn)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TEST_DATABASE_URL: %w", err)
+		}
+		// The server outlives the tests, so only the template is removed
+		stop = func() error {
+			return exec(context.Background(), admin, "DROP DATABASE IF EXISTS "+
+				pgx.Identifier{templateName}.Sanitize()+" WITH (FORCE)")
+		}
+	} else {
+		stop, err = startEmbedded()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := createTemplate(); err != nil {
+		stop()
+		return nil, err
+	}
+	return stop, nil
+}
+
+// startEmbedded starts a server of its own on a free port, in a temporary directory
+// removed by stop
+func startEmbedded() (stop func() error, err error) {
+	port, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", "pgtest-")
+	if err != nil {
+		return nil, err
+	}
+
+	var logs bytes.Buffer
+	pgConfig := embeddedpostgres.DefaultConfig().
+		Version(embeddedpostgres.V16).
+		Port(uint32(port)).
+		RuntimePath(dir).
+		StartTimeout(time.Minute).
+		Logger(&logs)
+	binaries := os.Getenv("PGTEST_BINARIES")
+	if binaries != "" {
+		pgConfig = pgConfig.BinariesPath(binaries)
+	}
+	cache := os.Getenv("PGTEST_CACHE")
+	if cache != "" {
+		pgConfig = pgConfig.CachePath(cache)
+	}
+	if repository := os.Getenv("PGTEST_BINARY_REPOSITORY"); repository != "" {
+		pgConfig = pgConfig.BinaryRepositoryURL(repository)
+	}
+	present := hasBinaries(binaries, cache)
+
+	server := embeddedpostgres.NewDatabase(pgConfig)
+	if err := server.Start(); err != nil {
+		os.RemoveAll(dir)
+		if !present {
+			// Start failed while downloading, since there was nothing to run yet
+			return nil, fmt.Errorf("%w and could not be downloaded (%v); set PGTEST_BINARIES or "+
+				"PGTEST_CACHE to a local copy, PGTEST_BINARY_REPOSITORY to a mirror, or TEST_DATABASE_URL "+
+				"to an existing server", errNoBinaries, err)
+		}
+		return nil, fmt.Errorf("error starting PostgreSQL: %w\n%s", err, logs.String())
+	}
+
+	admin = config.DatabaseConfig{
+		Host:     "localhost",
+		Port:     port,
+		User:     "postgres",
+		Password: "postgres",
+		Name:     "postgres",
+		SSLMode:  "disable",
+		MaxConns: 4,
+	}
+	return func() error {
+		defer os.RemoveAll(dir)
+		return server.Stop()
+	}, nil
+}
+
+// hasBinaries reports whether the server can start without downloading: binaries are
+// extracted at binaries, or the archive of the version is in the cache directory
+func hasBinaries(binaries, cache string) bool {
+	if binaries != "" {
+		if _, err := os.Stat(filepath.Join(binaries, "bin", "pg_ctl")); err == nil {
+			return true
+		}
+	}
+	if cache == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return false
+		}
+		cache = filepath.Join(home, ".embedded-postgres-go")
+	}
+	archives, _ := filepath.Glob(filepath.Join(cache, "embedded-postgres-binaries-*-"+string(embeddedpostgres.V16)+".txz"))
+	return len(archives) > 0
+}
+
+// createTemplate creates the template database from scratch and migrates it
+func createTemplate() error {
+	ctx := context.Background()
+	template := pgx.Identifier{templateName}.Sanitize()
+	if err := exec(ctx, admin, "DROP DATABASE IF EXISTS "+template+" WITH (FORCE)"); err != nil {
+		return err
+	}
+	if err := exec(ctx, admin, "CREATE DATABASE "+template); err != nil {
+		return err
+	}
+
+	cfg := admin
+	cfg.Name = templateName
+	runner, err := migration.NewRunner(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		return err
+	}
+	defer runner.Close()
+	return runner.Up()
+}
+
+// exec runs statement on its own connection, since CREATE DATABASE and DROP DATABASE
+// cannot run inside the implicit transaction of a pool
+func exec(ctx context.Context, cfg config.DatabaseConfig, statement string) error {
+	conn, err := pgx.Connect(ctx, cfg.ConnString())
+	if err != nil {
+		return fmt.Errorf("error connecting to %s: %w", cfg.Name, err)
+	}
+	defer conn.Close(ctx)
+
+	if _, err := conn.Exec(ctx, statement); err != nil {
+		return fmt.Errorf("error running %q: %w", statement, err)
+	}
+	return nil
+}
+
+// parseURL reads a postgres:// URL into the configuration used by the API
+func parseURL(dsn string) (config.DatabaseConfig, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return config.DatabaseConfig{}, err
+	}
+
+	cfg := config.DatabaseConfig{
+		Host:     u.Hostname(),
+		Port:     5432,
+		User:     u.User.Username(),
+		Name:     u.Path,
+		SSLMode:  u.Query().Get("sslmode"),
+		MaxConns: 4,
+	}
+	cfg.Password, _ = u.User.Password()
+	if len(cfg.Name) > 0 && cfg.Name[0] == '/' {
+		cfg.Name = cfg.Name[1:]
+	}
+	if cfg.Name == "" {
+		cfg.Name = "postgres"
+	}
+	if cfg.SSLMode == "" {
+		cfg.SSLMode = "disable"
+	}
+	if port := u.Port(); port != "" {
+		if cfg.Port, err = strconv.Atoi(port); err != nil {
+			return config.DatabaseConfig{}, err
+		}
+	}
+	return cfg, nil
+}
+
+// freePort asks the kernel for a port nobody listens on
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
@@ -6,26 +6,85 @@ package repository
 
 import (
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
 )
 
-type BlogPost struct {
+type BlogCategory struct {
 	ID          int32            `json:"id"`
-	Title       string           `json:"title"`
+	Name        string           `json:"name"`
 	Slug        string           `json:"slug"`
 	Description string           `json:"description"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+}
+
+type BlogPostCategory struct {
+	BlogPostID int32 `json:"blog_post_id"`
+	CategoryID int32 `json:"category_id"`
+}
+
+type BlogPostTag struct {
+	BlogPostID int32 `json:"blog_post_id"`
+	TagID      int32 `json:"tag_id"`
+}
+
+type BlogPostTranslation struct {
+	BlogPostID  int32            `json:"blog_post_id"`
+	Locale      string           `json:"locale"`
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
 	Content     string           `json:"content"`
-	ImageUrl    string           `json:"image_url"`
 	CreatedAt   pgtype.Timestamp `json:"created_at"`
 	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
 }
 
+type BlogPost struct {
+	ID              int32            `json:"id"`
+	Title           string           `json:"title"`
+	Slug            string           `json:"slug"`
+	Description     string           `json:"description"`
+	Content         string           `json:"content"`
+	ImageUrl        string           `json:"image_url"`
+	CreatedAt       pgtype.Timestamp `json:"created_at"`
+	UpdatedAt       pgtype.Timestamp `json:"updated_at"`
+	Status          string           `json:"status"`
+	PublishedAt     pgtype.Timestamp `json:"published_at"`
+	AuthorID        pgtype.Int8      `json:"author_id"`
+	ContentFormat   string           `json:"content_format"`
+	MetaTitle       string           `json:"meta_title"`
+	MetaDescription string           `json:"meta_description"`
+	CanonicalUrl    string           `json:"canonical_url"`
+	OgImageUrl      string           `json:"og_image_url"`
+}
+
+type BlogTag struct {
+	ID        int32            `json:"id"`
+	Name      string           `json:"name"`
+	Slug      string           `json:"slug"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+}
+
 type Category struct {
-	ID          int32            `json:"id"`
+	ID              int32            `json:"id"`
+	Name            string           `json:"name"`
+	Slug            string           `json:"slug"`
+	Description     pgtype.Text      `json:"description"`
+	ImageUrl        pgtype.Text      `json:"image_url"`
+	CreatedAt       pgtype.Timestamp `json:"created_at"`
+	MetaTitle       string           `json:"meta_title"`
+	MetaDescription string           `json:"meta_description"`
+	CanonicalUrl    string           `json:"canonical_url"`
+	OgImageUrl      string           `json:"og_image_url"`
+	UpdatedAt       pgtype.Timestamp `json:"updated_at"`
+}
+
+type CategoryTranslation struct {
+	CategoryID  int32            `json:"category_id"`
+	Locale      string           `json:"locale"`
 	Name        string           `json:"name"`
-	Slug        string           `json:"slug"`
-	Description pgtype.Text      `json:"description"`
-	ImageUrl    pgtype.Text      `json:"image_url"`
+	Description string           `json:"description"`
 	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
 }
 
 type ContactMessage struct {
@@ -36,12 +95,95 @@ type ContactMessage struct {
 	CreatedAt pgtype.Timestamp `json:"created_at"`
 }
 
-type Page struct {
+type ContentRevision struct {
+	ID            int32            `json:"id"`
+	ResourceType  string           `json:"resource_type"`
+	ResourceID    int32            `json:"resource_id"`
+	Title         string           `json:"title"`
+	Slug          string           `json:"slug"`
+	Description   string           `json:"description"`
+	Content       string           `json:"content"`
+	AuthorID      pgtype.Int8      `json:"author_id"`
+	CreatedAt     pgtype.Timestamp `json:"created_at"`
+	ContentFormat string           `json:"content_format"`
+}
+
+type Coupon struct {
+	ID                int32            `json:"id"`
+	Code              string           `json:"code"`
+	Description       string           `json:"description"`
+	DiscountType      string           `json:"discount_type"`
+	DiscountValue     decimal.Decimal  `json:"discount_value"`
+	MaxDiscount       decimal.Decimal  `json:"max_discount"`
+	MinOrderValue     decimal.Decimal  `json:"min_order_value"`
+	UsageLimit        int32            `json:"usage_limit"`
+	UsageLimitPerUser int32            `json:"usage_limit_per_user"`
+	StartsAt          pgtype.Timestamp `json:"starts_at"`
+	ExpiresAt         pgtype.Timestamp `json:"expires_at"`
+	IsActive          bool             `json:"is_active"`
+	CreatedAt         pgtype.Timestamp `json:"created_at"`
+	UpdatedAt         pgtype.Timestamp `json:"updated_at"`
+}
+
+type CouponCategory struct {
+	CouponID   int32 `json:"coupon_id"`
+	CategoryID int32 `json:"category_id"`
+}
+
+type CouponProduct struct {
+	CouponID  int32 `json:"coupon_id"`
+	ProductID int32 `json:"product_id"`
+}
+
+type CouponRedemption struct {
+	ID             int32            `json:"id"`
+	CouponID       int32            `json:"coupon_id"`
+	UserID         pgtype.Int8      `json:"user_id"`
+	OrderReference string           `json:"order_reference"`
+	DiscountAmount decimal.Decimal  `json:"discount_amount"`
+	CreatedAt      pgtype.Timestamp `json:"created_at"`
+}
+
+type ExchangeRate struct {
 	ID          int32            `json:"id"`
-	Slug        string           `json:"slug"`
-	Title       string           `json:"title"`
-	Description pgtype.Text      `json:"description"`
-	Content     string           `json:"content"`
+	Currency    string           `json:"currency"`
+	Rate        decimal.Decimal  `json:"rate"`
+	EffectiveAt pgtype.Timestamp `json:"effective_at"`
+	CreatedBy   pgtype.Int8      `json:"created_by"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+}
+
+type PageTranslation struct {
+	PageID    int32            `json:"page_id"`
+	Locale    string           `json:"locale"`
+	Title     string           `json:"title"`
+	Content   string           `json:"content"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+	UpdatedAt pgtype.Timestamp `json:"updated_at"`
+}
+
+type Page struct {
+	ID              int32            `json:"id"`
+	Slug            string           `json:"slug"`
+	Title           string           `json:"title"`
+	Description     pgtype.Text      `json:"description"`
+	Content         string           `json:"content"`
+	CreatedAt       pgtype.Timestamp `json:"created_at"`
+	UpdatedAt       pgtype.Timestamp `json:"updated_at"`
+	Status          string           `json:"status"`
+	PublishedAt     pgtype.Timestamp `json:"published_at"`
+	ContentFormat   string           `json:"content_format"`
+	MetaTitle       string           `json:"meta_title"`
+	MetaDescription string           `json:"meta_description"`
+	CanonicalUrl    string           `json:"canonical_url"`
+	OgImageUrl      string           `json:"og_image_url"`
+}
+
+type ProductTranslation struct {
+	ProductID   int32            `json:"product_id"`
+	Locale      string           `json:"locale"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
 	CreatedAt   pgtype.Timestamp `json:"created_at"`
 	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
 }
@@ -52,12 +194,25 @@ type Product struct {
 	Name              string           `json:"name"`
 	Slug              string           `json:"slug"`
 	Description       string           `json:"description"`
-	Price             float64          `json:"price"`
-	PriceSale         float64          `json:"price_sale"`
+	Price             decimal.Decimal  `json:"price"`
+	PriceSale         decimal.Decimal  `json:"price_sale"`
 	UnitOfMeasurement string           `json:"unit_of_measurement"`
 	ImageUrl          string           `json:"image_url"`
 	ThumbUrl          string           `json:"thumb_url"`
 	CreatedAt         pgtype.Timestamp `json:"created_at"`
+	MetaTitle         string           `json:"meta_title"`
+	MetaDescription   string           `json:"meta_description"`
+	CanonicalUrl      string           `json:"canonical_url"`
+	OgImageUrl        string           `json:"og_image_url"`
+	UpdatedAt         pgtype.Timestamp `json:"updated_at"`
+}
+
+type SlugHistory struct {
+	ID           int32            `json:"id"`
+	ResourceType string           `json:"resource_type"`
+	ResourceID   int32            `json:"resource_id"`
+	Slug         string           `json:"slug"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
 }
 
 type User struct {
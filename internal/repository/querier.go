@@ -9,54 +9,154 @@ import (
 )
 
 type Querier interface {
+	// Blog Post Taxonomy Queries
+	AddBlogPostCategory(ctx context.Context, arg AddBlogPostCategoryParams) error
+	AddBlogPostTag(ctx context.Context, arg AddBlogPostTagParams) error
+	AddCouponCategory(ctx context.Context, arg AddCouponCategoryParams) error
+	AddCouponProduct(ctx context.Context, arg AddCouponProductParams) error
+	BlogCategorySlugExists(ctx context.Context, arg BlogCategorySlugExistsParams) (bool, error)
+	BlogPostSlugExists(ctx context.Context, arg BlogPostSlugExistsParams) (bool, error)
+	CategorySlugExists(ctx context.Context, arg CategorySlugExistsParams) (bool, error)
+	CountCouponRedemptions(ctx context.Context, couponID int32) (int64, error)
+	CountCouponRedemptionsByUser(ctx context.Context, arg CountCouponRedemptionsByUserParams) (int64, error)
+	// Seed Queries
+	CreateAdminUser(ctx context.Context, arg CreateAdminUserParams) (int64, error)
+	// Blog Category Queries
+	CreateBlogCategory(ctx context.Context, arg CreateBlogCategoryParams) (BlogCategory, error)
 	// Blog Post Queries
 	CreateBlogPost(ctx context.Context, arg CreateBlogPostParams) (BlogPost, error)
 	CreateCategory(ctx context.Context, arg CreateCategoryParams) (int32, error)
+	// Content Revision Queries
+	CreateContentRevision(ctx context.Context, arg CreateContentRevisionParams) (ContentRevision, error)
+	// Coupon Queries
+	CreateCoupon(ctx context.Context, arg CreateCouponParams) (int32, error)
+	CreateCouponRedemption(ctx context.Context, arg CreateCouponRedemptionParams) (int32, error)
+	// Exchange Rate Queries
+	CreateExchangeRate(ctx context.Context, arg CreateExchangeRateParams) (ExchangeRate, error)
 	// Pages Queries
 	CreatePage(ctx context.Context, arg CreatePageParams) (Page, error)
 	CreateProduct(ctx context.Context, arg CreateProductParams) (int32, error)
+	// Slug History Queries
+	CreateSlugHistory(ctx context.Context, arg CreateSlugHistoryParams) error
 	CreateUser(ctx context.Context, arg CreateUserParams) (int64, error)
 	CreateWebsiteSetting(ctx context.Context, arg CreateWebsiteSettingParams) (int32, error)
-	DeleteBlogPost(ctx context.Context, id int32) error
-	DeleteCategory(ctx context.Context, id int32) error
-	DeletePage(ctx context.Context, id int32) error
-	DeleteProduct(ctx context.Context, id int32) error
+	DeleteBlogCategory(ctx context.Context, id int32) (int64, error)
+	DeleteBlogPost(ctx context.Context, id int32) (int64, error)
+	DeleteBlogPostCategories(ctx context.Context, blogPostID int32) error
+	DeleteBlogPostTags(ctx context.Context, blogPostID int32) error
+	DeleteBlogPostTranslation(ctx context.Context, arg DeleteBlogPostTranslationParams) (int64, error)
+	DeleteBlogTag(ctx context.Context, id int32) (int64, error)
+	DeleteCategory(ctx context.Context, id int32) (int64, error)
+	DeleteCategoryTranslation(ctx context.Context, arg DeleteCategoryTranslationParams) (int64, error)
+	DeleteContentRevisionsByResource(ctx context.Context, arg DeleteContentRevisionsByResourceParams) error
+	DeleteCoupon(ctx context.Context, id int32) (int64, error)
+	DeleteCouponCategories(ctx context.Context, couponID int32) error
+	DeleteCouponProducts(ctx context.Context, couponID int32) error
+	DeleteExchangeRate(ctx context.Context, id int32) (int64, error)
+	DeletePage(ctx context.Context, id int32) (int64, error)
+	DeletePageTranslation(ctx context.Context, arg DeletePageTranslationParams) (int64, error)
+	DeleteProduct(ctx context.Context, id int32) (int64, error)
+	DeleteProductTranslation(ctx context.Context, arg DeleteProductTranslationParams) (int64, error)
+	DeleteSlugHistory(ctx context.Context, arg DeleteSlugHistoryParams) error
+	DeleteSlugHistoryByResource(ctx context.Context, arg DeleteSlugHistoryByResourceParams) error
 	DeleteUser(ctx context.Context, id int64) error
-	DeleteWebsiteSetting(ctx context.Context, id int32) error
+	DeleteWebsiteSetting(ctx context.Context, id int32) (int64, error)
+	GetBlogCategory(ctx context.Context, id int32) (BlogCategory, error)
 	GetBlogPost(ctx context.Context, id int32) (BlogPost, error)
 	GetBlogPostBySlug(ctx context.Context, slug string) (BlogPost, error)
 	GetCategory(ctx context.Context, id int32) (Category, error)
 	GetCategoryBySlug(ctx context.Context, slug string) (Category, error)
+	GetContentRevision(ctx context.Context, arg GetContentRevisionParams) (ContentRevision, error)
+	GetCoupon(ctx context.Context, id int32) (Coupon, error)
+	GetCouponByCode(ctx context.Context, code string) (Coupon, error)
+	// Locks the coupon until the transaction ends, so concurrent redemptions see each other
+	GetCouponByCodeForUpdate(ctx context.Context, code string) (Coupon, error)
+	GetCurrentExchangeRate(ctx context.Context, currency string) (ExchangeRate, error)
 	GetPage(ctx context.Context, id int32) (Page, error)
 	GetPageBySlug(ctx context.Context, slug string) (Page, error)
 	GetProduct(ctx context.Context, id int32) (GetProductRow, error)
 	GetProductBySlug(ctx context.Context, slug string) (GetProductBySlugRow, error)
+	GetPublishedBlogPost(ctx context.Context, id int32) (BlogPost, error)
+	GetPublishedBlogPostBySlug(ctx context.Context, slug string) (BlogPost, error)
+	GetPublishedPage(ctx context.Context, id int32) (Page, error)
+	GetPublishedPageBySlug(ctx context.Context, slug string) (Page, error)
+	// Sitemap Queries
+	GetSitemapCounts(ctx context.Context) (GetSitemapCountsRow, error)
+	GetSlugHistory(ctx context.Context, arg GetSlugHistoryParams) (int32, error)
 	GetTotalBlogPosts(ctx context.Context) (int64, error)
+	GetTotalContentRevisions(ctx context.Context, arg GetTotalContentRevisionsParams) (int64, error)
+	GetTotalCoupons(ctx context.Context) (int64, error)
+	GetTotalExchangeRateHistory(ctx context.Context, currency string) (int64, error)
+	GetTotalMissingTranslations(ctx context.Context, arg GetTotalMissingTranslationsParams) (int64, error)
 	GetTotalPages(ctx context.Context) (int64, error)
 	GetTotalProducts(ctx context.Context) (int64, error)
 	GetTotalProductsByCategoryID(ctx context.Context, id int32) (int64, error)
 	GetTotalProductsByCategorySlug(ctx context.Context, slug string) (int64, error)
+	GetTotalPublishedBlogPosts(ctx context.Context, arg GetTotalPublishedBlogPostsParams) (int64, error)
+	GetTotalPublishedPages(ctx context.Context) (int64, error)
 	GetUser(ctx context.Context, id int64) (User, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserCount(ctx context.Context) (int64, error)
 	GetWebsiteSetting(ctx context.Context, id int32) (WebsiteSetting, error)
 	GetWebsiteSettingByName(ctx context.Context, name string) (WebsiteSetting, error)
+	ListBlogCategories(ctx context.Context) ([]ListBlogCategoriesRow, error)
+	ListBlogPostCategories(ctx context.Context, postIds []int32) ([]ListBlogPostCategoriesRow, error)
+	ListBlogPostTags(ctx context.Context, postIds []int32) ([]ListBlogPostTagsRow, error)
+	ListBlogPostTranslations(ctx context.Context, blogPostID int32) ([]BlogPostTranslation, error)
+	ListBlogPostTranslationsByLocale(ctx context.Context, arg ListBlogPostTranslationsByLocaleParams) ([]BlogPostTranslation, error)
 	ListBlogPosts(ctx context.Context, arg ListBlogPostsParams) ([]BlogPost, error)
+	ListBlogTags(ctx context.Context) ([]ListBlogTagsRow, error)
 	ListCategories(ctx context.Context) ([]Category, error)
+	ListCategoryTranslations(ctx context.Context, categoryID int32) ([]CategoryTranslation, error)
+	ListCategoryTranslationsByLocale(ctx context.Context, arg ListCategoryTranslationsByLocaleParams) ([]CategoryTranslation, error)
+	ListContentRevisions(ctx context.Context, arg ListContentRevisionsParams) ([]ContentRevision, error)
+	ListCouponCategoryIDs(ctx context.Context, couponID int32) ([]int32, error)
+	ListCouponProductIDs(ctx context.Context, couponID int32) ([]int32, error)
+	ListCoupons(ctx context.Context, arg ListCouponsParams) ([]Coupon, error)
+	ListCurrentExchangeRates(ctx context.Context) ([]ExchangeRate, error)
+	ListExchangeRateHistory(ctx context.Context, arg ListExchangeRateHistoryParams) ([]ExchangeRate, error)
+	ListMissingTranslations(ctx context.Context, arg ListMissingTranslationsParams) ([]ListMissingTranslationsRow, error)
+	ListPageTranslations(ctx context.Context, pageID int32) ([]PageTranslation, error)
+	ListPageTranslationsByLocale(ctx context.Context, arg ListPageTranslationsByLocaleParams) ([]PageTranslation, error)
 	ListPages(ctx context.Context, arg ListPagesParams) ([]Page, error)
+	ListProductTranslations(ctx context.Context, productID int32) ([]ProductTranslation, error)
+	ListProductTranslationsByLocale(ctx context.Context, arg ListProductTranslationsByLocaleParams) ([]ProductTranslation, error)
 	ListProducts(ctx context.Context, arg ListProductsParams) ([]ListProductsRow, error)
 	ListProductsByCategory(ctx context.Context, arg ListProductsByCategoryParams) ([]ListProductsByCategoryRow, error)
 	ListProductsByCategoryID(ctx context.Context, arg ListProductsByCategoryIDParams) ([]ListProductsByCategoryIDRow, error)
 	ListProductsByCategorySlug(ctx context.Context, arg ListProductsByCategorySlugParams) ([]ListProductsByCategorySlugRow, error)
+	ListProductsByIDs(ctx context.Context, ids []int32) ([]ListProductsByIDsRow, error)
+	ListProductsForExport(ctx context.Context, arg ListProductsForExportParams) ([]ListProductsForExportRow, error)
+	ListPublishedBlogPosts(ctx context.Context, arg ListPublishedBlogPostsParams) ([]BlogPost, error)
+	ListPublishedPages(ctx context.Context, arg ListPublishedPagesParams) ([]Page, error)
+	ListSitemapBlogPosts(ctx context.Context, arg ListSitemapBlogPostsParams) ([]ListSitemapBlogPostsRow, error)
+	ListSitemapCategories(ctx context.Context, arg ListSitemapCategoriesParams) ([]ListSitemapCategoriesRow, error)
+	ListSitemapPages(ctx context.Context, arg ListSitemapPagesParams) ([]ListSitemapPagesRow, error)
+	ListSitemapProducts(ctx context.Context, arg ListSitemapProductsParams) ([]ListSitemapProductsRow, error)
 	ListUsers(ctx context.Context) ([]ListUsersRow, error)
 	ListWebsiteSettings(ctx context.Context) ([]WebsiteSetting, error)
+	PageSlugExists(ctx context.Context, arg PageSlugExistsParams) (bool, error)
+	// Slug Queries
+	ProductSlugExists(ctx context.Context, arg ProductSlugExistsParams) (bool, error)
+	PromoteUserToAdmin(ctx context.Context, id int64) error
 	SearchBlogPosts(ctx context.Context, arg SearchBlogPostsParams) ([]BlogPost, error)
-	UpdateBlogPost(ctx context.Context, arg UpdateBlogPostParams) error
-	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) error
-	UpdatePage(ctx context.Context, arg UpdatePageParams) error
-	UpdateProduct(ctx context.Context, arg UpdateProductParams) error
+	TruncateAllData(ctx context.Context) error
+	UpdateBlogCategory(ctx context.Context, arg UpdateBlogCategoryParams) (int64, error)
+	UpdateBlogPost(ctx context.Context, arg UpdateBlogPostParams) (int64, error)
+	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (int64, error)
+	UpdateCoupon(ctx context.Context, arg UpdateCouponParams) (int64, error)
+	UpdatePage(ctx context.Context, arg UpdatePageParams) (int64, error)
+	UpdateProduct(ctx context.Context, arg UpdateProductParams) (int64, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) error
-	UpdateWebsiteSetting(ctx context.Context, arg UpdateWebsiteSettingParams) error
+	UpdateWebsiteSetting(ctx context.Context, arg UpdateWebsiteSettingParams) (int64, error)
+	UpsertBlogPostTranslation(ctx context.Context, arg UpsertBlogPostTranslationParams) (BlogPostTranslation, error)
+	// Blog Tag Queries
+	UpsertBlogTag(ctx context.Context, arg UpsertBlogTagParams) (int32, error)
+	UpsertCategoryTranslation(ctx context.Context, arg UpsertCategoryTranslationParams) (CategoryTranslation, error)
+	UpsertPageTranslation(ctx context.Context, arg UpsertPageTranslationParams) (PageTranslation, error)
+	UpsertProductBySlug(ctx context.Context, arg UpsertProductBySlugParams) (UpsertProductBySlugRow, error)
+	// Translation Queries
+	UpsertProductTranslation(ctx context.Context, arg UpsertProductTranslationParams) (ProductTranslation, error)
 }
 
 var _ Querier = (*Queries)(nil)
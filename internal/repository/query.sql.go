@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
 )
 
 const createBlogPost = `-- name: CreateBlogPost :one
@@ -18,18 +19,34 @@ INSERT INTO blog_posts (
     content,
     slug,
     image_url,
+    status,
+    published_at,
+    author_id,
+    content_format,
+    meta_title,
+    meta_description,
+    canonical_url,
+    og_image_url,
     created_at
 )
-VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
-RETURNING id, title, slug, description, content, image_url, created_at, updated_at
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, CURRENT_TIMESTAMP)
+RETURNING id, title, slug, description, content, image_url, created_at, updated_at, status, published_at, author_id, content_format, meta_title, meta_description, canonical_url, og_image_url
 `
 
 type CreateBlogPostParams struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Content     string `json:"content"`
-	Slug        string `json:"slug"`
-	ImageUrl    string `json:"image_url"`
+	Title           string           `json:"title"`
+	Description     string           `json:"description"`
+	Content         string           `json:"content"`
+	Slug            string           `json:"slug"`
+	ImageUrl        string           `json:"image_url"`
+	Status          string           `json:"status"`
+	PublishedAt     pgtype.Timestamp `json:"published_at"`
+	AuthorID        pgtype.Int8      `json:"author_id"`
+	ContentFormat   string           `json:"content_format"`
+	MetaTitle       string           `json:"meta_title"`
+	MetaDescription string           `json:"meta_description"`
+	CanonicalUrl    string           `json:"canonical_url"`
+	OgImageUrl      string           `json:"og_image_url"`
 }
 
 // Blog Post Queries
@@ -40,6 +57,14 @@ func (q *Queries) CreateBlogPost(ctx context.Context, arg CreateBlogPostParams)
 		arg.Content,
 		arg.Slug,
 		arg.ImageUrl,
+		arg.Status,
+		arg.PublishedAt,
+		arg.AuthorID,
+		arg.ContentFormat,
+		arg.MetaTitle,
+		arg.MetaDescription,
+		arg.CanonicalUrl,
+		arg.OgImageUrl,
 	)
 	var i BlogPost
 	err := row.Scan(
@@ -51,21 +76,33 @@ func (q *Queries) CreateBlogPost(ctx context.Context, arg CreateBlogPostParams)
 		&i.ImageUrl,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishedAt,
+		&i.AuthorID,
+		&i.ContentFormat,
+		&i.MetaTitle,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.OgImageUrl,
 	)
 	return i, err
 }
 
 const createCategory = `-- name: CreateCategory :one
-INSERT INTO categories (name, slug, description, image_url)
-VALUES ($1, $2, $3, $4)
+INSERT INTO categories (name, slug, description, image_url, meta_title, meta_description, canonical_url, og_image_url)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 RETURNING id
 `
 
 type CreateCategoryParams struct {
-	Name        string      `json:"name"`
-	Slug        string      `json:"slug"`
-	Description pgtype.Text `json:"description"`
-	ImageUrl    pgtype.Text `json:"image_url"`
+	Name            string      `json:"name"`
+	Slug            string      `json:"slug"`
+	Description     pgtype.Text `json:"description"`
+	ImageUrl        pgtype.Text `json:"image_url"`
+	MetaTitle       string      `json:"meta_title"`
+	MetaDescription string      `json:"meta_description"`
+	CanonicalUrl    string      `json:"canonical_url"`
+	OgImageUrl      string      `json:"og_image_url"`
 }
 
 func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams) (int32, error) {
@@ -74,6 +111,10 @@ func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams)
 		arg.Slug,
 		arg.Description,
 		arg.ImageUrl,
+		arg.MetaTitle,
+		arg.MetaDescription,
+		arg.CanonicalUrl,
+		arg.OgImageUrl,
 	)
 	var id int32
 	err := row.Scan(&id)
@@ -85,22 +126,47 @@ INSERT INTO pages (
     slug,
     title,
     content,
+    status,
+    published_at,
+    content_format,
+    meta_title,
+    meta_description,
+    canonical_url,
+    og_image_url,
     created_at,
     updated_at
 )
-VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
-RETURNING id, slug, title, description, content, created_at, updated_at
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+RETURNING id, slug, title, description, content, created_at, updated_at, status, published_at, content_format, meta_title, meta_description, canonical_url, og_image_url
 `
 
 type CreatePageParams struct {
-	Slug    string `json:"slug"`
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	Slug            string           `json:"slug"`
+	Title           string           `json:"title"`
+	Content         string           `json:"content"`
+	Status          string           `json:"status"`
+	PublishedAt     pgtype.Timestamp `json:"published_at"`
+	ContentFormat   string           `json:"content_format"`
+	MetaTitle       string           `json:"meta_title"`
+	MetaDescription string           `json:"meta_description"`
+	CanonicalUrl    string           `json:"canonical_url"`
+	OgImageUrl      string           `json:"og_image_url"`
 }
 
 // Pages Queries
 func (q *Queries) CreatePage(ctx context.Context, arg CreatePageParams) (Page, error) {
-	row := q.db.QueryRow(ctx, createPage, arg.Slug, arg.Title, arg.Content)
+	row := q.db.QueryRow(ctx, createPage,
+		arg.Slug,
+		arg.Title,
+		arg.Content,
+		arg.Status,
+		arg.PublishedAt,
+		arg.ContentFormat,
+		arg.MetaTitle,
+		arg.MetaDescription,
+		arg.CanonicalUrl,
+		arg.OgImageUrl,
+	)
 	var i Page
 	err := row.Scan(
 		&i.ID,
@@ -110,6 +176,13 @@ func (q *Queries) CreatePage(ctx context.Context, arg CreatePageParams) (Page, e
 		&i.Content,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishedAt,
+		&i.ContentFormat,
+		&i.MetaTitle,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.OgImageUrl,
 	)
 	return i, err
 }
@@ -124,22 +197,30 @@ INSERT INTO products (
     price_sale,
     unit_of_measurement,
     image_url,
-    thumb_url
+    thumb_url,
+    meta_title,
+    meta_description,
+    canonical_url,
+    og_image_url
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 RETURNING id
 `
 
 type CreateProductParams struct {
-	CategoryID        int32   `json:"category_id"`
-	Name              string  `json:"name"`
-	Slug              string  `json:"slug"`
-	Description       string  `json:"description"`
-	Price             float64 `json:"price"`
-	PriceSale         float64 `json:"price_sale"`
-	UnitOfMeasurement string  `json:"unit_of_measurement"`
-	ImageUrl          string  `json:"image_url"`
-	ThumbUrl          string  `json:"thumb_url"`
+	CategoryID        int32           `json:"category_id"`
+	Name              string          `json:"name"`
+	Slug              string          `json:"slug"`
+	Description       string          `json:"description"`
+	Price             decimal.Decimal `json:"price"`
+	PriceSale         decimal.Decimal `json:"price_sale"`
+	UnitOfMeasurement string          `json:"unit_of_measurement"`
+	ImageUrl          string          `json:"image_url"`
+	ThumbUrl          string          `json:"thumb_url"`
+	MetaTitle         string          `json:"meta_title"`
+	MetaDescription   string          `json:"meta_description"`
+	CanonicalUrl      string          `json:"canonical_url"`
+	OgImageUrl        string          `json:"og_image_url"`
 }
 
 func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (int32, error) {
@@ -153,6 +234,10 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (i
 		arg.UnitOfMeasurement,
 		arg.ImageUrl,
 		arg.ThumbUrl,
+		arg.MetaTitle,
+		arg.MetaDescription,
+		arg.CanonicalUrl,
+		arg.OgImageUrl,
 	)
 	var id int32
 	err := row.Scan(&id)
@@ -195,44 +280,56 @@ func (q *Queries) CreateWebsiteSetting(ctx context.Context, arg CreateWebsiteSet
 	return id, err
 }
 
-const deleteBlogPost = `-- name: DeleteBlogPost :exec
+const deleteBlogPost = `-- name: DeleteBlogPost :execrows
 DELETE FROM blog_posts
 WHERE id = $1
 `
 
-func (q *Queries) DeleteBlogPost(ctx context.Context, id int32) error {
-	_, err := q.db.Exec(ctx, deleteBlogPost, id)
-	return err
+func (q *Queries) DeleteBlogPost(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteBlogPost, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const deleteCategory = `-- name: DeleteCategory :exec
+const deleteCategory = `-- name: DeleteCategory :execrows
 DELETE FROM categories
 WHERE id = $1
 `
 
-func (q *Queries) DeleteCategory(ctx context.Context, id int32) error {
-	_, err := q.db.Exec(ctx, deleteCategory, id)
-	return err
+func (q *Queries) DeleteCategory(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCategory, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const deletePage = `-- name: DeletePage :exec
+const deletePage = `-- name: DeletePage :execrows
 DELETE FROM pages
 WHERE id = $1
 `
 
-func (q *Queries) DeletePage(ctx context.Context, id int32) error {
-	_, err := q.db.Exec(ctx, deletePage, id)
-	return err
+func (q *Queries) DeletePage(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deletePage, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const deleteProduct = `-- name: DeleteProduct :exec
+const deleteProduct = `-- name: DeleteProduct :execrows
 DELETE FROM products
 WHERE id = $1
 `
 
-func (q *Queries) DeleteProduct(ctx context.Context, id int32) error {
-	_, err := q.db.Exec(ctx, deleteProduct, id)
-	return err
+func (q *Queries) DeleteProduct(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProduct, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const deleteUser = `-- name: DeleteUser :exec
@@ -245,18 +342,21 @@ func (q *Queries) DeleteUser(ctx context.Context, id int64) error {
 	return err
 }
 
-const deleteWebsiteSetting = `-- name: DeleteWebsiteSetting :exec
+const deleteWebsiteSetting = `-- name: DeleteWebsiteSetting :execrows
 DELETE FROM website_settings
 WHERE id = $1
 `
 
-func (q *Queries) DeleteWebsiteSetting(ctx context.Context, id int32) error {
-	_, err := q.db.Exec(ctx, deleteWebsiteSetting, id)
-	return err
+func (q *Queries) DeleteWebsiteSetting(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteWebsiteSetting, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const getBlogPost = `-- name: GetBlogPost :one
-SELECT id, title, slug, description, content, image_url, created_at, updated_at
+SELECT id, title, slug, description, content, image_url, created_at, updated_at, status, published_at, author_id, content_format, meta_title, meta_description, canonical_url, og_image_url
 FROM blog_posts
 WHERE id = $1
 `
@@ -273,12 +373,20 @@ func (q *Queries) GetBlogPost(ctx context.Context, id int32) (BlogPost, error) {
 		&i.ImageUrl,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishedAt,
+		&i.AuthorID,
+		&i.ContentFormat,
+		&i.MetaTitle,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.OgImageUrl,
 	)
 	return i, err
 }
 
 const getBlogPostBySlug = `-- name: GetBlogPostBySlug :one
-SELECT id, title, slug, description, content, image_url, created_at, updated_at
+SELECT id, title, slug, description, content, image_url, created_at, updated_at, status, published_at, author_id, content_format, meta_title, meta_description, canonical_url, og_image_url
 FROM blog_posts
 WHERE slug = $1
 `
@@ -295,12 +403,20 @@ func (q *Queries) GetBlogPostBySlug(ctx context.Context, slug string) (BlogPost,
 		&i.ImageUrl,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishedAt,
+		&i.AuthorID,
+		&i.ContentFormat,
+		&i.MetaTitle,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.OgImageUrl,
 	)
 	return i, err
 }
 
 const getCategory = `-- name: GetCategory :one
-SELECT id, name, slug, description, image_url, created_at
+SELECT id, name, slug, description, image_url, created_at, meta_title, meta_description, canonical_url, og_image_url, updated_at
 FROM categories
 WHERE id = $1
 `
@@ -315,12 +431,17 @@ func (q *Queries) GetCategory(ctx context.Context, id int32) (Category, error) {
 		&i.Description,
 		&i.ImageUrl,
 		&i.CreatedAt,
+		&i.MetaTitle,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.OgImageUrl,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const getCategoryBySlug = `-- name: GetCategoryBySlug :one
-SELECT id, name, slug, description, image_url, created_at
+SELECT id, name, slug, description, image_url, created_at, meta_title, meta_description, canonical_url, og_image_url, updated_at
 FROM categories
 WHERE slug = $1
 `
@@ -335,12 +456,17 @@ func (q *Queries) GetCategoryBySlug(ctx context.Context, slug string) (Category,
 		&i.Description,
 		&i.ImageUrl,
 		&i.CreatedAt,
+		&i.MetaTitle,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.OgImageUrl,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const getPage = `-- name: GetPage :one
-SELECT id, slug, title, description, content, created_at, updated_at
+SELECT id, slug, title, description, content, created_at, updated_at, status, published_at, content_format, meta_title, meta_description, canonical_url, og_image_url
 FROM pages
 WHERE id = $1
 `
@@ -356,12 +482,19 @@ func (q *Queries) GetPage(ctx context.Context, id int32) (Page, error) {
 		&i.Content,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishedAt,
+		&i.ContentFormat,
+		&i.MetaTitle,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.OgImageUrl,
 	)
 	return i, err
 }
 
 const getPageBySlug = `-- name: GetPageBySlug :one
-SELECT id, slug, title, description, content, created_at, updated_at
+SELECT id, slug, title, description, content, created_at, updated_at, status, published_at, content_format, meta_title, meta_description, canonical_url, og_image_url
 FROM pages
 WHERE slug = $1
 `
@@ -377,6 +510,13 @@ func (q *Queries) GetPageBySlug(ctx context.Context, slug string) (Page, error)
 		&i.Content,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishedAt,
+		&i.ContentFormat,
+		&i.MetaTitle,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.OgImageUrl,
 	)
 	return i, err
 }
@@ -394,6 +534,11 @@ SELECT
     p.image_url,
     p.thumb_url,
     p.created_at,
+    p.meta_title,
+    p.meta_description,
+    p.canonical_url,
+    p.og_image_url,
+    p.updated_at,
     c.name as category_name,
     c.slug as category_slug
 FROM products p
@@ -407,12 +552,17 @@ type GetProductRow struct {
 	Name              string           `json:"name"`
 	Slug              string           `json:"slug"`
 	Description       string           `json:"description"`
-	Price             float64          `json:"price"`
-	PriceSale         float64          `json:"price_sale"`
+	Price             decimal.Decimal  `json:"price"`
+	PriceSale         decimal.Decimal  `json:"price_sale"`
 	UnitOfMeasurement string           `json:"unit_of_measurement"`
 	ImageUrl          string           `json:"image_url"`
 	ThumbUrl          string           `json:"thumb_url"`
 	CreatedAt         pgtype.Timestamp `json:"created_at"`
+	MetaTitle         string           `json:"meta_title"`
+	MetaDescription   string           `json:"meta_description"`
+	CanonicalUrl      string           `json:"canonical_url"`
+	OgImageUrl        string           `json:"og_image_url"`
+	UpdatedAt         pgtype.Timestamp `json:"updated_at"`
 	CategoryName      string           `json:"category_name"`
 	CategorySlug      string           `json:"category_slug"`
 }
@@ -432,6 +582,11 @@ func (q *Queries) GetProduct(ctx context.Context, id int32) (GetProductRow, erro
 		&i.ImageUrl,
 		&i.ThumbUrl,
 		&i.CreatedAt,
+		&i.MetaTitle,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.OgImageUrl,
+		&i.UpdatedAt,
 		&i.CategoryName,
 		&i.CategorySlug,
 	)
@@ -451,6 +606,11 @@ SELECT
     p.image_url,
     p.thumb_url,
     p.created_at,
+    p.meta_title,
+    p.meta_description,
+    p.canonical_url,
+    p.og_image_url,
+    p.updated_at,
     c.name as category_name,
     c.slug as category_slug
 FROM products p
@@ -464,12 +624,17 @@ type GetProductBySlugRow struct {
 	Name              string           `json:"name"`
 	Slug              string           `json:"slug"`
 	Description       string           `json:"description"`
-	Price             float64          `json:"price"`
-	PriceSale         float64          `json:"price_sale"`
+	Price             decimal.Decimal  `json:"price"`
+	PriceSale         decimal.Decimal  `json:"price_sale"`
 	UnitOfMeasurement string           `json:"unit_of_measurement"`
 	ImageUrl          string           `json:"image_url"`
 	ThumbUrl          string           `json:"thumb_url"`
 	CreatedAt         pgtype.Timestamp `json:"created_at"`
+	MetaTitle         string           `json:"meta_title"`
+	MetaDescription   string           `json:"meta_description"`
+	CanonicalUrl      string           `json:"canonical_url"`
+	OgImageUrl        string           `json:"og_image_url"`
+	UpdatedAt         pgtype.Timestamp `json:"updated_at"`
 	CategoryName      string           `json:"category_name"`
 	CategorySlug      string           `json:"category_slug"`
 }
@@ -489,12 +654,133 @@ func (q *Queries) GetProductBySlug(ctx context.Context, slug string) (GetProduct
 		&i.ImageUrl,
 		&i.ThumbUrl,
 		&i.CreatedAt,
+		&i.MetaTitle,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.OgImageUrl,
+		&i.UpdatedAt,
 		&i.CategoryName,
 		&i.CategorySlug,
 	)
 	return i, err
 }
 
+const getPublishedBlogPost = `-- name: GetPublishedBlogPost :one
+SELECT id, title, slug, description, content, image_url, created_at, updated_at, status, published_at, author_id, content_format, meta_title, meta_description, canonical_url, og_image_url
+FROM blog_posts
+WHERE id = $1 AND status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP
+`
+
+func (q *Queries) GetPublishedBlogPost(ctx context.Context, id int32) (BlogPost, error) {
+	row := q.db.QueryRow(ctx, getPublishedBlogPost, id)
+	var i BlogPost
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Slug,
+		&i.Description,
+		&i.Content,
+		&i.ImageUrl,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishedAt,
+		&i.AuthorID,
+		&i.ContentFormat,
+		&i.MetaTitle,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.OgImageUrl,
+	)
+	return i, err
+}
+
+const getPublishedBlogPostBySlug = `-- name: GetPublishedBlogPostBySlug :one
+SELECT id, title, slug, description, content, image_url, created_at, updated_at, status, published_at, author_id, content_format, meta_title, meta_description, canonical_url, og_image_url
+FROM blog_posts
+WHERE slug = $1 AND status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP
+`
+
+func (q *Queries) GetPublishedBlogPostBySlug(ctx context.Context, slug string) (BlogPost, error) {
+	row := q.db.QueryRow(ctx, getPublishedBlogPostBySlug, slug)
+	var i BlogPost
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Slug,
+		&i.Description,
+		&i.Content,
+		&i.ImageUrl,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishedAt,
+		&i.AuthorID,
+		&i.ContentFormat,
+		&i.MetaTitle,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.OgImageUrl,
+	)
+	return i, err
+}
+
+const getPublishedPage = `-- name: GetPublishedPage :one
+SELECT id, slug, title, description, content, created_at, updated_at, status, published_at, content_format, meta_title, meta_description, canonical_url, og_image_url
+FROM pages
+WHERE id = $1 AND status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP
+`
+
+func (q *Queries) GetPublishedPage(ctx context.Context, id int32) (Page, error) {
+	row := q.db.QueryRow(ctx, getPublishedPage, id)
+	var i Page
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.Title,
+		&i.Description,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishedAt,
+		&i.ContentFormat,
+		&i.MetaTitle,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.OgImageUrl,
+	)
+	return i, err
+}
+
+const getPublishedPageBySlug = `-- name: GetPublishedPageBySlug :one
+SELECT id, slug, title, description, content, created_at, updated_at, status, published_at, content_format, meta_title, meta_description, canonical_url, og_image_url
+FROM pages
+WHERE slug = $1 AND status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP
+`
+
+func (q *Queries) GetPublishedPageBySlug(ctx context.Context, slug string) (Page, error) {
+	row := q.db.QueryRow(ctx, getPublishedPageBySlug, slug)
+	var i Page
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.Title,
+		&i.Description,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishedAt,
+		&i.ContentFormat,
+		&i.MetaTitle,
+		&i.MetaDescription,
+		&i.CanonicalUrl,
+		&i.OgImageUrl,
+	)
+	return i, err
+}
+
 const getTotalBlogPosts = `-- name: GetTotalBlogPosts :one
 SELECT COUNT(*) as total_count
 FROM blog_posts
@@ -558,6 +844,49 @@ func (q *Queries) GetTotalProductsByCategorySlug(ctx context.Context, slug strin
 	return total_count, err
 }
 
+const getTotalPublishedBlogPosts = `-- name: GetTotalPublishedBlogPosts :one
+SELECT COUNT(*) as total_count
+FROM blog_posts
+WHERE status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP
+    AND ($1::text IS NULL OR id IN (
+        SELECT bpt.blog_post_id
+        FROM blog_post_tags bpt
+        JOIN blog_tags t ON t.id = bpt.tag_id
+        WHERE t.slug = $1
+    ))
+    AND ($2::text IS NULL OR id IN (
+        SELECT bpc.blog_post_id
+        FROM blog_post_categories bpc
+        JOIN blog_categories c ON c.id = bpc.category_id
+        WHERE c.slug = $2
+    ))
+`
+
+type GetTotalPublishedBlogPostsParams struct {
+	Tag      pgtype.Text `json:"tag"`
+	Category pgtype.Text `json:"category"`
+}
+
+func (q *Queries) GetTotalPublishedBlogPosts(ctx context.Context, arg GetTotalPublishedBlogPostsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getTotalPublishedBlogPosts, arg.Tag, arg.Category)
+	var total_count int64
+	err := row.Scan(&total_count)
+	return total_count, err
+}
+
+const getTotalPublishedPages = `-- name: GetTotalPublishedPages :one
+SELECT COUNT(*) as total_count
+FROM pages
+WHERE status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP
+`
+
+func (q *Queries) GetTotalPublishedPages(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, getTotalPublishedPages)
+	var total_count int64
+	err := row.Scan(&total_count)
+	return total_count, err
+}
+
 const getUser = `-- name: GetUser :one
 SELECT id, email, password, role, created_at, updated_at
 FROM users
@@ -637,7 +966,7 @@ func (q *Queries) GetWebsiteSettingByName(ctx context.Context, name string) (Web
 }
 
 const listBlogPosts = `-- name: ListBlogPosts :many
-SELECT id, title, slug, description, content, image_url, created_at, updated_at
+SELECT id, title, slug, description, content, image_url, created_at, updated_at, status, published_at, author_id, content_format, meta_title, meta_description, canonical_url, og_image_url
 FROM blog_posts
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
@@ -666,6 +995,14 @@ func (q *Queries) ListBlogPosts(ctx context.Context, arg ListBlogPostsParams) ([
 			&i.ImageUrl,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Status,
+			&i.PublishedAt,
+			&i.AuthorID,
+			&i.ContentFormat,
+			&i.MetaTitle,
+			&i.MetaDescription,
+			&i.CanonicalUrl,
+			&i.OgImageUrl,
 		); err != nil {
 			return nil, err
 		}
@@ -678,7 +1015,7 @@ func (q *Queries) ListBlogPosts(ctx context.Context, arg ListBlogPostsParams) ([
 }
 
 const listCategories = `-- name: ListCategories :many
-SELECT id, name, slug, description, image_url, created_at
+SELECT id, name, slug, description, image_url, created_at, meta_title, meta_description, canonical_url, og_image_url, updated_at
 FROM categories
 ORDER BY created_at DESC
 `
@@ -699,6 +1036,11 @@ func (q *Queries) ListCategories(ctx context.Context) ([]Category, error) {
 			&i.Description,
 			&i.ImageUrl,
 			&i.CreatedAt,
+			&i.MetaTitle,
+			&i.MetaDescription,
+			&i.CanonicalUrl,
+			&i.OgImageUrl,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -711,7 +1053,7 @@ func (q *Queries) ListCategories(ctx context.Context) ([]Category, error) {
 }
 
 const listPages = `-- name: ListPages :many
-SELECT id, slug, title, description, content, created_at, updated_at
+SELECT id, slug, title, description, content, created_at, updated_at, status, published_at, content_format, meta_title, meta_description, canonical_url, og_image_url
 FROM pages
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
@@ -739,6 +1081,13 @@ func (q *Queries) ListPages(ctx context.Context, arg ListPagesParams) ([]Page, e
 			&i.Content,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Status,
+			&i.PublishedAt,
+			&i.ContentFormat,
+			&i.MetaTitle,
+			&i.MetaDescription,
+			&i.CanonicalUrl,
+			&i.OgImageUrl,
 		); err != nil {
 			return nil, err
 		}
@@ -782,8 +1131,8 @@ type ListProductsRow struct {
 	Name              string           `json:"name"`
 	Slug              string           `json:"slug"`
 	Description       string           `json:"description"`
-	Price             float64          `json:"price"`
-	PriceSale         float64          `json:"price_sale"`
+	Price             decimal.Decimal  `json:"price"`
+	PriceSale         decimal.Decimal  `json:"price_sale"`
 	UnitOfMeasurement string           `json:"unit_of_measurement"`
 	ImageUrl          string           `json:"image_url"`
 	ThumbUrl          string           `json:"thumb_url"`
@@ -869,8 +1218,8 @@ type ListProductsByCategoryRow struct {
 	Name              string           `json:"name"`
 	Slug              string           `json:"slug"`
 	Description       string           `json:"description"`
-	Price             float64          `json:"price"`
-	PriceSale         float64          `json:"price_sale"`
+	Price             decimal.Decimal  `json:"price"`
+	PriceSale         decimal.Decimal  `json:"price_sale"`
 	UnitOfMeasurement string           `json:"unit_of_measurement"`
 	ImageUrl          string           `json:"image_url"`
 	ThumbUrl          string           `json:"thumb_url"`
@@ -954,8 +1303,8 @@ type ListProductsByCategoryIDRow struct {
 	Name              string           `json:"name"`
 	Slug              string           `json:"slug"`
 	Description       string           `json:"description"`
-	Price             float64          `json:"price"`
-	PriceSale         float64          `json:"price_sale"`
+	Price             decimal.Decimal  `json:"price"`
+	PriceSale         decimal.Decimal  `json:"price_sale"`
 	UnitOfMeasurement string           `json:"unit_of_measurement"`
 	ImageUrl          string           `json:"image_url"`
 	ThumbUrl          string           `json:"thumb_url"`
@@ -1032,8 +1381,8 @@ type ListProductsByCategorySlugRow struct {
 	Name              string           `json:"name"`
 	Slug              string           `json:"slug"`
 	Description       string           `json:"description"`
-	Price             float64          `json:"price"`
-	PriceSale         float64          `json:"price_sale"`
+	Price             decimal.Decimal  `json:"price"`
+	PriceSale         decimal.Decimal  `json:"price_sale"`
 	UnitOfMeasurement string           `json:"unit_of_measurement"`
 	ImageUrl          string           `json:"image_url"`
 	ThumbUrl          string           `json:"thumb_url"`
@@ -1076,6 +1425,243 @@ func (q *Queries) ListProductsByCategorySlug(ctx context.Context, arg ListProduc
 	return items, nil
 }
 
+const listProductsByIDs = `-- name: ListProductsByIDs :many
+SELECT
+    p.id,
+    p.category_id,
+    p.name,
+    p.slug,
+    p.price,
+    p.price_sale,
+    c.slug as category_slug
+FROM products p
+JOIN categories c ON p.category_id = c.id
+WHERE p.id = ANY($1::int[])
+`
+
+type ListProductsByIDsRow struct {
+	ID           int32           `json:"id"`
+	CategoryID   int32           `json:"category_id"`
+	Name         string          `json:"name"`
+	Slug         string          `json:"slug"`
+	Price        decimal.Decimal `json:"price"`
+	PriceSale    decimal.Decimal `json:"price_sale"`
+	CategorySlug string          `json:"category_slug"`
+}
+
+func (q *Queries) ListProductsByIDs(ctx context.Context, ids []int32) ([]ListProductsByIDsRow, error) {
+	rows, err := q.db.Query(ctx, listProductsByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProductsByIDsRow{}
+	for rows.Next() {
+		var i ListProductsByIDsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CategoryID,
+			&i.Name,
+			&i.Slug,
+			&i.Price,
+			&i.PriceSale,
+			&i.CategorySlug,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductsForExport = `-- name: ListProductsForExport :many
+SELECT
+    p.id,
+    c.slug as category_slug,
+    p.name,
+    p.slug,
+    p.description,
+    p.price,
+    p.price_sale,
+    p.unit_of_measurement,
+    p.image_url,
+    p.thumb_url
+FROM products p
+JOIN categories c ON p.category_id = c.id
+WHERE p.id > $1
+ORDER BY p.id
+LIMIT $2
+`
+
+type ListProductsForExportParams struct {
+	AfterID  int32 `json:"after_id"`
+	RowLimit int32 `json:"row_limit"`
+}
+
+type ListProductsForExportRow struct {
+	ID                int32           `json:"id"`
+	CategorySlug      string          `json:"category_slug"`
+	Name              string          `json:"name"`
+	Slug              string          `json:"slug"`
+	Description       string          `json:"description"`
+	Price             decimal.Decimal `json:"price"`
+	PriceSale         decimal.Decimal `json:"price_sale"`
+	UnitOfMeasurement string          `json:"unit_of_measurement"`
+	ImageUrl          string          `json:"image_url"`
+	ThumbUrl          string          `json:"thumb_url"`
+}
+
+func (q *Queries) ListProductsForExport(ctx context.Context, arg ListProductsForExportParams) ([]ListProductsForExportRow, error) {
+	rows, err := q.db.Query(ctx, listProductsForExport, arg.AfterID, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProductsForExportRow{}
+	for rows.Next() {
+		var i ListProductsForExportRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CategorySlug,
+			&i.Name,
+			&i.Slug,
+			&i.Description,
+			&i.Price,
+			&i.PriceSale,
+			&i.UnitOfMeasurement,
+			&i.ImageUrl,
+			&i.ThumbUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPublishedBlogPosts = `-- name: ListPublishedBlogPosts :many
+SELECT id, title, slug, description, content, image_url, created_at, updated_at, status, published_at, author_id, content_format, meta_title, meta_description, canonical_url, og_image_url
+FROM blog_posts
+WHERE status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP
+    AND ($1::text IS NULL OR id IN (
+        SELECT bpt.blog_post_id
+        FROM blog_post_tags bpt
+        JOIN blog_tags t ON t.id = bpt.tag_id
+        WHERE t.slug = $1
+    ))
+    AND ($2::text IS NULL OR id IN (
+        SELECT bpc.blog_post_id
+        FROM blog_post_categories bpc
+        JOIN blog_categories c ON c.id = bpc.category_id
+        WHERE c.slug = $2
+    ))
+ORDER BY published_at DESC
+LIMIT $3 OFFSET $4
+`
+
+type ListPublishedBlogPostsParams struct {
+	Tag      pgtype.Text `json:"tag"`
+	Category pgtype.Text `json:"category"`
+	Limit    int32       `json:"limit"`
+	Offset   int32       `json:"offset"`
+}
+
+func (q *Queries) ListPublishedBlogPosts(ctx context.Context, arg ListPublishedBlogPostsParams) ([]BlogPost, error) {
+	rows, err := q.db.Query(ctx, listPublishedBlogPosts,
+		arg.Tag,
+		arg.Category,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []BlogPost{}
+	for rows.Next() {
+		var i BlogPost
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Slug,
+			&i.Description,
+			&i.Content,
+			&i.ImageUrl,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.PublishedAt,
+			&i.AuthorID,
+			&i.ContentFormat,
+			&i.MetaTitle,
+			&i.MetaDescription,
+			&i.CanonicalUrl,
+			&i.OgImageUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPublishedPages = `-- name: ListPublishedPages :many
+SELECT id, slug, title, description, content, created_at, updated_at, status, published_at, content_format, meta_title, meta_description, canonical_url, og_image_url
+FROM pages
+WHERE status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP
+ORDER BY published_at DESC
+LIMIT $1 OFFSET $2
+`
+
+type ListPublishedPagesParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListPublishedPages(ctx context.Context, arg ListPublishedPagesParams) ([]Page, error) {
+	rows, err := q.db.Query(ctx, listPublishedPages, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Page{}
+	for rows.Next() {
+		var i Page
+		if err := rows.Scan(
+			&i.ID,
+			&i.Slug,
+			&i.Title,
+			&i.Description,
+			&i.Content,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.PublishedAt,
+			&i.ContentFormat,
+			&i.MetaTitle,
+			&i.MetaDescription,
+			&i.CanonicalUrl,
+			&i.OgImageUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUsers = `-- name: ListUsers :many
 SELECT id, email, role, created_at, updated_at
 FROM users
@@ -1141,7 +1727,7 @@ func (q *Queries) ListWebsiteSettings(ctx context.Context) ([]WebsiteSetting, er
 }
 
 const searchBlogPosts = `-- name: SearchBlogPosts :many
-SELECT id, title, slug, description, content, image_url, created_at, updated_at
+SELECT id, title, slug, description, content, image_url, created_at, updated_at, status, published_at, author_id, content_format, meta_title, meta_description, canonical_url, og_image_url
 FROM blog_posts
 WHERE 
     title ILIKE '%' || $1 || '%' OR
@@ -1174,6 +1760,14 @@ func (q *Queries) SearchBlogPosts(ctx context.Context, arg SearchBlogPostsParams
 			&i.ImageUrl,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Status,
+			&i.PublishedAt,
+			&i.AuthorID,
+			&i.ContentFormat,
+			&i.MetaTitle,
+			&i.MetaDescription,
+			&i.CanonicalUrl,
+			&i.OgImageUrl,
 		); err != nil {
 			return nil, err
 		}
@@ -1185,91 +1779,151 @@ func (q *Queries) SearchBlogPosts(ctx context.Context, arg SearchBlogPostsParams
 	return items, nil
 }
 
-const updateBlogPost = `-- name: UpdateBlogPost :exec
+const updateBlogPost = `-- name: UpdateBlogPost :execrows
 UPDATE blog_posts
 SET 
     title = $1,
     description = $2,
     content = $3,
     image_url = $4,
-    slug = $5
-WHERE id = $6
+    slug = $5,
+    status = $6,
+    published_at = $7,
+    content_format = $8,
+    meta_title = $9,
+    meta_description = $10,
+    canonical_url = $11,
+    og_image_url = $12
+WHERE id = $13
 `
 
 type UpdateBlogPostParams struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Content     string `json:"content"`
-	ImageUrl    string `json:"image_url"`
-	Slug        string `json:"slug"`
-	ID          int32  `json:"id"`
-}
-
-func (q *Queries) UpdateBlogPost(ctx context.Context, arg UpdateBlogPostParams) error {
-	_, err := q.db.Exec(ctx, updateBlogPost,
+	Title           string           `json:"title"`
+	Description     string           `json:"description"`
+	Content         string           `json:"content"`
+	ImageUrl        string           `json:"image_url"`
+	Slug            string           `json:"slug"`
+	Status          string           `json:"status"`
+	PublishedAt     pgtype.Timestamp `json:"published_at"`
+	ContentFormat   string           `json:"content_format"`
+	MetaTitle       string           `json:"meta_title"`
+	MetaDescription string           `json:"meta_description"`
+	CanonicalUrl    string           `json:"canonical_url"`
+	OgImageUrl      string           `json:"og_image_url"`
+	ID              int32            `json:"id"`
+}
+
+func (q *Queries) UpdateBlogPost(ctx context.Context, arg UpdateBlogPostParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateBlogPost,
 		arg.Title,
 		arg.Description,
 		arg.Content,
 		arg.ImageUrl,
 		arg.Slug,
+		arg.Status,
+		arg.PublishedAt,
+		arg.ContentFormat,
+		arg.MetaTitle,
+		arg.MetaDescription,
+		arg.CanonicalUrl,
+		arg.OgImageUrl,
 		arg.ID,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const updateCategory = `-- name: UpdateCategory :exec
+const updateCategory = `-- name: UpdateCategory :execrows
 UPDATE categories
-SET name = $1, slug = $2, description = $3, image_url = $4
-WHERE id = $5
+SET name = $1, slug = $2, description = $3, image_url = $4,
+    meta_title = $5, meta_description = $6, canonical_url = $7, og_image_url = $8
+WHERE id = $9
 `
 
 type UpdateCategoryParams struct {
-	Name        string      `json:"name"`
-	Slug        string      `json:"slug"`
-	Description pgtype.Text `json:"description"`
-	ImageUrl    pgtype.Text `json:"image_url"`
-	ID          int32       `json:"id"`
-}
-
-func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams) error {
-	_, err := q.db.Exec(ctx, updateCategory,
+	Name            string      `json:"name"`
+	Slug            string      `json:"slug"`
+	Description     pgtype.Text `json:"description"`
+	ImageUrl        pgtype.Text `json:"image_url"`
+	MetaTitle       string      `json:"meta_title"`
+	MetaDescription string      `json:"meta_description"`
+	CanonicalUrl    string      `json:"canonical_url"`
+	OgImageUrl      string      `json:"og_image_url"`
+	ID              int32       `json:"id"`
+}
+
+func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateCategory,
 		arg.Name,
 		arg.Slug,
 		arg.Description,
 		arg.ImageUrl,
+		arg.MetaTitle,
+		arg.MetaDescription,
+		arg.CanonicalUrl,
+		arg.OgImageUrl,
 		arg.ID,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const updatePage = `-- name: UpdatePage :exec
+const updatePage = `-- name: UpdatePage :execrows
 UPDATE pages
 SET 
     slug = $1,
     title = $2,
     content = $3,
+    status = $4,
+    published_at = $5,
+    content_format = $6,
+    meta_title = $7,
+    meta_description = $8,
+    canonical_url = $9,
+    og_image_url = $10,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $4
+WHERE id = $11
 `
 
 type UpdatePageParams struct {
-	Slug    string `json:"slug"`
-	Title   string `json:"title"`
-	Content string `json:"content"`
-	ID      int32  `json:"id"`
-}
-
-func (q *Queries) UpdatePage(ctx context.Context, arg UpdatePageParams) error {
-	_, err := q.db.Exec(ctx, updatePage,
+	Slug            string           `json:"slug"`
+	Title           string           `json:"title"`
+	Content         string           `json:"content"`
+	Status          string           `json:"status"`
+	PublishedAt     pgtype.Timestamp `json:"published_at"`
+	ContentFormat   string           `json:"content_format"`
+	MetaTitle       string           `json:"meta_title"`
+	MetaDescription string           `json:"meta_description"`
+	CanonicalUrl    string           `json:"canonical_url"`
+	OgImageUrl      string           `json:"og_image_url"`
+	ID              int32            `json:"id"`
+}
+
+func (q *Queries) UpdatePage(ctx context.Context, arg UpdatePageParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updatePage,
 		arg.Slug,
 		arg.Title,
 		arg.Content,
+		arg.Status,
+		arg.PublishedAt,
+		arg.ContentFormat,
+		arg.MetaTitle,
+		arg.MetaDescription,
+		arg.CanonicalUrl,
+		arg.OgImageUrl,
 		arg.ID,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const updateProduct = `-- name: UpdateProduct :exec
+const updateProduct = `-- name: UpdateProduct :execrows
 UPDATE products
 SET
     category_id = $1,
@@ -1280,25 +1934,33 @@ SET
     price_sale = $6,
     unit_of_measurement = $7,
     image_url = $8,
-    thumb_url = $9
-WHERE id = $10
+    thumb_url = $9,
+    meta_title = $10,
+    meta_description = $11,
+    canonical_url = $12,
+    og_image_url = $13
+WHERE id = $14
 `
 
 type UpdateProductParams struct {
-	CategoryID        int32   `json:"category_id"`
-	Name              string  `json:"name"`
-	Slug              string  `json:"slug"`
-	Description       string  `json:"description"`
-	Price             float64 `json:"price"`
-	PriceSale         float64 `json:"price_sale"`
-	UnitOfMeasurement string  `json:"unit_of_measurement"`
-	ImageUrl          string  `json:"image_url"`
-	ThumbUrl          string  `json:"thumb_url"`
-	ID                int32   `json:"id"`
-}
-
-func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) error {
-	_, err := q.db.Exec(ctx, updateProduct,
+	CategoryID        int32           `json:"category_id"`
+	Name              string          `json:"name"`
+	Slug              string          `json:"slug"`
+	Description       string          `json:"description"`
+	Price             decimal.Decimal `json:"price"`
+	PriceSale         decimal.Decimal `json:"price_sale"`
+	UnitOfMeasurement string          `json:"unit_of_measurement"`
+	ImageUrl          string          `json:"image_url"`
+	ThumbUrl          string          `json:"thumb_url"`
+	MetaTitle         string          `json:"meta_title"`
+	MetaDescription   string          `json:"meta_description"`
+	CanonicalUrl      string          `json:"canonical_url"`
+	OgImageUrl        string          `json:"og_image_url"`
+	ID                int32           `json:"id"`
+}
+
+func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateProduct,
 		arg.CategoryID,
 		arg.Name,
 		arg.Slug,
@@ -1308,9 +1970,16 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) er
 		arg.UnitOfMeasurement,
 		arg.ImageUrl,
 		arg.ThumbUrl,
+		arg.MetaTitle,
+		arg.MetaDescription,
+		arg.CanonicalUrl,
+		arg.OgImageUrl,
 		arg.ID,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const updateUser = `-- name: UpdateUser :exec
@@ -1329,7 +1998,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) error {
 	return err
 }
 
-const updateWebsiteSetting = `-- name: UpdateWebsiteSetting :exec
+const updateWebsiteSetting = `-- name: UpdateWebsiteSetting :execrows
 UPDATE website_settings
 SET value = $1
 WHERE name = $2
@@ -1340,7 +2009,70 @@ type UpdateWebsiteSettingParams struct {
 	Name  string `json:"name"`
 }
 
-func (q *Queries) UpdateWebsiteSetting(ctx context.Context, arg UpdateWebsiteSettingParams) error {
-	_, err := q.db.Exec(ctx, updateWebsiteSetting, arg.Value, arg.Name)
-	return err
+func (q *Queries) UpdateWebsiteSetting(ctx context.Context, arg UpdateWebsiteSettingParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateWebsiteSetting, arg.Value, arg.Name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const upsertProductBySlug = `-- name: UpsertProductBySlug :one
+INSERT INTO products (
+    category_id,
+    name,
+    slug,
+    description,
+    price,
+    price_sale,
+    unit_of_measurement,
+    image_url,
+    thumb_url
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (slug) DO UPDATE
+SET
+    category_id = EXCLUDED.category_id,
+    name = EXCLUDED.name,
+    description = EXCLUDED.description,
+    price = EXCLUDED.price,
+    price_sale = EXCLUDED.price_sale,
+    unit_of_measurement = EXCLUDED.unit_of_measurement,
+    image_url = EXCLUDED.image_url,
+    thumb_url = EXCLUDED.thumb_url
+RETURNING id, (xmax = 0)::boolean AS inserted
+`
+
+type UpsertProductBySlugParams struct {
+	CategoryID        int32           `json:"category_id"`
+	Name              string          `json:"name"`
+	Slug              string          `json:"slug"`
+	Description       string          `json:"description"`
+	Price             decimal.Decimal `json:"price"`
+	PriceSale         decimal.Decimal `json:"price_sale"`
+	UnitOfMeasurement string          `json:"unit_of_measurement"`
+	ImageUrl          string          `json:"image_url"`
+	ThumbUrl          string          `json:"thumb_url"`
+}
+
+type UpsertProductBySlugRow struct {
+	ID       int32 `json:"id"`
+	Inserted bool  `json:"inserted"`
+}
+
+func (q *Queries) UpsertProductBySlug(ctx context.Context, arg UpsertProductBySlugParams) (UpsertProductBySlugRow, error) {
+	row := q.db.QueryRow(ctx, upsertProductBySlug,
+		arg.CategoryID,
+		arg.Name,
+		arg.Slug,
+		arg.Description,
+		arg.Price,
+		arg.PriceSale,
+		arg.UnitOfMeasurement,
+		arg.ImageUrl,
+		arg.ThumbUrl,
+	)
+	var i UpsertProductBySlugRow
+	err := row.Scan(&i.ID, &i.Inserted)
+	return i, err
 }
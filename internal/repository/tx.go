@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// PostgreSQL error codes of transactions that failed only because of concurrent ones
+// and succeed when run again
+const (
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+const (
+	// defaultTxAttempts is how often a transaction is run when TxOptions leaves it unset
+	defaultTxAttempts = 3
+	// txRetryDelay is the base delay before running a transaction again. It grows
+	// with every attempt and is jittered so the competing transactions spread out.
+	txRetryDelay = 20 * time.Millisecond
+)
+
+// TxRunner runs a function inside a database transaction. Services take it next to
+// a Querier, so they can be tested without a database.
+type TxRunner interface {
+	// InTx commits when fn returns nil and rolls back otherwise. The Querier given
+	// to fn is bound to the transaction. fn may be run more than once, so it should
+	// have no effects outside the transaction.
+	InTx(ctx context.Context, fn func(q Querier) error) error
+}
+
+// TxBeginner starts transactions, e.g. a *pgxpool.Pool
+type TxBeginner interface {
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+// TxOptions configures the transactions of a PoolTxRunner
+type TxOptions struct {
+	// IsoLevel is the isolation level, the server default (read committed) when empty
+	IsoLevel pgx.TxIsoLevel
+	// MaxAttempts is how often a transaction failing with a serialization failure or
+	// a deadlock is run before its error is returned, 3 when zero
+	MaxAttempts int
+}
+
+// PoolTxRunner runs transactions on a connection pool
+type PoolTxRunner struct {
+	db   TxBeginner
+	opts TxOptions
+}
+
+func NewTxRunner(db TxBeginner) *PoolTxRunner {
+	return &PoolTxRunner{db: db}
+}
+
+// WithOptions returns a runner on the same pool using opts, e.g. for serializable
+// transactions
+func (r *PoolTxRunner) WithOptions(opts TxOptions) *PoolTxRunner {
+	return &PoolTxRunner{db: r.db, opts: opts}
+}
+
+// WithTransaction runs fn in a transaction, committing when it returns nil and rolling
+// back otherwise. When the transaction fails with a serialization failure (40001) or a
+// deadlock (40P01) it is rolled back and run again from the start, up to
+// MaxAttempts times, so fn must not have effects outside the transaction.
+func (r *PoolTxRunner) WithTransaction(ctx context.Context, fn func(q *Queries) error) error {
+	attempts := r.opts.MaxAttempts
+	if attempts <= 0 {
+		attempts = defaultTxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := r.run(ctx, fn)
+		if err == nil || attempt >= attempts || !isRetryable(err) {
+			return err
+		}
+
+		delay := time.Duration(attempt) * txRetryDelay
+		delay += rand.N(delay)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (r *PoolTxRunner) InTx(ctx context.Context, fn func(q Querier) error) error {
+	return r.WithTransaction(ctx, func(q *Queries) error {
+		return fn(q)
+	})
+}
+
+// run runs fn in a single transaction
+func (r *PoolTxRunner) run(ctx context.Context, fn func(q *Queries) error) error {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: r.opts.IsoLevel})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(New(tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// isRetryable reports whether err means the transaction lost against a concurrent one
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == pgSerializationFailure || pgErr.Code == pgDeadlockDetected
+}
+
+var _ TxRunner = (*PoolTxRunner)(nil)
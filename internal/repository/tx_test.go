@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeDB counts the transactions it starts and how they ended
+type fakeDB struct {
+	opts               []pgx.TxOptions
+	commits, rollbacks int
+	commitErr          error
+}
+
+func (db *fakeDB) BeginTx(ctx context.Context, opts pgx.TxOptions) (pgx.Tx, error) {
+	db.opts = append(db.opts, opts)
+	return &fakeTx{db: db}, nil
+}
+
+// fakeTx implements the transaction methods the runner calls; the others panic
+type fakeTx struct {
+	pgx.Tx
+	db   *fakeDB
+	done bool
+}
+
+func (tx *fakeTx) Commit(ctx context.Context) error {
+	tx.done = true
+	if tx.db.commitErr != nil {
+		return tx.db.commitErr
+	}
+	tx.db.commits++
+	return nil
+}
+
+func (tx *fakeTx) Rollback(ctx context.Context) error {
+	if !tx.done {
+		tx.done = true
+		tx.db.rollbacks++
+	}
+	return nil
+}
+
+func TestWithTransaction(t *testing.T) {
+	serializationFailure := &pgconn.PgError{Code: pgSerializationFailure}
+	deadlock := &pgconn.PgError{Code: pgDeadlockDetected}
+	uniqueViolation := &pgconn.PgError{Code: "23505"}
+
+	tests := []struct {
+		name          string
+		opts          TxOptions
+		errs          []error // returned by fn, one per run
+		wantErr       error
+		wantRuns      int
+		wantCommits   int
+		wantRollbacks int
+	}{
+		{
+			name:        "commits",
+			errs:        []error{nil},
+			wantRuns:    1,
+			wantCommits: 1,
+		},
+		{
+			name:          "retries a serialization failure",
+			errs:          []error{serializationFailure, nil},
+			wantRuns:      2,
+			wantCommits:   1,
+			wantRollbacks: 1,
+		},
+		{
+			name:          "retries a wrapped deadlock",
+			errs:          []error{errors.Join(errors.New("row 3"), deadlock), nil},
+			wantRuns:      2,
+			wantCommits:   1,
+			wantRollbacks: 1,
+		},
+		{
+			name:          "gives up after the attempts",
+			opts:          TxOptions{MaxAttempts: 2},
+			errs:          []error{serializationFailure, serializationFailure, nil},
+			wantErr:       serializationFailure,
+			wantRuns:      2,
+			wantRollbacks: 2,
+		},
+		{
+			name:          "does not retry other errors",
+			errs:          []error{uniqueViolation, nil},
+			wantErr:       uniqueViolation,
+			wantRuns:      1,
+			wantRollbacks: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &fakeDB{}
+			runs := 0
+			err := NewTxRunner(db).WithOptions(tt.opts).WithTransaction(context.Background(), func(q *Queries) error {
+				err := tt.errs[runs]
+				runs++
+				return err
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if runs != tt.wantRuns || db.commits != tt.wantCommits || db.rollbacks != tt.wantRollbacks {
+				t.Errorf("runs, commits, rollbacks = %d, %d, %d, want %d, %d, %d",
+					runs, db.commits, db.rollbacks, tt.wantRuns, tt.wantCommits, tt.wantRollbacks)
+			}
+		})
+	}
+}
+
+func TestWithTransactionRetriesCommit(t *testing.T) {
+	db := &fakeDB{commitErr: &pgconn.PgError{Code: pgSerializationFailure}}
+	runs := 0
+	err := NewTxRunner(db).WithTransaction(context.Background(), func(q *Queries) error {
+		runs++
+		if runs == defaultTxAttempts {
+			db.commitErr = nil
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	if runs != defaultTxAttempts || db.commits != 1 {
+		t.Errorf("runs, commits = %d, %d, want %d, 1", runs, db.commits, defaultTxAttempts)
+	}
+}
+
+func TestWithTransactionIsolationLevel(t *testing.T) {
+	db := &fakeDB{}
+	runner := NewTxRunner(db).WithOptions(TxOptions{IsoLevel: pgx.Serializable})
+	if err := runner.InTx(context.Background(), func(q Querier) error { return nil }); err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	if len(db.opts) != 1 || db.opts[0].IsoLevel != pgx.Serializable {
+		t.Errorf("transaction options = %+v, want serializable", db.opts)
+	}
+}
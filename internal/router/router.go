@@ -0,0 +1,208 @@
+// Package router builds the HTTP surface of the API. It only sees the services through
+// the interfaces of the handler package, so tests can build it with fakes.
+package router
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+
+	"beef-db-be/internal/config"
+	"beef-db-be/internal/handler"
+	"beef-db-be/internal/middleware"
+	"beef-db-be/internal/utils"
+)
+
+// Deps holds what the routes are built from. All fields are required.
+type Deps struct {
+	Config  *config.Config
+	Log     *slog.Logger
+	Locales utils.Locales
+
+	Users           handler.UserService
+	Categories      handler.CategoryService
+	Products        handler.ProductService
+	WebsiteSettings handler.WebsiteSettingService
+	Pages           handler.PageService
+	BlogPosts       handler.BlogPostService
+	BlogTaxonomy    handler.BlogTaxonomyService
+	Coupons         handler.CouponService
+	Sitemap         handler.SitemapService
+	Feed            handler.FeedService
+	Translations    handler.TranslationService
+	Currency        handler.CurrencyService
+	Health          handler.HealthService
+}
+
+// NewRouter returns the routes of the API with their middleware
+func NewRouter(deps Deps) http.Handler {
+	// Initialize handlers
+	userHandler := handler.NewUserHandler(deps.Users, deps.Config.Auth)
+	categoryHandler := handler.NewCategoryHandler(deps.Categories)
+	productHandler := handler.NewProductHandler(deps.Products, deps.WebsiteSettings, deps.Categories)
+	websiteSettingHandler := handler.NewWebsiteSettingHandler(deps.WebsiteSettings)
+	pageHandler := handler.NewPageHandler(deps.Pages)
+	blogPostHandler := handler.NewBlogPostHandler(deps.BlogPosts)
+	blogTaxonomyHandler := handler.NewBlogTaxonomyHandler(deps.BlogTaxonomy)
+	couponHandler := handler.NewCouponHandler(deps.Coupons, deps.Config.Auth)
+	sitemapHandler := handler.NewSitemapHandler(deps.Sitemap)
+	feedHandler := handler.NewFeedHandler(deps.Feed)
+	translationHandler := handler.NewTranslationHandler(deps.Translations)
+	currencyHandler := handler.NewCurrencyHandler(deps.Currency)
+	healthHandler := handler.NewHealthHandler(deps.Health)
+
+	// Initialize router
+	r := chi.NewRouter()
+
+	// Global middleware
+	r.Use(chimiddleware.RequestID)
+	r.Use(chimiddleware.RealIP)
+	r.Use(middleware.Tracing)
+	r.Use(middleware.RequestLogger(deps.Log))
+	r.Use(middleware.Metrics)
+	r.Use(chimiddleware.Recoverer)
+	r.Use(middleware.CORS(deps.Config.CORS))
+	r.Use(middleware.Locale(deps.Locales))
+	r.Use(middleware.Currency)
+
+	// Health check endpoint
+	r.Get("/health", healthHandler.CheckHealth)
+	r.Get("/livez", healthHandler.Livez)
+	r.Get("/readyz", healthHandler.Readyz)
+	r.With(middleware.RequireAuth(deps.Users, deps.Config.Auth)).Get("/health/details", healthHandler.GetDetails)
+
+	// SEO endpoints
+	r.Get("/sitemap.xml", sitemapHandler.GetSitemap)
+	r.Get("/sitemaps/{section}-{page}.xml", sitemapHandler.GetSitemapSection)
+	r.Get("/robots.txt", sitemapHandler.GetRobotsTxt)
+
+	// Blog feeds
+	r.Get("/feed.xml", feedHandler.GetRSS)
+	r.Get("/atom.xml", feedHandler.GetAtom)
+
+	// Routes
+	r.Route("/api", func(r chi.Router) {
+		// Public routes
+		r.Post("/auth/signup", userHandler.SignUp)
+		r.Post("/auth/login", userHandler.Login)
+		r.Post("/auth/logout", userHandler.Logout)
+		r.Get("/users/me", userHandler.GetMe) // Public endpoint for getting current user
+
+		// Public category routes
+		r.Get("/categories", categoryHandler.ListCategories)
+		r.Get("/categories/{id}", categoryHandler.GetCategory)
+		r.Get("/categories/slug/{slug}", categoryHandler.GetCategoryBySlug)
+
+		// Public product routes
+		r.Get("/products", productHandler.ListProducts)
+		r.Get("/products/{id}", productHandler.GetProduct)
+		r.Get("/products/slug/{slug}", productHandler.GetProductBySlug)
+		r.Get("/products/by-setting-categories", productHandler.ListProductsBySettingCategories)
+		r.Get("/categories/{categoryId}/products", productHandler.ListProductsByCategoryByID)
+		r.Get("/categories/slug/{categorySlug}/products", productHandler.ListProductsByCategoryBySlug)
+
+		// Public page routes
+		r.Get("/pages", pageHandler.ListPages)
+		r.Get("/pages/{id}", pageHandler.GetPage)
+		r.Get("/pages/slug/{slug}", pageHandler.GetPageBySlug)
+		r.Get("/pages/preview", pageHandler.PreviewPage)
+
+		// Public blog post routes
+		r.Get("/blog-posts", blogPostHandler.List)
+		r.Get("/blog-posts/{id}", blogPostHandler.GetByID)
+		r.Get("/blog-posts/slug/{slug}", blogPostHandler.GetBySlug)
+		r.Get("/blog-posts/preview", blogPostHandler.Preview)
+		r.Get("/blog-categories", blogTaxonomyHandler.ListCategories)
+		r.Get("/blog-tags", blogTaxonomyHandler.ListTags)
+
+		// Public website settings routes
+		r.Get("/settings", websiteSettingHandler.List)
+		r.Get("/settings/{id}", websiteSettingHandler.Get)
+		r.Get("/settings/name/{name}", websiteSettingHandler.GetByName)
+
+		// Public exchange rate routes
+		r.Get("/exchange-rates", currencyHandler.ListRates)
+
+		// Public coupon routes
+		r.Post("/coupons/validate", couponHandler.ValidateCoupon)
+
+		// Admin-only routes
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequireAuth(deps.Users, deps.Config.Auth))
+
+			// User management
+			r.Get("/users/{id}", userHandler.GetUser)
+			r.Get("/users", userHandler.ListUsers)
+
+			// Category management
+			r.Post("/categories", categoryHandler.CreateCategory)
+			r.Put("/categories/{id}", categoryHandler.UpdateCategory)
+			r.Delete("/categories/{id}", categoryHandler.DeleteCategory)
+
+			// Product management
+			r.Post("/products", productHandler.CreateProduct)
+			r.Put("/products/{id}", productHandler.UpdateProduct)
+			r.Delete("/products/{id}", productHandler.DeleteProduct)
+			r.Post("/admin/products/import", productHandler.ImportProducts)
+			r.Get("/admin/products/export", productHandler.ExportProducts)
+
+			// Website settings management
+			r.Post("/settings", websiteSettingHandler.Create)
+			r.Put("/settings/name/{name}", websiteSettingHandler.Update)
+			r.Delete("/settings/{id}", websiteSettingHandler.Delete)
+
+			// Page management
+			r.Post("/pages", pageHandler.CreatePage)
+			r.Put("/pages/{id}", pageHandler.UpdatePage)
+			r.Delete("/pages/{id}", pageHandler.DeletePage)
+			r.Get("/admin/pages", pageHandler.AdminListPages)
+			r.Get("/admin/pages/{id}", pageHandler.AdminGetPage)
+			r.Post("/admin/pages/{id}/preview-token", pageHandler.CreatePagePreviewToken)
+			r.Get("/admin/pages/{id}/revisions", pageHandler.ListPageRevisions)
+			r.Get("/admin/pages/{id}/revisions/diff", pageHandler.DiffPageRevisions)
+			r.Get("/admin/pages/{id}/revisions/{revisionId}", pageHandler.GetPageRevision)
+			r.Post("/admin/pages/{id}/revisions/{revisionId}/restore", pageHandler.RestorePageRevision)
+
+			// Blog post management
+			r.Post("/blog-posts", blogPostHandler.Create)
+			r.Put("/blog-posts/{id}", blogPostHandler.Update)
+			r.Delete("/blog-posts/{id}", blogPostHandler.Delete)
+			r.Get("/admin/blog-posts", blogPostHandler.AdminList)
+			r.Get("/admin/blog-posts/{id}", blogPostHandler.AdminGetByID)
+			r.Post("/admin/blog-posts/{id}/preview-token", blogPostHandler.CreatePreviewToken)
+			r.Get("/admin/blog-posts/{id}/revisions", blogPostHandler.ListRevisions)
+			r.Get("/admin/blog-posts/{id}/revisions/diff", blogPostHandler.DiffRevisions)
+			r.Get("/admin/blog-posts/{id}/revisions/{revisionId}", blogPostHandler.GetRevision)
+			r.Post("/admin/blog-posts/{id}/revisions/{revisionId}/restore", blogPostHandler.RestoreRevision)
+
+			// Blog taxonomy management
+			r.Post("/blog-categories", blogTaxonomyHandler.CreateCategory)
+			r.Put("/blog-categories/{id}", blogTaxonomyHandler.UpdateCategory)
+			r.Delete("/blog-categories/{id}", blogTaxonomyHandler.DeleteCategory)
+			r.Delete("/blog-tags/{id}", blogTaxonomyHandler.DeleteTag)
+
+			// Coupon management
+			r.Get("/coupons", couponHandler.ListCoupons)
+			r.Get("/coupons/{id}", couponHandler.GetCoupon)
+			r.Post("/coupons", couponHandler.CreateCoupon)
+			r.Put("/coupons/{id}", couponHandler.UpdateCoupon)
+			r.Delete("/coupons/{id}", couponHandler.DeleteCoupon)
+			r.Post("/admin/coupons/redeem", couponHandler.RedeemCoupon)
+
+			// Translation management
+			r.Get("/admin/translations/missing", translationHandler.ListMissingTranslations)
+			r.Get("/admin/translations/{resource}/{id}", translationHandler.ListTranslations)
+			r.Put("/admin/translations/{resource}/{id}/{locale}", translationHandler.UpsertTranslation)
+			r.Delete("/admin/translations/{resource}/{id}/{locale}", translationHandler.DeleteTranslation)
+
+			// Exchange rate management
+			r.Get("/admin/exchange-rates/{currency}/history", currencyHandler.ListRateHistory)
+			r.Post("/admin/exchange-rates", currencyHandler.CreateRate)
+			r.Delete("/admin/exchange-rates/{id}", currencyHandler.DeleteRate)
+		})
+	})
+
+	return r
+}
@@ -0,0 +1,116 @@
+package router_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"beef-db-be/internal/config"
+	"beef-db-be/internal/handler"
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/router"
+	"beef-db-be/internal/service"
+	"beef-db-be/internal/utils"
+)
+
+// fakeCategories serves one category. Methods the tests do not call are left to the
+// embedded nil interface and panic.
+type fakeCategories struct {
+	handler.CategoryService
+}
+
+func (fakeCategories) ListCategories(ctx context.Context) ([]model.Category, error) {
+	return []model.Category{{ID: 1, Name: "Beef", Slug: "beef"}}, nil
+}
+
+func (fakeCategories) GetCategory(ctx context.Context, id int) (*model.Category, error) {
+	if id != 1 {
+		return nil, service.NewNotFoundError("category")
+	}
+	return &model.Category{ID: 1, Name: "Beef", Slug: "beef"}, nil
+}
+
+type fakeUsers struct {
+	handler.UserService
+}
+
+type fakeHealth struct {
+	handler.HealthService
+}
+
+func (fakeHealth) Ready(ctx context.Context) model.HealthReport {
+	return model.HealthReport{Status: model.HealthStatusOK}
+}
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	cfg := &config.Config{Auth: config.AuthConfig{JWTSecret: "test-secret", TokenExpiryHours: 1}}
+	srv := httptest.NewServer(router.NewRouter(router.Deps{
+		Config:     cfg,
+		Log:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Locales:    utils.NewLocales(config.LocaleConfig{Default: "vi"}),
+		Users:      fakeUsers{},
+		Categories: fakeCategories{},
+		Health:     fakeHealth{},
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestNewRouter(t *testing.T) {
+	srv := newServer(t)
+
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{http.MethodGet, "/livez", http.StatusOK},
+		{http.MethodGet, "/readyz", http.StatusOK},
+		{http.MethodGet, "/api/categories", http.StatusOK},
+		{http.MethodGet, "/api/categories/1", http.StatusOK},
+		{http.MethodGet, "/api/categories/2", http.StatusNotFound},
+		{http.MethodGet, "/api/categories/beef", http.StatusBadRequest},
+		{http.MethodPost, "/api/categories", http.StatusUnauthorized},
+		{http.MethodGet, "/health/details", http.StatusUnauthorized},
+		{http.MethodGet, "/api/unknown", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest(tt.method, srv.URL+tt.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s %s: status %d, want %d", tt.method, tt.path, resp.StatusCode, tt.want)
+		}
+	}
+}
+
+func TestNewRouterUsesTheServices(t *testing.T) {
+	srv := newServer(t)
+
+	resp, err := http.Get(srv.URL + "/api/categories")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Data []model.Category `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.Data) != 1 || body.Data[0].Slug != "beef" {
+		t.Errorf("categories = %+v, want the one of the fake", body.Data)
+	}
+}
@@ -2,88 +2,150 @@ package service
 
 import (
 	"context"
-	"errors"
 
-	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/pgtype"
 
+	"beef-db-be/internal/config"
 	"beef-db-be/internal/model"
 	"beef-db-be/internal/repository"
+	"beef-db-be/internal/utils"
 )
 
+// maxBlogPostSlugLength matches the blog_posts.slug column
+const maxBlogPostSlugLength = 255
+
 type BlogPostService struct {
-	queries *repository.Queries
-	pool    *pgxpool.Pool
+	queries repository.Querier
+	tx      repository.TxRunner
+	auth    config.AuthConfig
 }
 
-func NewBlogPostService(pool *pgxpool.Pool) *BlogPostService {
+func NewBlogPostService(queries repository.Querier, tx repository.TxRunner, auth config.AuthConfig) *BlogPostService {
 	return &BlogPostService{
-		queries: repository.New(pool),
-		pool:    pool,
+		queries: queries,
+		tx:      tx,
+		auth:    auth,
 	}
 }
 
-func (s *BlogPostService) Create(ctx context.Context, req model.CreateBlogPostRequest) (*model.BlogPost, error) {
-	result, err := s.queries.CreateBlogPost(ctx, repository.CreateBlogPostParams{
-		Title:    req.Title,
-		Slug:     req.Slug,
-		Content:  req.Content,
-		ImageUrl: req.ImageURL,
+// Create creates a blog post and stores its first revision. authorID is the signed-in
+// user making the change.
+func (s *BlogPostService) Create(ctx context.Context, req model.CreateBlogPostRequest, authorID int64) (*model.BlogPost, error) {
+	ctx, span := tracer.Start(ctx, "BlogPostService.Create")
+	defer span.End()
+
+	slug, err := resolveSlug(ctx, req.Slug, req.Title, maxBlogPostSlugLength, s.slugExists(0))
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := resolvePublishState(req.Status, req.PublishedAt, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var id int32
+	err = s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		result, err := qtx.CreateBlogPost(ctx, repository.CreateBlogPostParams{
+			Title:           req.Title,
+			Slug:            slug,
+			Description:     req.Description,
+			Content:         req.Content,
+			ImageUrl:        req.ImageURL,
+			Status:          string(state.status),
+			PublishedAt:     state.publishedAt,
+			AuthorID:        pgtype.Int8{Int64: authorID, Valid: authorID != 0},
+			ContentFormat:   resolveContentFormat(req.ContentFormat, ""),
+			MetaTitle:       req.SEO.MetaTitle,
+			MetaDescription: req.SEO.MetaDescription,
+			CanonicalUrl:    req.SEO.CanonicalURL,
+			OgImageUrl:      req.SEO.OGImageURL,
+		})
+		if err != nil {
+			return WrapDBError(err, "blog post")
+		}
+
+		if err := setBlogPostTaxonomy(ctx, qtx, result.ID, req.CategoryIDs, req.Tags); err != nil {
+			return err
+		}
+
+		if err := recordRevision(ctx, qtx, resourceBlogPost, result.ID, revisionSnapshot{
+			title:         result.Title,
+			slug:          result.Slug,
+			description:   result.Description,
+			content:       result.Content,
+			contentFormat: result.ContentFormat,
+		}, authorID); err != nil {
+			return err
+		}
+
+		id = result.ID
+		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &model.BlogPost{
-		ID:        int64(result.ID),
-		Title:     result.Title,
-		Slug:      result.Slug,
-		Content:   result.Content,
-		ImageURL:  result.ImageUrl,
-		CreatedAt: result.CreatedAt.Time,
-	}, nil
+	return s.GetByID(ctx, int64(id))
 }
 
+// GetByID retrieves a blog post regardless of its publishing status
 func (s *BlogPostService) GetByID(ctx context.Context, id int64) (*model.BlogPost, error) {
+	ctx, span := tracer.Start(ctx, "BlogPostService.GetByID")
+	defer span.End()
+
 	post, err := s.queries.GetBlogPost(ctx, int32(id))
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, ErrNotFound
-		}
-		return nil, err
+		return nil, WrapDBError(err, "blog post")
 	}
 
-	return &model.BlogPost{
-		ID:          int64(post.ID),
-		Title:       post.Title,
-		Slug:        post.Slug,
-		Description: post.Description,
-		Content:     post.Content,
-		ImageURL:    post.ImageUrl,
-		CreatedAt:   post.CreatedAt.Time,
-	}, nil
+	return s.withTaxonomy(ctx, post)
 }
 
-func (s *BlogPostService) GetBySlug(ctx context.Context, slug string) (*model.BlogPost, error) {
-	post, err := s.queries.GetBlogPostBySlug(ctx, slug)
+// GetPublishedByID retrieves a blog post only if it is publicly visible
+func (s *BlogPostService) GetPublishedByID(ctx context.Context, id int64) (*model.BlogPost, error) {
+	ctx, span := tracer.Start(ctx, "BlogPostService.GetPublishedByID")
+	defer span.End()
+
+	post, err := s.queries.GetPublishedBlogPost(ctx, int32(id))
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, ErrNotFound
-		}
-		return nil, err
+		return nil, WrapDBError(err, "blog post")
 	}
 
-	return &model.BlogPost{
-		ID:        int64(post.ID),
-		Title:     post.Title,
-		Slug:      post.Slug,
-		Content:   post.Content,
-		ImageURL:  post.ImageUrl,
-		CreatedAt: post.CreatedAt.Time,
-	}, nil
+	return s.localized(ctx, post)
+}
+
+// GetBySlug retrieves a published blog post by its current slug. When slug is a previous
+// slug of the post, the post is returned with moved set to true.
+func (s *BlogPostService) GetBySlug(ctx context.Context, slug string) (post *model.BlogPost, moved bool, err error) {
+	ctx, span := tracer.Start(ctx, "BlogPostService.GetBySlug")
+	defer span.End()
+
+	row, err := s.queries.GetPublishedBlogPostBySlug(ctx, slug)
+	if err == nil {
+		post, err = s.localized(ctx, row)
+		return post, false, err
+	}
+	if !isNotFound(err) {
+		return nil, false, err
+	}
+
+	id, err := lookupSlugHistory(ctx, s.queries, resourceBlogPost, "blog post", slug)
+	if err != nil {
+		return nil, false, err
+	}
+	post, err = s.GetPublishedByID(ctx, int64(id))
+	if err != nil {
+		return nil, false, err
+	}
+	return post, true, nil
 }
 
+// List retrieves all blog posts, including drafts, for admins
 func (s *BlogPostService) List(ctx context.Context, limit, offset int) ([]model.BlogPost, int64, error) {
+	ctx, span := tracer.Start(ctx, "BlogPostService.List")
+	defer span.End()
+
 	// Get total count first
 	totalCount, err := s.queries.GetTotalBlogPosts(ctx)
 	if err != nil {
@@ -99,46 +161,340 @@ func (s *BlogPostService) List(ctx context.Context, limit, offset int) ([]model.
 	}
 
 	result := make([]model.BlogPost, len(posts))
+	refs := make([]*model.BlogPost, len(posts))
 	for i, post := range posts {
-		result[i] = model.BlogPost{
-			ID:        int64(post.ID),
-			Title:     post.Title,
-			Slug:      post.Slug,
-			Content:   post.Content,
-			ImageURL:  post.ImageUrl,
-			CreatedAt: post.CreatedAt.Time,
-		}
+		result[i] = *toBlogPostModel(post)
+		refs[i] = &result[i]
+	}
+	if err := loadBlogPostTaxonomy(ctx, s.queries, refs...); err != nil {
+		return nil, 0, err
+	}
+
+	return result, totalCount, nil
+}
+
+// ListPublished retrieves the publicly visible blog posts, newest first, optionally
+// narrowed to a tag and/or category slug
+func (s *BlogPostService) ListPublished(ctx context.Context, limit, offset int, filter model.BlogPostFilter) ([]model.BlogPost, int64, error) {
+	ctx, span := tracer.Start(ctx, "BlogPostService.ListPublished")
+	defer span.End()
+
+	tag := pgtype.Text{String: filter.Tag, Valid: filter.Tag != ""}
+	category := pgtype.Text{String: filter.Category, Valid: filter.Category != ""}
+
+	totalCount, err := s.queries.GetTotalPublishedBlogPosts(ctx, repository.GetTotalPublishedBlogPostsParams{
+		Tag:      tag,
+		Category: category,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	posts, err := s.queries.ListPublishedBlogPosts(ctx, repository.ListPublishedBlogPostsParams{
+		Tag:      tag,
+		Category: category,
+		Limit:    int32(limit),
+		Offset:   int32(offset),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]model.BlogPost, len(posts))
+	refs := make([]*model.BlogPost, len(posts))
+	for i, post := range posts {
+		result[i] = *toBlogPostModel(post)
+		refs[i] = &result[i]
+	}
+	if err := loadBlogPostTaxonomy(ctx, s.queries, refs...); err != nil {
+		return nil, 0, err
+	}
+	if err := localizeBlogPosts(ctx, s.queries, refs...); err != nil {
+		return nil, 0, err
 	}
 
 	return result, totalCount, nil
 }
 
-func (s *BlogPostService) Update(ctx context.Context, id int64, req model.UpdateBlogPostRequest) error {
-	err := s.queries.UpdateBlogPost(ctx, repository.UpdateBlogPostParams{
-		ID:       int32(id),
-		Title:    req.Title,
-		Slug:     req.Slug,
-		Content:  req.Content,
-		ImageUrl: req.ImageURL,
+// CreatePreviewToken returns a signed token that lets anyone holding it view the post
+// before it is published
+func (s *BlogPostService) CreatePreviewToken(ctx context.Context, id int64) (*model.PreviewTokenResponse, error) {
+	ctx, span := tracer.Start(ctx, "BlogPostService.CreatePreviewToken")
+	defer span.End()
+
+	if _, err := s.queries.GetBlogPost(ctx, int32(id)); err != nil {
+		return nil, WrapDBError(err, "blog post")
+	}
+
+	token, expiresAt, err := utils.GeneratePreviewToken(s.auth, resourceBlogPost, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PreviewTokenResponse{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// GetPreview retrieves the blog post a preview token was issued for, whatever its status
+func (s *BlogPostService) GetPreview(ctx context.Context, token string) (*model.BlogPost, error) {
+	ctx, span := tracer.Start(ctx, "BlogPostService.GetPreview")
+	defer span.End()
+
+	claims, err := utils.ValidatePreviewToken(s.auth, token)
+	if err != nil || claims.Resource != resourceBlogPost {
+		return nil, NewUnauthorizedError("invalid_preview_token", "Preview link is invalid or has expired")
+	}
+
+	post, err := s.queries.GetBlogPost(ctx, int32(claims.ResourceID))
+	if err != nil {
+		return nil, WrapDBError(err, "blog post")
+	}
+	return s.localized(ctx, post)
+}
+
+// Update updates a blog post. An empty slug keeps the current one; when the slug
+// changes the old one is kept in the slug history. Every update stores a new revision.
+func (s *BlogPostService) Update(ctx context.Context, id int64, req model.UpdateBlogPostRequest, authorID int64) error {
+	ctx, span := tracer.Start(ctx, "BlogPostService.Update")
+	defer span.End()
+
+	return s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		existing, err := qtx.GetBlogPost(ctx, int32(id))
+		if err != nil {
+			return WrapDBError(err, "blog post")
+		}
+
+		slug := req.Slug
+		if slug == "" {
+			slug = existing.Slug
+		}
+
+		state, err := resolvePublishState(req.Status, req.PublishedAt, &publishState{
+			status:      model.PublishStatus(existing.Status),
+			publishedAt: existing.PublishedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		contentFormat := resolveContentFormat(req.ContentFormat, existing.ContentFormat)
+
+		rows, err := qtx.UpdateBlogPost(ctx, repository.UpdateBlogPostParams{
+			ID:              int32(id),
+			Title:           req.Title,
+			Slug:            slug,
+			Description:     req.Description,
+			Content:         req.Content,
+			ImageUrl:        req.ImageURL,
+			Status:          string(state.status),
+			PublishedAt:     state.publishedAt,
+			ContentFormat:   contentFormat,
+			MetaTitle:       req.SEO.MetaTitle,
+			MetaDescription: req.SEO.MetaDescription,
+			CanonicalUrl:    req.SEO.CanonicalURL,
+			OgImageUrl:      req.SEO.OGImageURL,
+		})
+		if err != nil {
+			return WrapDBError(err, "blog post")
+		}
+		if rows == 0 {
+			return NewNotFoundError("blog post")
+		}
+
+		if err := recordSlugChange(ctx, qtx, resourceBlogPost, int32(id), existing.Slug, slug); err != nil {
+			return err
+		}
+
+		if err := clearBlogPostTaxonomy(ctx, qtx, int32(id)); err != nil {
+			return err
+		}
+		if err := setBlogPostTaxonomy(ctx, qtx, int32(id), req.CategoryIDs, req.Tags); err != nil {
+			return err
+		}
+
+		return recordRevision(ctx, qtx, resourceBlogPost, int32(id), revisionSnapshot{
+			title:         req.Title,
+			slug:          slug,
+			description:   req.Description,
+			content:       req.Content,
+			contentFormat: contentFormat,
+		}, authorID)
 	})
+}
+
+// ListRevisions retrieves the revisions of a blog post, newest first
+func (s *BlogPostService) ListRevisions(ctx context.Context, id int64, pagination model.Pagination) ([]model.ContentRevision, int64, error) {
+	ctx, span := tracer.Start(ctx, "BlogPostService.ListRevisions")
+	defer span.End()
+
+	if _, err := s.queries.GetBlogPost(ctx, int32(id)); err != nil {
+		return nil, 0, WrapDBError(err, "blog post")
+	}
+	return listRevisions(ctx, s.queries, resourceBlogPost, int32(id), pagination)
+}
+
+// GetRevision retrieves a single revision of a blog post
+func (s *BlogPostService) GetRevision(ctx context.Context, id, revisionID int64) (*model.ContentRevision, error) {
+	ctx, span := tracer.Start(ctx, "BlogPostService.GetRevision")
+	defer span.End()
+
+	revision, err := getRevision(ctx, s.queries, resourceBlogPost, int32(id), int32(revisionID))
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return ErrNotFound
+		return nil, err
+	}
+	result := toRevisionModel(revision)
+	return &result, nil
+}
+
+// DiffRevisions compares two revisions of a blog post
+func (s *BlogPostService) DiffRevisions(ctx context.Context, id, fromID, toID int64) (*model.RevisionDiff, error) {
+	ctx, span := tracer.Start(ctx, "BlogPostService.DiffRevisions")
+	defer span.End()
+
+	return diffRevisions(ctx, s.queries, resourceBlogPost, int32(id), int32(fromID), int32(toID))
+}
+
+// RestoreRevision makes an old revision the current version of a blog post. The
+// restore is itself stored as a new revision so it can be undone too.
+func (s *BlogPostService) RestoreRevision(ctx context.Context, id, revisionID, authorID int64) (*model.BlogPost, error) {
+	ctx, span := tracer.Start(ctx, "BlogPostService.RestoreRevision")
+	defer span.End()
+
+	err := s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		existing, err := qtx.GetBlogPost(ctx, int32(id))
+		if err != nil {
+			return WrapDBError(err, "blog post")
 		}
-		return err
+
+		revision, err := getRevision(ctx, qtx, resourceBlogPost, int32(id), int32(revisionID))
+		if err != nil {
+			return err
+		}
+
+		if _, err := qtx.UpdateBlogPost(ctx, repository.UpdateBlogPostParams{
+			ID:              existing.ID,
+			Title:           revision.Title,
+			Slug:            revision.Slug,
+			Description:     revision.Description,
+			Content:         revision.Content,
+			ImageUrl:        existing.ImageUrl,
+			Status:          existing.Status,
+			PublishedAt:     existing.PublishedAt,
+			ContentFormat:   revision.ContentFormat,
+			MetaTitle:       existing.MetaTitle,
+			MetaDescription: existing.MetaDescription,
+			CanonicalUrl:    existing.CanonicalUrl,
+			OgImageUrl:      existing.OgImageUrl,
+		}); err != nil {
+			return WrapDBError(err, "blog post")
+		}
+
+		if err := recordSlugChange(ctx, qtx, resourceBlogPost, existing.ID, existing.Slug, revision.Slug); err != nil {
+			return err
+		}
+
+		return recordRevision(ctx, qtx, resourceBlogPost, existing.ID, revisionSnapshot{
+			title:         revision.Title,
+			slug:          revision.Slug,
+			description:   revision.Description,
+			content:       revision.Content,
+			contentFormat: revision.ContentFormat,
+		}, authorID)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return s.GetByID(ctx, id)
 }
 
 func (s *BlogPostService) Delete(ctx context.Context, id int64) error {
-	err := s.queries.DeleteBlogPost(ctx, int32(id))
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return ErrNotFound
+	ctx, span := tracer.Start(ctx, "BlogPostService.Delete")
+	defer span.End()
+
+	return s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		rows, err := qtx.DeleteBlogPost(ctx, int32(id))
+		if err != nil {
+			return WrapDBError(err, "blog post")
+		}
+		if rows == 0 {
+			return NewNotFoundError("blog post")
+		}
+
+		if err := qtx.DeleteSlugHistoryByResource(ctx, repository.DeleteSlugHistoryByResourceParams{
+			ResourceType: resourceBlogPost,
+			ResourceID:   int32(id),
+		}); err != nil {
+			return err
 		}
-		return err
+
+		return qtx.DeleteContentRevisionsByResource(ctx, repository.DeleteContentRevisionsByResourceParams{
+			ResourceType: resourceBlogPost,
+			ResourceID:   int32(id),
+		})
+	})
+}
+
+// slugExists returns a check for blog post slugs used by posts other than excludeID
+func (s *BlogPostService) slugExists(excludeID int32) slugExistsFunc {
+	return func(ctx context.Context, slug string) (bool, error) {
+		return s.queries.BlogPostSlugExists(ctx, repository.BlogPostSlugExistsParams{
+			Slug: slug,
+			ID:   excludeID,
+		})
 	}
+}
+
+func toBlogPostModel(post repository.BlogPost) *model.BlogPost {
+	format := model.ContentFormat(post.ContentFormat)
+	contentHTML := utils.RenderContent(post.Content, format)
+	text := utils.PlainText(contentHTML)
+
+	return &model.BlogPost{
+		ID:            int64(post.ID),
+		Title:         post.Title,
+		Slug:          post.Slug,
+		Description:   post.Description,
+		Content:       post.Content,
+		ContentHTML:   contentHTML,
+		ContentFormat: format,
+		Excerpt:       utils.Excerpt(text, blogPostExcerptLength),
+		ReadingTime:   utils.ReadingTime(text),
+		ImageURL:      post.ImageUrl,
+		Status:        effectiveStatus(post.Status, post.PublishedAt),
+		PublishedAt:   fromPgTimestamp(post.PublishedAt),
+		AuthorID:      fromPgInt8(post.AuthorID),
+		Categories:    []model.BlogCategory{},
+		Tags:          []model.BlogTag{},
+		SEO:           toSEOModel(post.MetaTitle, post.MetaDescription, post.CanonicalUrl, post.OgImageUrl),
+		CreatedAt:     post.CreatedAt.Time,
+		UpdatedAt:     post.UpdatedAt.Time,
+	}
+}
+
+// withTaxonomy converts post to its model with categories and tags filled in
+func (s *BlogPostService) withTaxonomy(ctx context.Context, post repository.BlogPost) (*model.BlogPost, error) {
+	result := toBlogPostModel(post)
+	if err := loadBlogPostTaxonomy(ctx, s.queries, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
 
-	return nil
+// localized is withTaxonomy with the text translated to the request locale, for public views
+func (s *BlogPostService) localized(ctx context.Context, post repository.BlogPost) (*model.BlogPost, error) {
+	result, err := s.withTaxonomy(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	if err := localizeBlogPosts(ctx, s.queries, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func fromPgInt8(v pgtype.Int8) *int64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Int64
 }
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/repository"
+	"beef-db-be/internal/utils"
+)
+
+// maxBlogTaxonomySlugLength matches the blog_categories.slug and blog_tags.slug columns
+const maxBlogTaxonomySlugLength = 150
+
+// BlogTaxonomyService manages blog categories and tags
+type BlogTaxonomyService struct {
+	queries repository.Querier
+	tx      repository.TxRunner
+}
+
+func NewBlogTaxonomyService(queries repository.Querier, tx repository.TxRunner) *BlogTaxonomyService {
+	return &BlogTaxonomyService{
+		queries: queries,
+		tx:      tx,
+	}
+}
+
+func (s *BlogTaxonomyService) CreateCategory(ctx context.Context, req model.CreateBlogCategoryRequest) (*model.BlogCategory, error) {
+	ctx, span := tracer.Start(ctx, "BlogTaxonomyService.CreateCategory")
+	defer span.End()
+
+	slug, err := resolveSlug(ctx, req.Slug, req.Name, maxBlogTaxonomySlugLength, s.categorySlugExists(0))
+	if err != nil {
+		return nil, err
+	}
+
+	category, err := s.queries.CreateBlogCategory(ctx, repository.CreateBlogCategoryParams{
+		Name:        req.Name,
+		Slug:        slug,
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, WrapDBError(err, "blog category")
+	}
+
+	return toBlogCategoryModel(category), nil
+}
+
+// ListCategories retrieves all blog categories with their published post counts
+func (s *BlogTaxonomyService) ListCategories(ctx context.Context) ([]model.BlogCategoryDetail, error) {
+	ctx, span := tracer.Start(ctx, "BlogTaxonomyService.ListCategories")
+	defer span.End()
+
+	categories, err := s.queries.ListBlogCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.BlogCategoryDetail, len(categories))
+	for i, category := range categories {
+		result[i] = model.BlogCategoryDetail{
+			BlogCategory: model.BlogCategory{
+				ID:          int64(category.ID),
+				Name:        category.Name,
+				Slug:        category.Slug,
+				Description: category.Description,
+			},
+			PostCount: category.PostCount,
+			CreatedAt: category.CreatedAt.Time,
+		}
+	}
+	return result, nil
+}
+
+// UpdateCategory updates a blog category. An empty slug keeps the current one.
+func (s *BlogTaxonomyService) UpdateCategory(ctx context.Context, id int32, req model.UpdateBlogCategoryRequest) (*model.BlogCategory, error) {
+	ctx, span := tracer.Start(ctx, "BlogTaxonomyService.UpdateCategory")
+	defer span.End()
+
+	slug := req.Slug
+	err := s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		existing, err := qtx.GetBlogCategory(ctx, id)
+		if err != nil {
+			return WrapDBError(err, "blog category")
+		}
+		if req.Slug == "" {
+			slug = existing.Slug
+		}
+
+		rows, err := qtx.UpdateBlogCategory(ctx, repository.UpdateBlogCategoryParams{
+			ID:          id,
+			Name:        req.Name,
+			Slug:        slug,
+			Description: req.Description,
+		})
+		if err != nil {
+			return WrapDBError(err, "blog category")
+		}
+		if rows == 0 {
+			return NewNotFoundError("blog category")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.BlogCategory{
+		ID:          int64(id),
+		Name:        req.Name,
+		Slug:        slug,
+		Description: req.Description,
+	}, nil
+}
+
+func (s *BlogTaxonomyService) DeleteCategory(ctx context.Context, id int32) error {
+	ctx, span := tracer.Start(ctx, "BlogTaxonomyService.DeleteCategory")
+	defer span.End()
+
+	rows, err := s.queries.DeleteBlogCategory(ctx, id)
+	if err != nil {
+		return WrapDBError(err, "blog category")
+	}
+	if rows == 0 {
+		return NewNotFoundError("blog category")
+	}
+	return nil
+}
+
+// ListTags retrieves all blog tags with their published post counts, most used first
+func (s *BlogTaxonomyService) ListTags(ctx context.Context) ([]model.BlogTagDetail, error) {
+	ctx, span := tracer.Start(ctx, "BlogTaxonomyService.ListTags")
+	defer span.End()
+
+	tags, err := s.queries.ListBlogTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.BlogTagDetail, len(tags))
+	for i, tag := range tags {
+		result[i] = model.BlogTagDetail{
+			BlogTag: model.BlogTag{
+				ID:   int64(tag.ID),
+				Name: tag.Name,
+				Slug: tag.Slug,
+			},
+			PostCount: tag.PostCount,
+		}
+	}
+	return result, nil
+}
+
+func (s *BlogTaxonomyService) DeleteTag(ctx context.Context, id int32) error {
+	ctx, span := tracer.Start(ctx, "BlogTaxonomyService.DeleteTag")
+	defer span.End()
+
+	rows, err := s.queries.DeleteBlogTag(ctx, id)
+	if err != nil {
+		return WrapDBError(err, "blog tag")
+	}
+	if rows == 0 {
+		return NewNotFoundError("blog tag")
+	}
+	return nil
+}
+
+// categorySlugExists returns a check for blog category slugs used by categories other than excludeID
+func (s *BlogTaxonomyService) categorySlugExists(excludeID int32) slugExistsFunc {
+	return func(ctx context.Context, slug string) (bool, error) {
+		return s.queries.BlogCategorySlugExists(ctx, repository.BlogCategorySlugExistsParams{
+			Slug: slug,
+			ID:   excludeID,
+		})
+	}
+}
+
+// setBlogPostTaxonomy links a blog post to categories and tags. Tags are given by name
+// and created on first use; names that slugify to the same tag are stored once.
+func setBlogPostTaxonomy(ctx context.Context, q repository.Querier, postID int32, categoryIDs []int, tags []string) error {
+	for _, categoryID := range categoryIDs {
+		if err := q.AddBlogPostCategory(ctx, repository.AddBlogPostCategoryParams{
+			BlogPostID: postID,
+			CategoryID: int32(categoryID),
+		}); err != nil {
+			return WrapDBError(err, "blog post")
+		}
+	}
+
+	for _, name := range tags {
+		name = strings.TrimSpace(name)
+		slug := utils.TruncateSlug(utils.Slugify(name), maxBlogTaxonomySlugLength)
+		if slug == "" {
+			return NewValidationError("tags", "Tag \""+name+"\" must contain letters or digits")
+		}
+
+		tagID, err := q.UpsertBlogTag(ctx, repository.UpsertBlogTagParams{
+			Name: name,
+			Slug: slug,
+		})
+		if err != nil {
+			return WrapDBError(err, "blog tag")
+		}
+		if err := q.AddBlogPostTag(ctx, repository.AddBlogPostTagParams{
+			BlogPostID: postID,
+			TagID:      tagID,
+		}); err != nil {
+			return WrapDBError(err, "blog post")
+		}
+	}
+	return nil
+}
+
+// clearBlogPostTaxonomy removes all category and tag links of a blog post
+func clearBlogPostTaxonomy(ctx context.Context, q repository.Querier, postID int32) error {
+	if err := q.DeleteBlogPostCategories(ctx, postID); err != nil {
+		return err
+	}
+	return q.DeleteBlogPostTags(ctx, postID)
+}
+
+// loadBlogPostTaxonomy fills in the categories and tags of posts with one query each
+func loadBlogPostTaxonomy(ctx context.Context, q repository.Querier, posts ...*model.BlogPost) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]int32, len(posts))
+	byID := make(map[int64]*model.BlogPost, len(posts))
+	for i, post := range posts {
+		ids[i] = int32(post.ID)
+		byID[post.ID] = post
+	}
+
+	categories, err := q.ListBlogPostCategories(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, category := range categories {
+		post := byID[int64(category.BlogPostID)]
+		post.Categories = append(post.Categories, model.BlogCategory{
+			ID:          int64(category.ID),
+			Name:        category.Name,
+			Slug:        category.Slug,
+			Description: category.Description,
+		})
+	}
+
+	tags, err := q.ListBlogPostTags(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		post := byID[int64(tag.BlogPostID)]
+		post.Tags = append(post.Tags, model.BlogTag{
+			ID:   int64(tag.ID),
+			Name: tag.Name,
+			Slug: tag.Slug,
+		})
+	}
+	return nil
+}
+
+func toBlogCategoryModel(category repository.BlogCategory) *model.BlogCategory {
+	return &model.BlogCategory{
+		ID:          int64(category.ID),
+		Name:        category.Name,
+		Slug:        category.Slug,
+		Description: category.Description,
+	}
+}
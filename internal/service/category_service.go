@@ -2,126 +2,199 @@ package service
 
 import (
 	"context"
-	"errors"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 
 	"beef-db-be/internal/model"
 	"beef-db-be/internal/repository"
 )
 
+// maxCategorySlugLength matches the categories.slug column
+const maxCategorySlugLength = 150
+
 type CategoryService struct {
-	queries *repository.Queries
-	pool    *pgxpool.Pool
+	queries repository.Querier
+	tx      repository.TxRunner
 }
 
-func NewCategoryService(pool *pgxpool.Pool) *CategoryService {
+func NewCategoryService(queries repository.Querier, tx repository.TxRunner) *CategoryService {
 	return &CategoryService{
-		queries: repository.New(pool),
-		pool:    pool,
+		queries: queries,
+		tx:      tx,
 	}
 }
 
 func (s *CategoryService) CreateCategory(ctx context.Context, req model.CreateCategoryRequest) (*model.Category, error) {
-	result, err := s.queries.CreateCategory(ctx, repository.CreateCategoryParams{
-		Name:        req.Name,
-		Slug:        req.Slug,
-		Description: pgtype.Text{String: req.Description, Valid: req.Description != ""},
-		ImageUrl:    pgtype.Text{String: req.ImageURL, Valid: req.ImageURL != ""},
+	ctx, span := tracer.Start(ctx, "CategoryService.CreateCategory")
+	defer span.End()
+
+	slug, err := resolveSlug(ctx, req.Slug, req.Name, maxCategorySlugLength, s.slugExists(0))
+	if err != nil {
+		return nil, err
+	}
+
+	var category *model.Category
+	err = s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		id, err := qtx.CreateCategory(ctx, repository.CreateCategoryParams{
+			Name:            req.Name,
+			Slug:            slug,
+			Description:     pgtype.Text{String: req.Description, Valid: req.Description != ""},
+			ImageUrl:        pgtype.Text{String: req.ImageURL, Valid: req.ImageURL != ""},
+			MetaTitle:       req.SEO.MetaTitle,
+			MetaDescription: req.SEO.MetaDescription,
+			CanonicalUrl:    req.SEO.CanonicalURL,
+			OgImageUrl:      req.SEO.OGImageURL,
+		})
+		if err != nil {
+			return WrapDBError(err, "category")
+		}
+
+		category, err = getCategory(ctx, qtx, int(id))
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return s.GetCategory(ctx, int(result))
+	return category, nil
 }
 
 func (s *CategoryService) GetCategory(ctx context.Context, id int) (*model.Category, error) {
-	category, err := s.queries.GetCategory(ctx, int32(id))
+	ctx, span := tracer.Start(ctx, "CategoryService.GetCategory")
+	defer span.End()
+
+	return getCategory(ctx, s.queries, id)
+}
+
+// getCategory loads a localized category through q
+func getCategory(ctx context.Context, q repository.Querier, id int) (*model.Category, error) {
+	category, err := q.GetCategory(ctx, int32(id))
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, errors.New("category not found")
-		}
-		return nil, err
+		return nil, WrapDBError(err, "category")
 	}
 
-	return &model.Category{
-		ID:          int(category.ID),
-		Name:        category.Name,
-		Slug:        category.Slug,
-		Description: category.Description.String,
-		ImageURL:    category.ImageUrl.String,
-		CreatedAt:   category.CreatedAt.Time,
-	}, nil
+	result := toCategoryModel(category)
+	if err := localizeCategories(ctx, q, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
 }
 
 func (s *CategoryService) GetCategoryBySlug(ctx context.Context, slug string) (*model.Category, error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.GetCategoryBySlug")
+	defer span.End()
+
 	category, err := s.queries.GetCategoryBySlug(ctx, slug)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, errors.New("category not found")
-		}
-		return nil, err
+		return nil, WrapDBError(err, "category")
 	}
 
-	return &model.Category{
-		ID:          int(category.ID),
-		Name:        category.Name,
-		Slug:        category.Slug,
-		Description: category.Description.String,
-		ImageURL:    category.ImageUrl.String,
-		CreatedAt:   category.CreatedAt.Time,
-	}, nil
+	result := toCategoryModel(category)
+	if err := localizeCategories(ctx, s.queries, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
 }
 
 func (s *CategoryService) ListCategories(ctx context.Context) ([]model.Category, error) {
+	ctx, span := tracer.Start(ctx, "CategoryService.ListCategories")
+	defer span.End()
+
 	categories, err := s.queries.ListCategories(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	result := make([]model.Category, len(categories))
+	refs := make([]*model.Category, len(categories))
 	for i, category := range categories {
-		result[i] = model.Category{
-			ID:          int(category.ID),
-			Name:        category.Name,
-			Slug:        category.Slug,
-			Description: category.Description.String,
-			ImageURL:    category.ImageUrl.String,
-			CreatedAt:   category.CreatedAt.Time,
-		}
+		result[i] = toCategoryModel(category)
+		refs[i] = &result[i]
+	}
+	if err := localizeCategories(ctx, s.queries, refs...); err != nil {
+		return nil, err
 	}
 
 	return result, nil
 }
 
+// UpdateCategory updates a category. An empty slug keeps the current one.
 func (s *CategoryService) UpdateCategory(ctx context.Context, id int, req model.UpdateCategoryRequest) (*model.Category, error) {
-	err := s.queries.UpdateCategory(ctx, repository.UpdateCategoryParams{
-		ID:          int32(id),
-		Name:        req.Name,
-		Slug:        req.Slug,
-		Description: pgtype.Text{String: req.Description, Valid: req.Description != ""},
-		ImageUrl:    pgtype.Text{String: req.ImageURL, Valid: req.ImageURL != ""},
+	ctx, span := tracer.Start(ctx, "CategoryService.UpdateCategory")
+	defer span.End()
+
+	var category *model.Category
+	err := s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		slug := req.Slug
+		if slug == "" {
+			existing, err := qtx.GetCategory(ctx, int32(id))
+			if err != nil {
+				return WrapDBError(err, "category")
+			}
+			slug = existing.Slug
+		}
+
+		rows, err := qtx.UpdateCategory(ctx, repository.UpdateCategoryParams{
+			ID:              int32(id),
+			Name:            req.Name,
+			Slug:            slug,
+			Description:     pgtype.Text{String: req.Description, Valid: req.Description != ""},
+			ImageUrl:        pgtype.Text{String: req.ImageURL, Valid: req.ImageURL != ""},
+			MetaTitle:       req.SEO.MetaTitle,
+			MetaDescription: req.SEO.MetaDescription,
+			CanonicalUrl:    req.SEO.CanonicalURL,
+			OgImageUrl:      req.SEO.OGImageURL,
+		})
+		if err != nil {
+			return WrapDBError(err, "category")
+		}
+		if rows == 0 {
+			return NewNotFoundError("category")
+		}
+
+		category, err = getCategory(ctx, qtx, id)
+		return err
 	})
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, errors.New("category not found")
-		}
 		return nil, err
 	}
 
-	return s.GetCategory(ctx, id)
+	return category, nil
 }
 
 func (s *CategoryService) DeleteCategory(ctx context.Context, id int) error {
-	err := s.queries.DeleteCategory(ctx, int32(id))
+	ctx, span := tracer.Start(ctx, "CategoryService.DeleteCategory")
+	defer span.End()
+
+	rows, err := s.queries.DeleteCategory(ctx, int32(id))
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return errors.New("category not found")
-		}
-		return err
+		return WrapDBError(err, "category")
+	}
+	if rows == 0 {
+		return NewNotFoundError("category")
 	}
 	return nil
 }
+
+// slugExists returns a check for category slugs used by categories other than excludeID
+func (s *CategoryService) slugExists(excludeID int32) slugExistsFunc {
+	return func(ctx context.Context, slug string) (bool, error) {
+		return s.queries.CategorySlugExists(ctx, repository.CategorySlugExistsParams{
+			Slug: slug,
+			ID:   excludeID,
+		})
+	}
+}
+
+func toCategoryModel(category repository.Category) model.Category {
+	return model.Category{
+		ID:          int(category.ID),
+		Name:        category.Name,
+		Slug:        category.Slug,
+		Description: category.Description.String,
+		ImageURL:    category.ImageUrl.String,
+		SEO:         toSEOModel(category.MetaTitle, category.MetaDescription, category.CanonicalUrl, category.OgImageUrl),
+		CreatedAt:   category.CreatedAt.Time,
+		UpdatedAt:   category.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,18 @@
+package service
+
+import "beef-db-be/internal/model"
+
+// blogPostExcerptLength is the maximum length of generated blog post excerpts
+const blogPostExcerptLength = 200
+
+// resolveContentFormat returns the content format to store. An empty request keeps
+// current, and content without a format on record is treated as HTML.
+func resolveContentFormat(requested model.ContentFormat, current string) string {
+	if requested != "" {
+		return string(requested)
+	}
+	if current != "" {
+		return current
+	}
+	return string(model.ContentFormatHTML)
+}
@@ -0,0 +1,548 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/repository"
+	"beef-db-be/internal/utils"
+)
+
+type CouponService struct {
+	queries repository.Querier
+	tx      repository.TxRunner
+}
+
+func NewCouponService(queries repository.Querier, tx repository.TxRunner) *CouponService {
+	return &CouponService{
+		queries: queries,
+		tx:      tx,
+	}
+}
+
+// CreateCoupon creates a coupon together with its category and product restrictions
+func (s *CouponService) CreateCoupon(ctx context.Context, req model.CreateCouponRequest) (*model.Coupon, error) {
+	ctx, span := tracer.Start(ctx, "CouponService.CreateCoupon")
+	defer span.End()
+
+	if err := checkCouponRequest(req); err != nil {
+		return nil, err
+	}
+
+	var id int32
+	err := s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		couponID, err := qtx.CreateCoupon(ctx, repository.CreateCouponParams{
+			Code:              normalizeCouponCode(req.Code),
+			Description:       req.Description,
+			DiscountType:      string(req.DiscountType),
+			DiscountValue:     req.DiscountValue,
+			MaxDiscount:       req.MaxDiscount,
+			MinOrderValue:     req.MinOrderValue,
+			UsageLimit:        int32(req.UsageLimit),
+			UsageLimitPerUser: int32(req.UsageLimitPerUser),
+			StartsAt:          toPgTimestamp(req.StartsAt),
+			ExpiresAt:         toPgTimestamp(req.ExpiresAt),
+			IsActive:          req.IsActive == nil || *req.IsActive,
+		})
+		if err != nil {
+			return WrapDBError(err, "coupon")
+		}
+
+		id = couponID
+		return setCouponRestrictions(ctx, qtx, id, req.CategoryIDs, req.ProductIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetCoupon(ctx, int(id))
+}
+
+// GetCoupon retrieves a coupon by ID
+func (s *CouponService) GetCoupon(ctx context.Context, id int) (*model.Coupon, error) {
+	ctx, span := tracer.Start(ctx, "CouponService.GetCoupon")
+	defer span.End()
+
+	coupon, err := s.queries.GetCoupon(ctx, int32(id))
+	if err != nil {
+		return nil, WrapDBError(err, "coupon")
+	}
+
+	return s.toModel(ctx, coupon)
+}
+
+// ListCoupons retrieves a paginated list of coupons
+func (s *CouponService) ListCoupons(ctx context.Context, pagination model.Pagination) ([]model.Coupon, int64, error) {
+	ctx, span := tracer.Start(ctx, "CouponService.ListCoupons")
+	defer span.End()
+
+	totalCount, err := s.queries.GetTotalCoupons(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get total count: %v", err)
+	}
+
+	coupons, err := s.queries.ListCoupons(ctx, repository.ListCouponsParams{
+		Limit:  int32(pagination.GetLimit()),
+		Offset: int32(pagination.GetOffset()),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]model.Coupon, len(coupons))
+	for i, c := range coupons {
+		coupon, err := s.toModel(ctx, c)
+		if err != nil {
+			return nil, 0, err
+		}
+		result[i] = *coupon
+	}
+
+	return result, totalCount, nil
+}
+
+// UpdateCoupon replaces a coupon and its restrictions
+func (s *CouponService) UpdateCoupon(ctx context.Context, id int, req model.UpdateCouponRequest) (*model.Coupon, error) {
+	ctx, span := tracer.Start(ctx, "CouponService.UpdateCoupon")
+	defer span.End()
+
+	if err := checkCouponRequest(model.CreateCouponRequest(req)); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.queries.GetCoupon(ctx, int32(id))
+	if err != nil {
+		return nil, WrapDBError(err, "coupon")
+	}
+
+	isActive := existing.IsActive
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	err = s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		rows, err := qtx.UpdateCoupon(ctx, repository.UpdateCouponParams{
+			ID:                int32(id),
+			Code:              normalizeCouponCode(req.Code),
+			Description:       req.Description,
+			DiscountType:      string(req.DiscountType),
+			DiscountValue:     req.DiscountValue,
+			MaxDiscount:       req.MaxDiscount,
+			MinOrderValue:     req.MinOrderValue,
+			UsageLimit:        int32(req.UsageLimit),
+			UsageLimitPerUser: int32(req.UsageLimitPerUser),
+			StartsAt:          toPgTimestamp(req.StartsAt),
+			ExpiresAt:         toPgTimestamp(req.ExpiresAt),
+			IsActive:          isActive,
+		})
+		if err != nil {
+			return WrapDBError(err, "coupon")
+		}
+		if rows == 0 {
+			return NewNotFoundError("coupon")
+		}
+
+		if err := qtx.DeleteCouponCategories(ctx, int32(id)); err != nil {
+			return err
+		}
+		if err := qtx.DeleteCouponProducts(ctx, int32(id)); err != nil {
+			return err
+		}
+		return setCouponRestrictions(ctx, qtx, int32(id), req.CategoryIDs, req.ProductIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetCoupon(ctx, id)
+}
+
+// DeleteCoupon deletes a coupon
+func (s *CouponService) DeleteCoupon(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "CouponService.DeleteCoupon")
+	defer span.End()
+
+	rows, err := s.queries.DeleteCoupon(ctx, int32(id))
+	if err != nil {
+		return WrapDBError(err, "coupon")
+	}
+	if rows == 0 {
+		return NewNotFoundError("coupon")
+	}
+	return nil
+}
+
+// ValidateCoupon checks a coupon code against a cart and calculates the discount.
+// userID is zero for anonymous visitors.
+func (s *CouponService) ValidateCoupon(ctx context.Context, userID int64, req model.ValidateCouponRequest) (*model.ValidateCouponResponse, error) {
+	ctx, span := tracer.Start(ctx, "CouponService.ValidateCoupon")
+	defer span.End()
+
+	resp, _, err := evaluateCoupon(ctx, s.queries, userID, req, false)
+	return resp, err
+}
+
+// RedeemCoupon records the use of a coupon for an order and returns the discount it
+// grants. The coupon is locked while its usage limits are checked, so concurrent
+// redemptions cannot both take its last use. A coupon that does not apply to the cart
+// is a conflict error carrying the reason.
+func (s *CouponService) RedeemCoupon(ctx context.Context, req model.RedeemCouponRequest) (*model.CouponRedemption, error) {
+	ctx, span := tracer.Start(ctx, "CouponService.RedeemCoupon")
+	defer span.End()
+
+	var redemption *model.CouponRedemption
+	err := s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		resp, couponID, err := evaluateCoupon(ctx, qtx, req.UserID, req.ValidateCouponRequest, true)
+		if err != nil {
+			return err
+		}
+		if !resp.Valid {
+			return NewConflictError("coupon_not_applicable", resp.Reason)
+		}
+
+		id, err := qtx.CreateCouponRedemption(ctx, repository.CreateCouponRedemptionParams{
+			CouponID:       couponID,
+			UserID:         pgtype.Int8{Int64: req.UserID, Valid: req.UserID != 0},
+			OrderReference: req.OrderReference,
+			DiscountAmount: resp.Discount,
+		})
+		if err != nil {
+			return WrapDBError(err, "coupon redemption")
+		}
+
+		redemption = &model.CouponRedemption{
+			ID:                     int(id),
+			CouponID:               int(couponID),
+			UserID:                 req.UserID,
+			OrderReference:         req.OrderReference,
+			ValidateCouponResponse: *resp,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return redemption, nil
+}
+
+// evaluateCoupon prices the cart and applies the coupon to it through q. It returns
+// the breakdown and the coupon ID, zero when the code does not exist. lock locks the
+// coupon row until the transaction of q ends.
+func evaluateCoupon(ctx context.Context, q repository.Querier, userID int64, req model.ValidateCouponRequest, lock bool) (*model.ValidateCouponResponse, int32, error) {
+	code := normalizeCouponCode(req.Code)
+	resp := &model.ValidateCouponResponse{
+		Code:  code,
+		Items: []model.CouponLineItem{},
+	}
+
+	// Price the cart first so the breakdown is returned even for invalid coupons
+	quantities := make(map[int32]int)
+	ids := make([]int32, 0, len(req.Items))
+	for _, item := range req.Items {
+		if item.Quantity <= 0 {
+			return nil, 0, NewValidationError("items", "Quantity must be greater than zero")
+		}
+		id := int32(item.ProductID)
+		if _, ok := quantities[id]; !ok {
+			ids = append(ids, id)
+		}
+		quantities[id] += item.Quantity
+	}
+
+	products, err := q.ListProductsByIDs(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	// Amounts are rounded to the decimals of the base currency, like catalog prices
+	base, err := baseCurrency(ctx, q)
+	if err != nil {
+		return nil, 0, err
+	}
+	productsByID := make(map[int32]repository.ListProductsByIDsRow, len(products))
+	for _, p := range products {
+		productsByID[p.ID] = p
+	}
+
+	for _, id := range ids {
+		p, ok := productsByID[id]
+		if !ok {
+			return nil, 0, NewValidationError("items", fmt.Sprintf("Product %d does not exist", id))
+		}
+		unitPrice := p.Price
+		if p.PriceSale.IsPositive() && p.PriceSale.LessThan(p.Price) {
+			unitPrice = p.PriceSale
+		}
+		unitPrice = utils.RoundAmount(unitPrice, base)
+		lineTotal := unitPrice.Mul(decimal.NewFromInt(int64(quantities[id])))
+		resp.Items = append(resp.Items, model.CouponLineItem{
+			ProductID: int(id),
+			Name:      p.Name,
+			Quantity:  quantities[id],
+			UnitPrice: unitPrice,
+			LineTotal: lineTotal,
+		})
+		resp.Subtotal = resp.Subtotal.Add(lineTotal)
+	}
+	resp.Total = resp.Subtotal
+
+	getCoupon := q.GetCouponByCode
+	if lock {
+		getCoupon = q.GetCouponByCodeForUpdate
+	}
+	coupon, err := getCoupon(ctx, code)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			resp.Reason = "Coupon code is not valid"
+			return resp, 0, nil
+		}
+		return nil, 0, err
+	}
+	resp.DiscountType = model.DiscountType(coupon.DiscountType)
+	resp.DiscountValue = coupon.DiscountValue
+
+	if reason, err := checkCouponUsable(ctx, q, coupon, userID, resp.Subtotal); err != nil {
+		return nil, 0, err
+	} else if reason != "" {
+		resp.Reason = reason
+		return resp, coupon.ID, nil
+	}
+
+	categoryIDs, err := q.ListCouponCategoryIDs(ctx, coupon.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+	productIDs, err := q.ListCouponProductIDs(ctx, coupon.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+	restricted := len(categoryIDs) > 0 || len(productIDs) > 0
+	allowedCategories := toSet(categoryIDs)
+	allowedProducts := toSet(productIDs)
+
+	var eligibleLines []int
+	for i := range resp.Items {
+		item := &resp.Items[i]
+		p := productsByID[int32(item.ProductID)]
+		item.Eligible = !restricted || allowedProducts[p.ID] || allowedCategories[p.CategoryID]
+		if item.Eligible {
+			resp.EligibleSubtotal = resp.EligibleSubtotal.Add(item.LineTotal)
+			eligibleLines = append(eligibleLines, i)
+		}
+	}
+
+	if len(eligibleLines) == 0 {
+		resp.Reason = "Coupon does not apply to any item in the cart"
+		return resp, coupon.ID, nil
+	}
+
+	discount := calculateDiscount(coupon, resp.EligibleSubtotal, base)
+	allocateDiscount(resp.Items, eligibleLines, resp.EligibleSubtotal, discount, base)
+
+	resp.Valid = true
+	resp.Discount = discount
+	resp.Total = resp.Subtotal.Sub(discount)
+	return resp, coupon.ID, nil
+}
+
+// checkCouponUsable returns a human readable reason when the coupon cannot be applied
+func checkCouponUsable(ctx context.Context, q repository.Querier, coupon repository.Coupon, userID int64, subtotal decimal.Decimal) (string, error) {
+	now := time.Now()
+
+	if !coupon.IsActive {
+		return "Coupon is not active", nil
+	}
+	if coupon.StartsAt.Valid && now.Before(coupon.StartsAt.Time) {
+		return "Coupon is not yet valid", nil
+	}
+	if coupon.ExpiresAt.Valid && now.After(coupon.ExpiresAt.Time) {
+		return "Coupon has expired", nil
+	}
+	if subtotal.LessThan(coupon.MinOrderValue) {
+		return fmt.Sprintf("Order value must be at least %s", coupon.MinOrderValue.StringFixed(2)), nil
+	}
+
+	if coupon.UsageLimit > 0 {
+		used, err := q.CountCouponRedemptions(ctx, coupon.ID)
+		if err != nil {
+			return "", err
+		}
+		if used >= int64(coupon.UsageLimit) {
+			return "Coupon usage limit has been reached", nil
+		}
+	}
+
+	if coupon.UsageLimitPerUser > 0 {
+		if userID == 0 {
+			return "You must be logged in to use this coupon", nil
+		}
+		used, err := q.CountCouponRedemptionsByUser(ctx, repository.CountCouponRedemptionsByUserParams{
+			CouponID: coupon.ID,
+			UserID:   pgtype.Int8{Int64: userID, Valid: true},
+		})
+		if err != nil {
+			return "", err
+		}
+		if used >= int64(coupon.UsageLimitPerUser) {
+			return "You have already used this coupon the maximum number of times", nil
+		}
+	}
+
+	return "", nil
+}
+
+func (s *CouponService) toModel(ctx context.Context, c repository.Coupon) (*model.Coupon, error) {
+	categoryIDs, err := s.queries.ListCouponCategoryIDs(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	productIDs, err := s.queries.ListCouponProductIDs(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	used, err := s.queries.CountCouponRedemptions(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Coupon{
+		ID:                int(c.ID),
+		Code:              c.Code,
+		Description:       c.Description,
+		DiscountType:      model.DiscountType(c.DiscountType),
+		DiscountValue:     c.DiscountValue,
+		MaxDiscount:       c.MaxDiscount,
+		MinOrderValue:     c.MinOrderValue,
+		UsageLimit:        int(c.UsageLimit),
+		UsageLimitPerUser: int(c.UsageLimitPerUser),
+		TimesUsed:         used,
+		StartsAt:          fromPgTimestamp(c.StartsAt),
+		ExpiresAt:         fromPgTimestamp(c.ExpiresAt),
+		IsActive:          c.IsActive,
+		CategoryIDs:       toIntSlice(categoryIDs),
+		ProductIDs:        toIntSlice(productIDs),
+		CreatedAt:         c.CreatedAt.Time,
+		UpdatedAt:         c.UpdatedAt.Time,
+	}, nil
+}
+
+func checkCouponRequest(req model.CreateCouponRequest) error {
+	if strings.TrimSpace(req.Code) == "" {
+		return NewValidationError("code", "Code is required")
+	}
+	switch req.DiscountType {
+	case model.DiscountTypePercentage:
+		if !req.DiscountValue.IsPositive() || req.DiscountValue.GreaterThan(decimal.NewFromInt(100)) {
+			return NewValidationError("discount_value", "Percentage discount must be between 0 and 100")
+		}
+	case model.DiscountTypeFixed:
+		if !req.DiscountValue.IsPositive() {
+			return NewValidationError("discount_value", "Fixed discount must be greater than zero")
+		}
+	default:
+		return NewValidationError("discount_type", "Must be one of: percentage, fixed")
+	}
+	if req.StartsAt != nil && req.ExpiresAt != nil && !req.ExpiresAt.After(*req.StartsAt) {
+		return NewValidationError("expires_at", "Must be after starts_at")
+	}
+	return nil
+}
+
+func setCouponRestrictions(ctx context.Context, q repository.Querier, couponID int32, categoryIDs, productIDs []int) error {
+	for _, categoryID := range categoryIDs {
+		if err := q.AddCouponCategory(ctx, repository.AddCouponCategoryParams{
+			CouponID:   couponID,
+			CategoryID: int32(categoryID),
+		}); err != nil {
+			return WrapDBError(err, "coupon")
+		}
+	}
+	for _, productID := range productIDs {
+		if err := q.AddCouponProduct(ctx, repository.AddCouponProductParams{
+			CouponID:  couponID,
+			ProductID: int32(productID),
+		}); err != nil {
+			return WrapDBError(err, "coupon")
+		}
+	}
+	return nil
+}
+
+// calculateDiscount returns the discount for the eligible subtotal, never exceeding it,
+// rounded to the decimals of currency
+func calculateDiscount(coupon repository.Coupon, eligibleSubtotal decimal.Decimal, currency string) decimal.Decimal {
+	var discount decimal.Decimal
+	switch model.DiscountType(coupon.DiscountType) {
+	case model.DiscountTypePercentage:
+		discount = eligibleSubtotal.Mul(coupon.DiscountValue).Div(decimal.NewFromInt(100))
+	case model.DiscountTypeFixed:
+		discount = coupon.DiscountValue
+	}
+	discount = utils.RoundAmount(discount, currency)
+	if maxDiscount := utils.RoundAmount(coupon.MaxDiscount, currency); maxDiscount.IsPositive() && discount.GreaterThan(maxDiscount) {
+		discount = maxDiscount
+	}
+	if discount.GreaterThan(eligibleSubtotal) {
+		discount = eligibleSubtotal
+	}
+	return discount
+}
+
+// allocateDiscount spreads the discount over the eligible lines proportionally to
+// their totals, assigning any rounding remainder to the last eligible line
+func allocateDiscount(items []model.CouponLineItem, eligibleLines []int, eligibleSubtotal, discount decimal.Decimal, currency string) {
+	remaining := discount
+	for n, idx := range eligibleLines {
+		if n == len(eligibleLines)-1 {
+			items[idx].Discount = remaining
+			return
+		}
+		share := utils.RoundAmount(discount.Mul(items[idx].LineTotal).Div(eligibleSubtotal), currency)
+		items[idx].Discount = share
+		remaining = remaining.Sub(share)
+	}
+}
+
+func normalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// toPgTimestamp encodes t for a TIMESTAMP column. The columns hold UTC, since pgx
+// stores the wall clock of a time and drops its offset.
+func toPgTimestamp(t *time.Time) pgtype.Timestamp {
+	if t == nil {
+		return pgtype.Timestamp{}
+	}
+	return pgtype.Timestamp{Time: t.UTC(), Valid: true}
+}
+
+func fromPgTimestamp(t pgtype.Timestamp) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func toSet(ids []int32) map[int32]bool {
+	set := make(map[int32]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+func toIntSlice(ids []int32) []int {
+	result := make([]int, len(ids))
+	for i, id := range ids {
+		result[i] = int(id)
+	}
+	return result
+}
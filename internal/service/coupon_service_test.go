@@ -0,0 +1,76 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/repository"
+)
+
+func TestCalculateDiscount(t *testing.T) {
+	d := decimal.RequireFromString
+	tests := []struct {
+		name     string
+		coupon   repository.Coupon
+		subtotal string
+		currency string
+		want     string
+	}{
+		{
+			name:     "percentage in whole dong",
+			coupon:   repository.Coupon{DiscountType: string(model.DiscountTypePercentage), DiscountValue: d("15")},
+			subtotal: "333333", currency: "VND", want: "50000",
+		},
+		{
+			name:     "percentage in cents",
+			coupon:   repository.Coupon{DiscountType: string(model.DiscountTypePercentage), DiscountValue: d("15")},
+			subtotal: "33.33", currency: "USD", want: "5",
+		},
+		{
+			name:     "fixed amount rounded to the currency",
+			coupon:   repository.Coupon{DiscountType: string(model.DiscountTypeFixed), DiscountValue: d("20000.50")},
+			subtotal: "100000", currency: "VND", want: "20001",
+		},
+		{
+			name:     "capped by the max discount",
+			coupon:   repository.Coupon{DiscountType: string(model.DiscountTypePercentage), DiscountValue: d("50"), MaxDiscount: d("30000")},
+			subtotal: "100000", currency: "VND", want: "30000",
+		},
+		{
+			name:     "never more than the subtotal",
+			coupon:   repository.Coupon{DiscountType: string(model.DiscountTypeFixed), DiscountValue: d("50000")},
+			subtotal: "20000", currency: "VND", want: "20000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateDiscount(tt.coupon, d(tt.subtotal), tt.currency)
+			if !got.Equal(d(tt.want)) {
+				t.Errorf("calculateDiscount = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllocateDiscountInWholeDong(t *testing.T) {
+	d := decimal.RequireFromString
+	items := []model.CouponLineItem{
+		{LineTotal: d("100000")},
+		{LineTotal: d("100000")},
+		{LineTotal: d("100000")},
+	}
+	allocateDiscount(items, []int{0, 1, 2}, d("300000"), d("50000"), "VND")
+
+	total := decimal.Zero
+	for i, item := range items {
+		if !item.Discount.Equal(item.Discount.Round(0)) {
+			t.Errorf("items[%d].Discount = %s, want whole dong", i, item.Discount)
+		}
+		total = total.Add(item.Discount)
+	}
+	if !total.Equal(d("50000")) {
+		t.Errorf("discounts add up to %s, want 50000", total)
+	}
+}
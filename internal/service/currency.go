@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/repository"
+	"beef-db-be/internal/utils"
+)
+
+// baseCurrencySetting is the website setting naming the currency prices are stored in
+const baseCurrencySetting = "base_currency"
+
+// CurrencyService manages exchange rates against the base currency. Product prices are
+// converted by the other services through priceProducts.
+type CurrencyService struct {
+	queries repository.Querier
+	tx      repository.TxRunner
+}
+
+func NewCurrencyService(queries repository.Querier, tx repository.TxRunner) *CurrencyService {
+	return &CurrencyService{
+		queries: queries,
+		tx:      tx,
+	}
+}
+
+// ListCurrentRates lists the rate currently in effect for each currency, ordered by currency
+func (s *CurrencyService) ListCurrentRates(ctx context.Context) (*model.ExchangeRatesResponse, error) {
+	ctx, span := tracer.Start(ctx, "CurrencyService.ListCurrentRates")
+	defer span.End()
+
+	base, err := baseCurrency(ctx, s.queries)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := s.queries.ListCurrentExchangeRates(ctx)
+	if err != nil {
+		return nil, WrapDBError(err, "exchange rate")
+	}
+
+	result := &model.ExchangeRatesResponse{BaseCurrency: base, Rates: []model.ExchangeRate{}}
+	for _, rate := range rates {
+		if rate.Currency == base {
+			continue
+		}
+		result.Rates = append(result.Rates, toExchangeRateModel(rate))
+	}
+	return result, nil
+}
+
+// ListRateHistory lists every rate of a currency, newest first, including scheduled ones
+func (s *CurrencyService) ListRateHistory(ctx context.Context, currency string, pagination model.Pagination) ([]model.ExchangeRate, int64, error) {
+	ctx, span := tracer.Start(ctx, "CurrencyService.ListRateHistory")
+	defer span.End()
+
+	currency = utils.NormalizeCurrency(currency)
+	if !utils.IsCurrencyCode(currency) {
+		return nil, 0, NewValidationError("currency", "Must be a 3 letter currency code")
+	}
+
+	total, err := s.queries.GetTotalExchangeRateHistory(ctx, currency)
+	if err != nil {
+		return nil, 0, WrapDBError(err, "exchange rate")
+	}
+
+	rates, err := s.queries.ListExchangeRateHistory(ctx, repository.ListExchangeRateHistoryParams{
+		Currency: currency,
+		Limit:    int32(pagination.GetLimit()),
+		Offset:   int32(pagination.GetOffset()),
+	})
+	if err != nil {
+		return nil, 0, WrapDBError(err, "exchange rate")
+	}
+
+	result := make([]model.ExchangeRate, len(rates))
+	for i, rate := range rates {
+		result[i] = toExchangeRateModel(rate)
+	}
+	return result, total, nil
+}
+
+// CreateRate records a new rate for a currency. Earlier rates are kept as history.
+func (s *CurrencyService) CreateRate(ctx context.Context, userID int64, req model.CreateExchangeRateRequest) (*model.ExchangeRate, error) {
+	ctx, span := tracer.Start(ctx, "CurrencyService.CreateRate")
+	defer span.End()
+
+	currency := utils.NormalizeCurrency(req.Currency)
+	if !utils.IsCurrencyCode(currency) {
+		return nil, NewValidationError("currency", "Must be a 3 letter currency code")
+	}
+
+	effectiveAt := time.Now()
+	if req.EffectiveAt != nil {
+		effectiveAt = *req.EffectiveAt
+	}
+
+	createdBy := pgtype.Int8{}
+	if userID != 0 {
+		createdBy = pgtype.Int8{Int64: userID, Valid: true}
+	}
+
+	var rate repository.ExchangeRate
+	err := s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		base, err := baseCurrency(ctx, qtx)
+		if err != nil {
+			return err
+		}
+		if currency == base {
+			return NewValidationError("currency", "The base currency does not need an exchange rate")
+		}
+
+		rate, err = qtx.CreateExchangeRate(ctx, repository.CreateExchangeRateParams{
+			Currency:    currency,
+			Rate:        req.Rate,
+			EffectiveAt: toPgTimestamp(&effectiveAt),
+			CreatedBy:   createdBy,
+		})
+		return WrapDBError(err, "exchange rate")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := toExchangeRateModel(rate)
+	return &result, nil
+}
+
+// DeleteRate removes a rate entered by mistake. The previous rate of the currency, if
+// any, becomes current again.
+func (s *CurrencyService) DeleteRate(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "CurrencyService.DeleteRate")
+	defer span.End()
+
+	rows, err := s.queries.DeleteExchangeRate(ctx, int32(id))
+	if err != nil {
+		return WrapDBError(err, "exchange rate")
+	}
+	if rows == 0 {
+		return NewNotFoundError("exchange rate")
+	}
+	return nil
+}
+
+// baseCurrency returns the base_currency setting, or VND when it is not set
+func baseCurrency(ctx context.Context, q repository.Querier) (string, error) {
+	value, err := settingValue(ctx, q, baseCurrencySetting)
+	if err != nil {
+		return "", err
+	}
+	if currency := utils.NormalizeCurrency(value); utils.IsCurrencyCode(currency) {
+		return currency, nil
+	}
+	return utils.FallbackBaseCurrency, nil
+}
+
+// priceProducts converts product prices to the currency requested with ?currency=, or
+// rounds them in the base currency when none was requested, and sets their currency
+func priceProducts(ctx context.Context, q repository.Querier, products ...*model.Product) error {
+	base, err := baseCurrency(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	currency := utils.CurrencyFromContext(ctx)
+	if currency == "" || currency == base {
+		for _, p := range products {
+			p.Price = utils.RoundAmount(p.Price, base)
+			p.PriceSale = utils.RoundAmount(p.PriceSale, base)
+			p.Currency = base
+		}
+		return nil
+	}
+
+	if !utils.IsCurrencyCode(currency) {
+		return NewValidationError("currency", "Must be a 3 letter currency code")
+	}
+	rate, err := q.GetCurrentExchangeRate(ctx, currency)
+	if err != nil {
+		if isNotFound(err) {
+			return NewValidationError("currency", fmt.Sprintf("No exchange rate is set for %s", currency))
+		}
+		return WrapDBError(err, "exchange rate")
+	}
+
+	for _, p := range products {
+		p.Price = utils.ConvertAmount(p.Price, rate.Rate, currency)
+		p.PriceSale = utils.ConvertAmount(p.PriceSale, rate.Rate, currency)
+		p.Currency = currency
+	}
+	return nil
+}
+
+// priceProductList prices every product of a list in place
+func priceProductList(ctx context.Context, q repository.Querier, products []model.Product) error {
+	refs := make([]*model.Product, len(products))
+	for i := range products {
+		refs[i] = &products[i]
+	}
+	return priceProducts(ctx, q, refs...)
+}
+
+func toExchangeRateModel(rate repository.ExchangeRate) model.ExchangeRate {
+	return model.ExchangeRate{
+		ID:          int(rate.ID),
+		Currency:    rate.Currency,
+		Rate:        rate.Rate,
+		EffectiveAt: rate.EffectiveAt.Time,
+		CreatedBy:   fromPgInt8(rate.CreatedBy),
+		CreatedAt:   rate.CreatedAt.Time,
+	}
+}
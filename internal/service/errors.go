@@ -1,6 +1,13 @@
 package service
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
 
 var (
 	// ErrNotFound is returned when a requested resource is not found
@@ -9,4 +16,171 @@ var (
 	ErrInvalidInput = errors.New("invalid input")
 	// ErrUnauthorized is returned when the user is not authorized to perform the action
 	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden is returned when the user is authenticated but not allowed to perform the action
+	ErrForbidden = errors.New("forbidden")
+	// ErrConflict is returned when the action conflicts with the current state, e.g. a duplicate slug
+	ErrConflict = errors.New("conflict")
+)
+
+// PostgreSQL error codes handled by WrapDBError
+const (
+	pgNotNullViolation    = "23502"
+	pgForeignKeyViolation = "23503"
+	pgUniqueViolation     = "23505"
+	pgCheckViolation      = "23514"
 )
+
+// Error is a typed service error. Kind is one of the sentinel errors above, so callers
+// can keep using errors.Is(err, ErrNotFound). Code is a stable machine-readable
+// identifier such as "product_not_found" that API clients can rely on.
+type Error struct {
+	Kind    error
+	Code    string
+	Message string
+	Field   string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the error is of the given kind
+func (e *Error) Is(target error) bool {
+	return e.Kind == target
+}
+
+// NewNotFoundError returns a not found error for the given resource, e.g. "product"
+func NewNotFoundError(resource string) *Error {
+	return &Error{
+		Kind:    ErrNotFound,
+		Code:    codeName(resource) + "_not_found",
+		Message: capitalize(resource) + " not found",
+	}
+}
+
+// NewConflictError returns an error for an action that conflicts with existing data
+func NewConflictError(code, message string) *Error {
+	return &Error{Kind: ErrConflict, Code: code, Message: message}
+}
+
+// NewValidationError returns an error for invalid input on the given field
+func NewValidationError(field, message string) *Error {
+	return &Error{Kind: ErrInvalidInput, Code: "validation_failed", Message: message, Field: field}
+}
+
+// NewUnauthorizedError returns an authentication error
+func NewUnauthorizedError(code, message string) *Error {
+	return &Error{Kind: ErrUnauthorized, Code: code, Message: message}
+}
+
+// NewForbiddenError returns an authorization error
+func NewForbiddenError(code, message string) *Error {
+	return &Error{Kind: ErrForbidden, Code: code, Message: message}
+}
+
+// WrapDBError converts database errors into typed service errors for the given
+// resource. pgx.ErrNoRows becomes a not found error and constraint violations
+// become conflict or validation errors. Other errors are returned unchanged.
+func WrapDBError(err error, resource string) error {
+	if err == nil {
+		return nil
+	}
+
+	var serviceErr *Error
+	if errors.As(err, &serviceErr) {
+		return err
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return NewNotFoundError(resource)
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		field := constraintField(pgErr)
+		return &Error{
+			Kind:    ErrConflict,
+			Code:    fmt.Sprintf("%s_%s_taken", codeName(resource), field),
+			Message: fmt.Sprintf("A %s with this %s already exists", resource, strings.ReplaceAll(field, "_", " ")),
+			Field:   field,
+			Err:     err,
+		}
+	case pgForeignKeyViolation:
+		// Inserting or updating a row that points to a missing parent
+		if strings.Contains(pgErr.Detail, "is not present in table") {
+			field := constraintField(pgErr)
+			return &Error{
+				Kind:    ErrInvalidInput,
+				Code:    "invalid_reference",
+				Message: fmt.Sprintf("The referenced %s does not exist", strings.TrimSuffix(field, "_id")),
+				Field:   field,
+				Err:     err,
+			}
+		}
+		// Deleting a row that is still referenced elsewhere
+		return &Error{
+			Kind:    ErrConflict,
+			Code:    codeName(resource) + "_in_use",
+			Message: fmt.Sprintf("The %s is still in use and cannot be deleted", resource),
+			Err:     err,
+		}
+	case pgNotNullViolation:
+		return &Error{
+			Kind:    ErrInvalidInput,
+			Code:    "validation_failed",
+			Message: fmt.Sprintf("%s is required", pgErr.ColumnName),
+			Field:   pgErr.ColumnName,
+			Err:     err,
+		}
+	case pgCheckViolation:
+		return &Error{
+			Kind:    ErrInvalidInput,
+			Code:    "validation_failed",
+			Message: fmt.Sprintf("Invalid value for %s", resource),
+			Err:     err,
+		}
+	}
+
+	return err
+}
+
+// constraintField guesses the offending column from the constraint name,
+// e.g. "products_slug_key" -> "slug", "products_category_id_fkey" -> "category_id"
+func constraintField(pgErr *pgconn.PgError) string {
+	if pgErr.ColumnName != "" {
+		return pgErr.ColumnName
+	}
+	name := strings.TrimPrefix(pgErr.ConstraintName, pgErr.TableName+"_")
+	for _, suffix := range []string{"_key", "_fkey", "_pkey"} {
+		name = strings.TrimSuffix(name, suffix)
+	}
+	if name == "" {
+		return "value"
+	}
+	return name
+}
+
+// codeName converts a resource name such as "blog post" into "blog_post"
+func codeName(resource string) string {
+	return strings.ReplaceAll(strings.ToLower(resource), " ", "_")
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
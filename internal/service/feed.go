@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"mime"
+	"net/url"
+	"path"
+	"time"
+
+	"beef-db-be/internal/config"
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/repository"
+)
+
+// blogFeedSize is how many of the latest published posts the feeds list
+const blogFeedSize = 20
+
+// defaultFeedTitle is used when the site_name setting is not set
+const defaultFeedTitle = "Blog"
+
+// FeedService builds the RSS and Atom feeds of the blog
+type FeedService struct {
+	queries   repository.Querier
+	blogPosts *BlogPostService
+	site      config.SiteConfig
+}
+
+func NewFeedService(queries repository.Querier, blogPosts *BlogPostService, site config.SiteConfig) *FeedService {
+	return &FeedService{
+		queries:   queries,
+		blogPosts: blogPosts,
+		site:      site,
+	}
+}
+
+// blogFeed is what both feed formats are built from
+type blogFeed struct {
+	siteURL      string
+	title        string
+	description  string
+	posts        []model.BlogPost
+	lastModified time.Time
+}
+
+// GetRSS returns the RSS 2.0 feed of the latest published posts and the time the
+// newest of them last changed, for conditional requests. Links start with the
+// site_url setting, or SITE_URL when it is not set.
+func (s *FeedService) GetRSS(ctx context.Context) (*model.RSSFeed, time.Time, error) {
+	ctx, span := tracer.Start(ctx, "FeedService.GetRSS")
+	defer span.End()
+
+	feed, err := s.load(ctx)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	channel := model.RSSChannel{
+		Title:       feed.title,
+		Link:        feed.siteURL + "/blog",
+		Description: feed.description,
+		AtomLink: model.RSSAtomLink{
+			Href: feed.siteURL + "/feed.xml",
+			Rel:  "self",
+			Type: "application/rss+xml",
+		},
+		Items: make([]model.RSSItem, len(feed.posts)),
+	}
+	if !feed.lastModified.IsZero() {
+		channel.LastBuildDate = feed.lastModified.UTC().Format(time.RFC1123Z)
+	}
+
+	for i, post := range feed.posts {
+		link := blogPostURL(feed.siteURL, post.Slug)
+		item := model.RSSItem{
+			Title:       post.Title,
+			Link:        link,
+			GUID:        model.RSSGUID{Value: link, IsPermaLink: true},
+			Description: feedSummary(post),
+			Categories:  feedCategories(post),
+		}
+		if post.PublishedAt != nil {
+			item.PubDate = post.PublishedAt.UTC().Format(time.RFC1123Z)
+		}
+		if post.ImageURL != "" {
+			item.Enclosure = &model.RSSEnclosure{URL: post.ImageURL, Type: imageMimeType(post.ImageURL)}
+		}
+		channel.Items[i] = item
+	}
+
+	return &model.RSSFeed{Version: "2.0", Atom: "http://www.w3.org/2005/Atom", Channel: channel}, feed.lastModified, nil
+}
+
+// GetAtom returns the Atom 1.0 feed of the latest published posts and the time the
+// newest of them last changed, for conditional requests
+func (s *FeedService) GetAtom(ctx context.Context) (*model.AtomFeed, time.Time, error) {
+	ctx, span := tracer.Start(ctx, "FeedService.GetAtom")
+	defer span.End()
+
+	feed, err := s.load(ctx)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	updated := feed.lastModified
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+
+	atom := &model.AtomFeed{
+		Xmlns:    "http://www.w3.org/2005/Atom",
+		ID:       feed.siteURL + "/atom.xml",
+		Title:    feed.title,
+		Subtitle: feed.description,
+		Updated:  updated.UTC().Format(time.RFC3339),
+		Links: []model.AtomLink{
+			{Href: feed.siteURL + "/atom.xml", Rel: "self", Type: "application/atom+xml"},
+			{Href: feed.siteURL + "/blog", Rel: "alternate", Type: "text/html"},
+		},
+		Entries: make([]model.AtomEntry, len(feed.posts)),
+	}
+
+	for i, post := range feed.posts {
+		link := blogPostURL(feed.siteURL, post.Slug)
+		entry := model.AtomEntry{
+			ID:      link,
+			Title:   post.Title,
+			Updated: post.UpdatedAt.UTC().Format(time.RFC3339),
+			Summary: feedSummary(post),
+			Links:   []model.AtomLink{{Href: link, Rel: "alternate", Type: "text/html"}},
+		}
+		if post.PublishedAt != nil {
+			entry.Published = post.PublishedAt.UTC().Format(time.RFC3339)
+		}
+		if post.ImageURL != "" {
+			entry.Links = append(entry.Links, model.AtomLink{Href: post.ImageURL, Rel: "enclosure", Type: imageMimeType(post.ImageURL)})
+		}
+		for _, term := range feedCategories(post) {
+			entry.Categories = append(entry.Categories, model.AtomCategory{Term: term})
+		}
+		atom.Entries[i] = entry
+	}
+
+	return atom, feed.lastModified, nil
+}
+
+func (s *FeedService) load(ctx context.Context) (*blogFeed, error) {
+	siteURL, err := resolveSiteURL(ctx, s.queries, s.site.URL)
+	if err != nil {
+		return nil, err
+	}
+	title, err := settingValue(ctx, s.queries, siteNameSetting)
+	if err != nil {
+		return nil, err
+	}
+	if title == "" {
+		title = defaultFeedTitle
+	}
+	description, err := settingValue(ctx, s.queries, siteDescriptionSetting)
+	if err != nil {
+		return nil, err
+	}
+	if description == "" {
+		description = title
+	}
+
+	posts, _, err := s.blogPosts.ListPublished(ctx, blogFeedSize, 0, model.BlogPostFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &blogFeed{siteURL: siteURL, title: title, description: description, posts: posts}
+	for _, post := range posts {
+		if post.UpdatedAt.After(feed.lastModified) {
+			feed.lastModified = post.UpdatedAt
+		}
+		if post.PublishedAt != nil && post.PublishedAt.After(feed.lastModified) {
+			feed.lastModified = *post.PublishedAt
+		}
+	}
+	return feed, nil
+}
+
+// blogPostURL is the public page of a blog post on the site
+func blogPostURL(siteURL, slug string) string {
+	return siteURL + "/blog/" + slug
+}
+
+// feedSummary is the post description, or its excerpt when the description is empty
+func feedSummary(post model.BlogPost) string {
+	if post.Description != "" {
+		return post.Description
+	}
+	return post.Excerpt
+}
+
+func feedCategories(post model.BlogPost) []string {
+	terms := make([]string, 0, len(post.Categories)+len(post.Tags))
+	for _, category := range post.Categories {
+		terms = append(terms, category.Name)
+	}
+	for _, tag := range post.Tags {
+		terms = append(terms, tag.Name)
+	}
+	return terms
+}
+
+// imageMimeType guesses the type of an image from its URL extension, defaulting to JPEG
+func imageMimeType(imageURL string) string {
+	p := imageURL
+	if u, err := url.Parse(imageURL); err == nil {
+		p = u.Path
+	}
+	if t := mime.TypeByExtension(path.Ext(p)); t != "" {
+		return t
+	}
+	return "image/jpeg"
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"beef-db-be/internal/buildinfo"
+	"beef-db-be/internal/migration"
+	"beef-db-be/internal/model"
+)
+
+// ExpectedSchemaVersion is the newest embedded migration, so instances are not ready
+// against an outdated database
+var ExpectedSchemaVersion = int64(migration.LatestVersion())
+
+// healthCheckTimeout bounds each dependency check
+const healthCheckTimeout = 2 * time.Second
+
+// HealthService checks the dependencies the API needs to serve traffic
+type HealthService struct {
+	pool     *pgxpool.Pool
+	draining atomic.Bool
+}
+
+func NewHealthService(pool *pgxpool.Pool) *HealthService {
+	return &HealthService{
+		pool: pool,
+	}
+}
+
+// StartDraining marks the service as shutting down, so readiness fails and load
+// balancers stop sending traffic while in-flight requests finish
+func (s *HealthService) StartDraining() {
+	s.draining.Store(true)
+}
+
+// Ready checks that the service is not shutting down, database connectivity, the
+// migration version and pool saturation
+func (s *HealthService) Ready(ctx context.Context) model.HealthReport {
+	ctx, span := tracer.Start(ctx, "HealthService.Ready")
+	defer span.End()
+
+	checks := []model.HealthCheck{
+		runHealthCheck(ctx, "shutdown", s.checkShutdown),
+		runHealthCheck(ctx, "database", s.checkDatabase),
+		runHealthCheck(ctx, "migrations", s.checkMigrations),
+		runHealthCheck(ctx, "pool", s.checkPool),
+	}
+
+	report := model.HealthReport{Status: model.HealthStatusOK, Checks: checks}
+	for _, check := range checks {
+		if check.Status != model.HealthStatusOK {
+			report.Status = model.HealthStatusFail
+		}
+	}
+	return report
+}
+
+// Details returns the readiness checks along with pool statistics and build information
+func (s *HealthService) Details(ctx context.Context) model.HealthDetails {
+	ctx, span := tracer.Start(ctx, "HealthService.Details")
+	defer span.End()
+
+	stat := s.pool.Stat()
+	return model.HealthDetails{
+		HealthReport:  s.Ready(ctx),
+		Version:       buildinfo.Version,
+		Commit:        buildinfo.GetCommit(),
+		StartedAt:     buildinfo.StartedAt,
+		UptimeSeconds: int64(buildinfo.Uptime().Seconds()),
+		Pool: model.PoolStats{
+			AcquiredConns:        stat.AcquiredConns(),
+			IdleConns:            stat.IdleConns(),
+			TotalConns:           stat.TotalConns(),
+			MaxConns:             stat.MaxConns(),
+			AcquireCount:         stat.AcquireCount(),
+			EmptyAcquireCount:    stat.EmptyAcquireCount(),
+			CanceledAcquireCount: stat.CanceledAcquireCount(),
+			AcquireDurationMS:    float64(stat.AcquireDuration().Microseconds()) / 1000,
+		},
+	}
+}
+
+func (s *HealthService) checkShutdown(ctx context.Context) error {
+	if s.draining.Load() {
+		return errors.New("service is shutting down")
+	}
+	return nil
+}
+
+// Ping checks that the database answers
+func (s *HealthService) Ping(ctx context.Context) error {
+	return s.pool.Ping(ctx)
+}
+
+func (s *HealthService) checkDatabase(ctx context.Context) error {
+	return s.Ping(ctx)
+}
+
+// checkMigrations compares the version recorded by golang-migrate with ExpectedSchemaVersion
+func (s *HealthService) checkMigrations(ctx context.Context) error {
+	var version int64
+	var dirty bool
+	err := s.pool.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("no migrations have been applied")
+		}
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("migration %d failed and left the database dirty", version)
+	}
+	if version < ExpectedSchemaVersion {
+		return fmt.Errorf("database is at version %d, expected %d", version, ExpectedSchemaVersion)
+	}
+	return nil
+}
+
+// checkPool fails when every connection is in use, so new requests would have to wait
+func (s *HealthService) checkPool(ctx context.Context) error {
+	stat := s.pool.Stat()
+	if stat.AcquiredConns() >= stat.MaxConns() {
+		return fmt.Errorf("all %d connections are in use", stat.MaxConns())
+	}
+	return nil
+}
+
+func runHealthCheck(ctx context.Context, name string, check func(context.Context) error) model.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	result := model.HealthCheck{
+		Name:      name,
+		Status:    model.HealthStatusOK,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = model.HealthStatusFail
+		result.Message = err.Error()
+	}
+	return result
+}
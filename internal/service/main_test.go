@@ -0,0 +1,13 @@
+//go:build integration
+
+package service_test
+
+import (
+	"testing"
+
+	"beef-db-be/internal/pgtest"
+)
+
+func TestMain(m *testing.M) {
+	pgtest.Main(m)
+}
@@ -2,81 +2,134 @@ package service
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
-	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
-
+	"beef-db-be/internal/config"
 	"beef-db-be/internal/model"
 	"beef-db-be/internal/repository"
+	"beef-db-be/internal/utils"
 )
 
+// maxPageSlugLength matches the pages.slug column
+const maxPageSlugLength = 255
+
 type PageService struct {
-	queries *repository.Queries
-	pool    *pgxpool.Pool
+	queries repository.Querier
+	tx      repository.TxRunner
+	auth    config.AuthConfig
 }
 
-func NewPageService(dbPool *pgxpool.Pool) *PageService {
+func NewPageService(queries repository.Querier, tx repository.TxRunner, auth config.AuthConfig) *PageService {
 	return &PageService{
-		queries: repository.New(dbPool),
-		pool:    dbPool,
+		queries: queries,
+		tx:      tx,
+		auth:    auth,
 	}
 }
 
-func (s *PageService) CreatePage(ctx context.Context, req model.CreatePageRequest) (*model.Page, error) {
-	page, err := s.queries.CreatePage(ctx, repository.CreatePageParams{
-		Title:   req.Title,
-		Slug:    req.Slug,
-		Content: req.Content,
+// CreatePage creates a page and stores its first revision. authorID is the signed-in
+// user making the change.
+func (s *PageService) CreatePage(ctx context.Context, req model.CreatePageRequest, authorID int64) (*model.Page, error) {
+	ctx, span := tracer.Start(ctx, "PageService.CreatePage")
+	defer span.End()
+
+	slug, err := resolveSlug(ctx, req.Slug, req.Title, maxPageSlugLength, s.slugExists(0))
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := resolvePublishState(req.Status, req.PublishedAt, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var page repository.Page
+	err = s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		created, err := qtx.CreatePage(ctx, repository.CreatePageParams{
+			Title:           req.Title,
+			Slug:            slug,
+			Content:         req.Content,
+			Status:          string(state.status),
+			PublishedAt:     state.publishedAt,
+			ContentFormat:   resolveContentFormat(req.ContentFormat, ""),
+			MetaTitle:       req.SEO.MetaTitle,
+			MetaDescription: req.SEO.MetaDescription,
+			CanonicalUrl:    req.SEO.CanonicalURL,
+			OgImageUrl:      req.SEO.OGImageURL,
+		})
+		if err != nil {
+			return WrapDBError(err, "page")
+		}
+
+		page = created
+		return recordRevision(ctx, qtx, resourcePage, page.ID, revisionSnapshot{
+			title:         page.Title,
+			slug:          page.Slug,
+			content:       page.Content,
+			contentFormat: page.ContentFormat,
+		}, authorID)
 	})
 	if err != nil {
 		return nil, err
 	}
-	return &model.Page{
-		ID:        int64(page.ID),
-		Title:     page.Title,
-		Slug:      page.Slug,
-		Content:   page.Content,
-		CreatedAt: page.CreatedAt.Time,
-	}, nil
+	return toPageModel(page), nil
 }
 
+// GetPage retrieves a page regardless of its publishing status
 func (s *PageService) GetPage(ctx context.Context, id int32) (*model.Page, error) {
+	ctx, span := tracer.Start(ctx, "PageService.GetPage")
+	defer span.End()
+
 	page, err := s.queries.GetPage(ctx, id)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, ErrNotFound
-		}
-		return nil, err
+		return nil, WrapDBError(err, "page")
 	}
-	return &model.Page{
-		ID:        int64(page.ID),
-		Title:     page.Title,
-		Slug:      page.Slug,
-		Content:   page.Content,
-		CreatedAt: page.CreatedAt.Time,
-	}, nil
+	return toPageModel(page), nil
 }
 
-func (s *PageService) GetPageBySlug(ctx context.Context, slug string) (*model.Page, error) {
-	page, err := s.queries.GetPageBySlug(ctx, slug)
+// GetPublishedPage retrieves a page only if it is publicly visible
+func (s *PageService) GetPublishedPage(ctx context.Context, id int32) (*model.Page, error) {
+	ctx, span := tracer.Start(ctx, "PageService.GetPublishedPage")
+	defer span.End()
+
+	page, err := s.queries.GetPublishedPage(ctx, id)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, ErrNotFound
-		}
-		return nil, err
+		return nil, WrapDBError(err, "page")
 	}
-	return &model.Page{
-		ID:        int64(page.ID),
-		Title:     page.Title,
-		Slug:      page.Slug,
-		Content:   page.Content,
-		CreatedAt: page.CreatedAt.Time,
-	}, nil
+	return s.localized(ctx, page)
+}
+
+// GetPageBySlug retrieves a published page by its current slug. When slug is a previous
+// slug of the page, the page is returned with moved set to true.
+func (s *PageService) GetPageBySlug(ctx context.Context, slug string) (page *model.Page, moved bool, err error) {
+	ctx, span := tracer.Start(ctx, "PageService.GetPageBySlug")
+	defer span.End()
+
+	row, err := s.queries.GetPublishedPageBySlug(ctx, slug)
+	if err == nil {
+		page, err = s.localized(ctx, row)
+		return page, false, err
+	}
+	if !isNotFound(err) {
+		return nil, false, err
+	}
+
+	id, err := lookupSlugHistory(ctx, s.queries, resourcePage, "page", slug)
+	if err != nil {
+		return nil, false, err
+	}
+	page, err = s.GetPublishedPage(ctx, id)
+	if err != nil {
+		return nil, false, err
+	}
+	return page, true, nil
 }
 
+// ListPages retrieves all pages, including drafts, for admins
 func (s *PageService) ListPages(ctx context.Context, pagination model.Pagination) ([]model.Page, int64, error) {
+	ctx, span := tracer.Start(ctx, "PageService.ListPages")
+	defer span.End()
+
 	totalCount, err := s.queries.GetTotalPages(ctx)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get total count: %v", err)
@@ -91,40 +144,274 @@ func (s *PageService) ListPages(ctx context.Context, pagination model.Pagination
 
 	result := make([]model.Page, len(pages))
 	for i, page := range pages {
-		result[i] = model.Page{
-			ID:        int64(page.ID),
-			Title:     page.Title,
-			Slug:      page.Slug,
-			Content:   page.Content,
-			CreatedAt: page.CreatedAt.Time,
-		}
+		result[i] = *toPageModel(page)
 	}
 	return result, totalCount, nil
 }
 
-func (s *PageService) UpdatePage(ctx context.Context, id int32, req model.UpdatePageRequest) error {
-	err := s.queries.UpdatePage(ctx, repository.UpdatePageParams{
-		ID:      id,
-		Title:   req.Title,
-		Slug:    req.Slug,
-		Content: req.Content,
+// ListPublishedPages retrieves the publicly visible pages, newest first
+func (s *PageService) ListPublishedPages(ctx context.Context, pagination model.Pagination) ([]model.Page, int64, error) {
+	ctx, span := tracer.Start(ctx, "PageService.ListPublishedPages")
+	defer span.End()
+
+	totalCount, err := s.queries.GetTotalPublishedPages(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get total count: %v", err)
+	}
+	pages, err := s.queries.ListPublishedPages(ctx, repository.ListPublishedPagesParams{
+		Limit:  int32(pagination.GetLimit()),
+		Offset: int32(pagination.GetOffset()),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]model.Page, len(pages))
+	refs := make([]*model.Page, len(pages))
+	for i, page := range pages {
+		result[i] = *toPageModel(page)
+		refs[i] = &result[i]
+	}
+	if err := localizePages(ctx, s.queries, refs...); err != nil {
+		return nil, 0, err
+	}
+	return result, totalCount, nil
+}
+
+// CreatePreviewToken returns a signed token that lets anyone holding it view the page
+// before it is published
+func (s *PageService) CreatePreviewToken(ctx context.Context, id int32) (*model.PreviewTokenResponse, error) {
+	ctx, span := tracer.Start(ctx, "PageService.CreatePreviewToken")
+	defer span.End()
+
+	if _, err := s.queries.GetPage(ctx, id); err != nil {
+		return nil, WrapDBError(err, "page")
+	}
+
+	token, expiresAt, err := utils.GeneratePreviewToken(s.auth, resourcePage, int64(id))
+	if err != nil {
+		return nil, err
+	}
+	return &model.PreviewTokenResponse{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// GetPreview retrieves the page a preview token was issued for, whatever its status
+func (s *PageService) GetPreview(ctx context.Context, token string) (*model.Page, error) {
+	ctx, span := tracer.Start(ctx, "PageService.GetPreview")
+	defer span.End()
+
+	claims, err := utils.ValidatePreviewToken(s.auth, token)
+	if err != nil || claims.Resource != resourcePage {
+		return nil, NewUnauthorizedError("invalid_preview_token", "Preview link is invalid or has expired")
+	}
+
+	page, err := s.queries.GetPage(ctx, int32(claims.ResourceID))
+	if err != nil {
+		return nil, WrapDBError(err, "page")
+	}
+	return s.localized(ctx, page)
+}
+
+// UpdatePage updates a page. An empty slug keeps the current one; when the slug
+// changes the old one is kept in the slug history. Every update stores a new revision.
+func (s *PageService) UpdatePage(ctx context.Context, id int32, req model.UpdatePageRequest, authorID int64) error {
+	ctx, span := tracer.Start(ctx, "PageService.UpdatePage")
+	defer span.End()
+
+	return s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		existing, err := qtx.GetPage(ctx, id)
+		if err != nil {
+			return WrapDBError(err, "page")
+		}
+
+		slug := req.Slug
+		if slug == "" {
+			slug = existing.Slug
+		}
+
+		state, err := resolvePublishState(req.Status, req.PublishedAt, &publishState{
+			status:      model.PublishStatus(existing.Status),
+			publishedAt: existing.PublishedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		contentFormat := resolveContentFormat(req.ContentFormat, existing.ContentFormat)
+
+		rows, err := qtx.UpdatePage(ctx, repository.UpdatePageParams{
+			ID:              id,
+			Title:           req.Title,
+			Slug:            slug,
+			Content:         req.Content,
+			Status:          string(state.status),
+			PublishedAt:     state.publishedAt,
+			ContentFormat:   contentFormat,
+			MetaTitle:       req.SEO.MetaTitle,
+			MetaDescription: req.SEO.MetaDescription,
+			CanonicalUrl:    req.SEO.CanonicalURL,
+			OgImageUrl:      req.SEO.OGImageURL,
+		})
+		if err != nil {
+			return WrapDBError(err, "page")
+		}
+		if rows == 0 {
+			return NewNotFoundError("page")
+		}
+
+		if err := recordSlugChange(ctx, qtx, resourcePage, id, existing.Slug, slug); err != nil {
+			return err
+		}
+
+		return recordRevision(ctx, qtx, resourcePage, id, revisionSnapshot{
+			title:         req.Title,
+			slug:          slug,
+			content:       req.Content,
+			contentFormat: contentFormat,
+		}, authorID)
 	})
+}
+
+// ListPageRevisions retrieves the revisions of a page, newest first
+func (s *PageService) ListPageRevisions(ctx context.Context, id int32, pagination model.Pagination) ([]model.ContentRevision, int64, error) {
+	ctx, span := tracer.Start(ctx, "PageService.ListPageRevisions")
+	defer span.End()
+
+	if _, err := s.queries.GetPage(ctx, id); err != nil {
+		return nil, 0, WrapDBError(err, "page")
+	}
+	return listRevisions(ctx, s.queries, resourcePage, id, pagination)
+}
+
+// GetPageRevision retrieves a single revision of a page
+func (s *PageService) GetPageRevision(ctx context.Context, id, revisionID int32) (*model.ContentRevision, error) {
+	ctx, span := tracer.Start(ctx, "PageService.GetPageRevision")
+	defer span.End()
+
+	revision, err := getRevision(ctx, s.queries, resourcePage, id, revisionID)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return ErrNotFound
+		return nil, err
+	}
+	result := toRevisionModel(revision)
+	return &result, nil
+}
+
+// DiffPageRevisions compares two revisions of a page
+func (s *PageService) DiffPageRevisions(ctx context.Context, id, fromID, toID int32) (*model.RevisionDiff, error) {
+	ctx, span := tracer.Start(ctx, "PageService.DiffPageRevisions")
+	defer span.End()
+
+	return diffRevisions(ctx, s.queries, resourcePage, id, fromID, toID)
+}
+
+// RestorePageRevision makes an old revision the current version of a page. The
+// restore is itself stored as a new revision so it can be undone too.
+func (s *PageService) RestorePageRevision(ctx context.Context, id, revisionID int32, authorID int64) (*model.Page, error) {
+	ctx, span := tracer.Start(ctx, "PageService.RestorePageRevision")
+	defer span.End()
+
+	err := s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		existing, err := qtx.GetPage(ctx, id)
+		if err != nil {
+			return WrapDBError(err, "page")
+		}
+
+		revision, err := getRevision(ctx, qtx, resourcePage, id, revisionID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := qtx.UpdatePage(ctx, repository.UpdatePageParams{
+			ID:              id,
+			Title:           revision.Title,
+			Slug:            revision.Slug,
+			Content:         revision.Content,
+			Status:          existing.Status,
+			PublishedAt:     existing.PublishedAt,
+			ContentFormat:   revision.ContentFormat,
+			MetaTitle:       existing.MetaTitle,
+			MetaDescription: existing.MetaDescription,
+			CanonicalUrl:    existing.CanonicalUrl,
+			OgImageUrl:      existing.OgImageUrl,
+		}); err != nil {
+			return WrapDBError(err, "page")
+		}
+
+		if err := recordSlugChange(ctx, qtx, resourcePage, id, existing.Slug, revision.Slug); err != nil {
+			return err
 		}
-		return err
+
+		return recordRevision(ctx, qtx, resourcePage, id, revisionSnapshot{
+			title:         revision.Title,
+			slug:          revision.Slug,
+			content:       revision.Content,
+			contentFormat: revision.ContentFormat,
+		}, authorID)
+	})
+	if err != nil {
+		return nil, err
 	}
-	return nil
+
+	return s.GetPage(ctx, id)
 }
 
 func (s *PageService) DeletePage(ctx context.Context, id int32) error {
-	err := s.queries.DeletePage(ctx, id)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return ErrNotFound
+	ctx, span := tracer.Start(ctx, "PageService.DeletePage")
+	defer span.End()
+
+	return s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		rows, err := qtx.DeletePage(ctx, id)
+		if err != nil {
+			return WrapDBError(err, "page")
 		}
-		return err
+		if rows == 0 {
+			return NewNotFoundError("page")
+		}
+
+		if err := qtx.DeleteSlugHistoryByResource(ctx, repository.DeleteSlugHistoryByResourceParams{
+			ResourceType: resourcePage,
+			ResourceID:   id,
+		}); err != nil {
+			return err
+		}
+
+		return qtx.DeleteContentRevisionsByResource(ctx, repository.DeleteContentRevisionsByResourceParams{
+			ResourceType: resourcePage,
+			ResourceID:   id,
+		})
+	})
+}
+
+// slugExists returns a check for page slugs used by pages other than excludeID
+func (s *PageService) slugExists(excludeID int32) slugExistsFunc {
+	return func(ctx context.Context, slug string) (bool, error) {
+		return s.queries.PageSlugExists(ctx, repository.PageSlugExistsParams{
+			Slug: slug,
+			ID:   excludeID,
+		})
+	}
+}
+
+func toPageModel(page repository.Page) *model.Page {
+	return &model.Page{
+		ID:            int64(page.ID),
+		Title:         page.Title,
+		Slug:          page.Slug,
+		Content:       page.Content,
+		ContentHTML:   utils.RenderContent(page.Content, model.ContentFormat(page.ContentFormat)),
+		ContentFormat: model.ContentFormat(page.ContentFormat),
+		Status:        effectiveStatus(page.Status, page.PublishedAt),
+		PublishedAt:   fromPgTimestamp(page.PublishedAt),
+		SEO:           toSEOModel(page.MetaTitle, page.MetaDescription, page.CanonicalUrl, page.OgImageUrl),
+		CreatedAt:     page.CreatedAt.Time,
+	}
+}
+
+// localized converts page to its model with the text translated to the request locale, for public views
+func (s *PageService) localized(ctx context.Context, page repository.Page) (*model.Page, error) {
+	result := toPageModel(page)
+	if err := localizePages(ctx, s.queries, result); err != nil {
+		return nil, err
 	}
-	return nil
+	return result, nil
 }
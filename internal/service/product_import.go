@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"beef-db-be/internal/logger"
+	"beef-db-be/internal/metrics"
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/repository"
+	"beef-db-be/internal/utils"
+)
+
+// exportBatchSize is the number of products read from the database per export batch
+const exportBatchSize = 500
+
+// ErrImportInvalid is returned when one or more import rows fail validation
+var ErrImportInvalid = errors.New("import contains invalid rows")
+
+// errDryRun rolls back the transaction of a dry run import
+var errDryRun = errors.New("dry run")
+
+// ImportProducts validates the rows and upserts them by slug in a single transaction.
+// When dryRun is true the transaction is rolled back so nothing is persisted, but the
+// result still reports how many products would be created or updated.
+// If any row is invalid nothing is written and ErrImportInvalid is returned along with
+// the per-row errors.
+func (s *ProductService) ImportProducts(ctx context.Context, rows []model.ProductImportRow, dryRun bool) (*model.ProductImportResult, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.ImportProducts")
+	defer span.End()
+
+	result := &model.ProductImportResult{
+		DryRun:    dryRun,
+		TotalRows: len(rows),
+		Errors:    []model.ProductImportRowError{},
+	}
+
+	categories, err := s.queries.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	categoryIDs := make(map[string]int32, len(categories))
+	for _, c := range categories {
+		categoryIDs[c.Slug] = c.ID
+	}
+
+	seenSlugs := make(map[string]int, len(rows))
+	for i, row := range rows {
+		rowNumber := i + 1
+		errs := validateImportRow(row, categoryIDs)
+		if first, ok := seenSlugs[row.Slug]; ok && row.Slug != "" {
+			errs = append(errs, model.NewValidationError("slug",
+				fmt.Sprintf("Duplicate slug, already used on row %d", first)))
+		} else {
+			seenSlugs[row.Slug] = rowNumber
+		}
+		if len(errs) > 0 {
+			result.Errors = append(result.Errors, model.ProductImportRowError{
+				Row:    rowNumber,
+				Slug:   row.Slug,
+				Errors: errs,
+			})
+		}
+	}
+
+	if len(result.Errors) > 0 {
+		return result, ErrImportInvalid
+	}
+
+	err = s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		// Counted again when the transaction is retried
+		result.Created, result.Updated = 0, 0
+		for i, row := range rows {
+			unit := row.UnitOfMeasurement
+			if unit == "" {
+				unit = "piece"
+			}
+
+			upserted, err := qtx.UpsertProductBySlug(ctx, repository.UpsertProductBySlugParams{
+				CategoryID:        categoryIDs[row.CategorySlug],
+				Name:              row.Name,
+				Slug:              row.Slug,
+				Description:       row.Description,
+				Price:             row.Price,
+				PriceSale:         row.PriceSale,
+				UnitOfMeasurement: unit,
+				ImageUrl:          row.ImageURL,
+				ThumbUrl:          row.ThumbURL,
+			})
+			if err != nil {
+				return fmt.Errorf("row %d (%s): %w", i+1, row.Slug, err)
+			}
+
+			if upserted.Inserted {
+				result.Created++
+			} else {
+				result.Updated++
+			}
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errDryRun) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("Products imported", "created", result.Created, "updated", result.Updated)
+	metrics.ProductsCreatedTotal.Add(float64(result.Created))
+	return result, nil
+}
+
+// ExportProducts reads the full catalog in batches ordered by ID and passes each
+// product to fn, so callers can stream the output without loading everything in memory
+func (s *ProductService) ExportProducts(ctx context.Context, fn func(model.ProductImportRow) error) error {
+	ctx, span := tracer.Start(ctx, "ProductService.ExportProducts")
+	defer span.End()
+
+	var afterID int32
+	for {
+		products, err := s.queries.ListProductsForExport(ctx, repository.ListProductsForExportParams{
+			AfterID:  afterID,
+			RowLimit: exportBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, p := range products {
+			if err := fn(model.ProductImportRow{
+				CategorySlug:      p.CategorySlug,
+				Name:              p.Name,
+				Slug:              p.Slug,
+				Description:       p.Description,
+				Price:             p.Price,
+				PriceSale:         p.PriceSale,
+				UnitOfMeasurement: p.UnitOfMeasurement,
+				ImageURL:          p.ImageUrl,
+				ThumbURL:          p.ThumbUrl,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if len(products) < exportBatchSize {
+			return nil
+		}
+		afterID = products[len(products)-1].ID
+	}
+}
+
+func validateImportRow(row model.ProductImportRow, categoryIDs map[string]int32) []model.ValidationError {
+	errs := utils.ValidateStruct(row)
+
+	if row.CategorySlug != "" {
+		if _, ok := categoryIDs[row.CategorySlug]; !ok {
+			errs = append(errs, model.NewValidationError("category_slug",
+				fmt.Sprintf("Category '%s' does not exist", row.CategorySlug)))
+		}
+	}
+
+	return errs
+}
@@ -2,58 +2,88 @@ package service
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 
+	"beef-db-be/internal/metrics"
 	"beef-db-be/internal/model"
 	"beef-db-be/internal/repository"
 )
 
+// maxProductSlugLength matches the products.slug column
+const maxProductSlugLength = 200
+
 type ProductService struct {
-	queries *repository.Queries
-	pool    *pgxpool.Pool
+	queries repository.Querier
+	tx      repository.TxRunner
 }
 
-func NewProductService(pool *pgxpool.Pool) *ProductService {
+func NewProductService(queries repository.Querier, tx repository.TxRunner) *ProductService {
 	return &ProductService{
-		queries: repository.New(pool),
-		pool:    pool,
+		queries: queries,
+		tx:      tx,
 	}
 }
 
 func (s *ProductService) CreateProduct(ctx context.Context, req model.CreateProductRequest) (*model.Product, error) {
-	result, err := s.queries.CreateProduct(ctx, repository.CreateProductParams{
-		CategoryID:        int32(req.CategoryID),
-		Name:              req.Name,
-		Slug:              req.Slug,
-		Description:       req.Description,
-		Price:             req.Price,
-		PriceSale:         req.PriceSale,
-		ImageUrl:          req.ImageURL,
-		UnitOfMeasurement: req.UnitOfMeasurement,
-		ThumbUrl:          req.ThumbURL,
+	ctx, span := tracer.Start(ctx, "ProductService.CreateProduct")
+	defer span.End()
+
+	slug, err := resolveSlug(ctx, req.Slug, req.Name, maxProductSlugLength, s.slugExists(0))
+	if err != nil {
+		return nil, err
+	}
+
+	var product *model.Product
+	err = s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		id, err := qtx.CreateProduct(ctx, repository.CreateProductParams{
+			CategoryID:        int32(req.CategoryID),
+			Name:              req.Name,
+			Slug:              slug,
+			Description:       req.Description,
+			Price:             req.Price,
+			PriceSale:         req.PriceSale,
+			ImageUrl:          req.ImageURL,
+			UnitOfMeasurement: req.UnitOfMeasurement,
+			ThumbUrl:          req.ThumbURL,
+			MetaTitle:         req.SEO.MetaTitle,
+			MetaDescription:   req.SEO.MetaDescription,
+			CanonicalUrl:      req.SEO.CanonicalURL,
+			OgImageUrl:        req.SEO.OGImageURL,
+		})
+		if err != nil {
+			return WrapDBError(err, "product")
+		}
+
+		product, err = getProduct(ctx, qtx, int(id))
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
+	metrics.ProductsCreatedTotal.Inc()
 
-	return s.GetProduct(ctx, int(result))
+	return product, nil
 }
 
 func (s *ProductService) GetProduct(ctx context.Context, id int) (*model.Product, error) {
-	product, err := s.queries.GetProduct(ctx, int32(id))
+	ctx, span := tracer.Start(ctx, "ProductService.GetProduct")
+	defer span.End()
+
+	return getProduct(ctx, s.queries, id)
+}
+
+// getProduct loads a localized and priced product through q
+func getProduct(ctx context.Context, q repository.Querier, id int) (*model.Product, error) {
+	product, err := q.GetProduct(ctx, int32(id))
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, errors.New("product not found")
-		}
-		return nil, err
+		return nil, WrapDBError(err, "product")
 	}
 
-	return &model.Product{
+	seo := toSEOModel(product.MetaTitle, product.MetaDescription, product.CanonicalUrl, product.OgImageUrl)
+	result := &model.Product{
 		ID:                int(product.ID),
 		CategoryID:        int(product.CategoryID),
 		Name:              product.Name,
@@ -63,22 +93,57 @@ func (s *ProductService) GetProduct(ctx context.Context, id int) (*model.Product
 		PriceSale:         product.PriceSale,
 		ImageURL:          product.ImageUrl,
 		ThumbURL:          product.ThumbUrl,
+		SEO:               &seo,
 		CreatedAt:         product.CreatedAt.Time,
+		UpdatedAt:         &product.UpdatedAt.Time,
 		CategoryName:      product.CategoryName,
 		CategorySlug:      product.CategorySlug,
 		UnitOfMeasurement: product.UnitOfMeasurement,
-	}, nil
+	}
+	if err := localizeProducts(ctx, q, result); err != nil {
+		return nil, err
+	}
+	if err := priceProducts(ctx, q, result); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
-func (s *ProductService) GetProductBySlug(ctx context.Context, slug string) (*model.Product, error) {
-	product, err := s.queries.GetProductBySlug(ctx, slug)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, errors.New("product not found")
+// GetProductBySlug retrieves a product by its current slug. When slug is a previous
+// slug of the product, the product is returned with moved set to true so callers can
+// redirect to the current slug.
+func (s *ProductService) GetProductBySlug(ctx context.Context, slug string) (product *model.Product, moved bool, err error) {
+	ctx, span := tracer.Start(ctx, "ProductService.GetProductBySlug")
+	defer span.End()
+
+	row, err := s.queries.GetProductBySlug(ctx, slug)
+	if err == nil {
+		product = productFromSlugRow(row)
+		if err := localizeProducts(ctx, s.queries, product); err != nil {
+			return nil, false, err
 		}
-		return nil, err
+		if err := priceProducts(ctx, s.queries, product); err != nil {
+			return nil, false, err
+		}
+		return product, false, nil
+	}
+	if !isNotFound(err) {
+		return nil, false, err
+	}
+
+	id, err := lookupSlugHistory(ctx, s.queries, resourceProduct, "product", slug)
+	if err != nil {
+		return nil, false, err
 	}
+	product, err = s.GetProduct(ctx, int(id))
+	if err != nil {
+		return nil, false, err
+	}
+	return product, true, nil
+}
 
+func productFromSlugRow(product repository.GetProductBySlugRow) *model.Product {
+	seo := toSEOModel(product.MetaTitle, product.MetaDescription, product.CanonicalUrl, product.OgImageUrl)
 	return &model.Product{
 		ID:           int(product.ID),
 		CategoryID:   int(product.CategoryID),
@@ -89,13 +154,18 @@ func (s *ProductService) GetProductBySlug(ctx context.Context, slug string) (*mo
 		PriceSale:    product.PriceSale,
 		ImageURL:     product.ImageUrl,
 		ThumbURL:     product.ThumbUrl,
+		SEO:          &seo,
 		CreatedAt:    product.CreatedAt.Time,
+		UpdatedAt:    &product.UpdatedAt.Time,
 		CategoryName: product.CategoryName,
 		CategorySlug: product.CategorySlug,
-	}, nil
+	}
 }
 
 func (s *ProductService) ListProducts(ctx context.Context, pagination model.Pagination) ([]model.Product, int64, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.ListProducts")
+	defer span.End()
+
 	// Get total count first
 	totalCount, err := s.queries.GetTotalProducts(ctx)
 	if err != nil {
@@ -108,8 +178,6 @@ func (s *ProductService) ListProducts(ctx context.Context, pagination model.Pagi
 	}
 
 	products, err := s.queries.ListProducts(ctx, params)
-	fmt.Println("UnitOfMeasurement", products[0].UnitOfMeasurement)
-
 	if err != nil {
 		return nil, 0, err
 	}
@@ -133,12 +201,21 @@ func (s *ProductService) ListProducts(ctx context.Context, pagination model.Pagi
 			UnitOfMeasurement: p.UnitOfMeasurement,
 		}
 	}
+	if err := localizeProductList(ctx, s.queries, result); err != nil {
+		return nil, 0, err
+	}
+	if err := priceProductList(ctx, s.queries, result); err != nil {
+		return nil, 0, err
+	}
 
 	return result, totalCount, nil
 }
 
 // ListProductsByCategoryID retrieves products by category ID
 func (s *ProductService) ListProductsByCategoryID(ctx context.Context, categoryID int64, pagination model.Pagination) ([]model.Product, int64, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.ListProductsByCategoryID")
+	defer span.End()
+
 	// Get total count first
 	totalCount, err := s.queries.GetTotalProductsByCategoryID(ctx, int32(categoryID))
 	if err != nil {
@@ -175,12 +252,21 @@ func (s *ProductService) ListProductsByCategoryID(ctx context.Context, categoryI
 			UnitOfMeasurement: p.UnitOfMeasurement,
 		}
 	}
+	if err := localizeProductList(ctx, s.queries, result); err != nil {
+		return nil, 0, err
+	}
+	if err := priceProductList(ctx, s.queries, result); err != nil {
+		return nil, 0, err
+	}
 
 	return result, totalCount, nil
 }
 
 // ListProductsByCategorySlug retrieves products by category slug
 func (s *ProductService) ListProductsByCategorySlug(ctx context.Context, categorySlug string, pagination model.Pagination) ([]model.Product, int64, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.ListProductsByCategorySlug")
+	defer span.End()
+
 	// Get total count first
 	totalCount, err := s.queries.GetTotalProductsByCategorySlug(ctx, categorySlug)
 	if err != nil {
@@ -217,46 +303,106 @@ func (s *ProductService) ListProductsByCategorySlug(ctx context.Context, categor
 			UnitOfMeasurement: p.UnitOfMeasurement,
 		}
 	}
+	if err := localizeProductList(ctx, s.queries, result); err != nil {
+		return nil, 0, err
+	}
+	if err := priceProductList(ctx, s.queries, result); err != nil {
+		return nil, 0, err
+	}
 
 	return result, totalCount, nil
 }
 
+// UpdateProduct updates a product. An empty slug keeps the current one; when the slug
+// changes the old one is kept in the slug history so existing links keep working.
 func (s *ProductService) UpdateProduct(ctx context.Context, id int, req model.UpdateProductRequest) (*model.Product, error) {
-	err := s.queries.UpdateProduct(ctx, repository.UpdateProductParams{
-		ID:                int32(id),
-		CategoryID:        int32(req.CategoryID),
-		Name:              req.Name,
-		Slug:              req.Slug,
-		Description:       req.Description,
-		UnitOfMeasurement: req.UnitOfMeasurement,
-		Price:             req.Price,
-		PriceSale:         req.PriceSale,
-		ImageUrl:          req.ImageURL,
-		ThumbUrl:          req.ThumbURL,
+	ctx, span := tracer.Start(ctx, "ProductService.UpdateProduct")
+	defer span.End()
+
+	var product *model.Product
+	err := s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		existing, err := qtx.GetProduct(ctx, int32(id))
+		if err != nil {
+			return WrapDBError(err, "product")
+		}
+
+		slug := req.Slug
+		if slug == "" {
+			slug = existing.Slug
+		}
+
+		rows, err := qtx.UpdateProduct(ctx, repository.UpdateProductParams{
+			ID:                int32(id),
+			CategoryID:        int32(req.CategoryID),
+			Name:              req.Name,
+			Slug:              slug,
+			Description:       req.Description,
+			UnitOfMeasurement: req.UnitOfMeasurement,
+			Price:             req.Price,
+			PriceSale:         req.PriceSale,
+			ImageUrl:          req.ImageURL,
+			ThumbUrl:          req.ThumbURL,
+			MetaTitle:         req.SEO.MetaTitle,
+			MetaDescription:   req.SEO.MetaDescription,
+			CanonicalUrl:      req.SEO.CanonicalURL,
+			OgImageUrl:        req.SEO.OGImageURL,
+		})
+		if err != nil {
+			return WrapDBError(err, "product")
+		}
+		if rows == 0 {
+			return NewNotFoundError("product")
+		}
+
+		if err := recordSlugChange(ctx, qtx, resourceProduct, int32(id), existing.Slug, slug); err != nil {
+			return err
+		}
+
+		product, err = getProduct(ctx, qtx, id)
+		return err
 	})
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, errors.New("product not found")
-		}
 		return nil, err
 	}
 
-	return s.GetProduct(ctx, id)
+	return product, nil
 }
 
 func (s *ProductService) DeleteProduct(ctx context.Context, id int) error {
-	err := s.queries.DeleteProduct(ctx, int32(id))
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return errors.New("product not found")
+	ctx, span := tracer.Start(ctx, "ProductService.DeleteProduct")
+	defer span.End()
+
+	return s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		rows, err := qtx.DeleteProduct(ctx, int32(id))
+		if err != nil {
+			return WrapDBError(err, "product")
 		}
-		return err
+		if rows == 0 {
+			return NewNotFoundError("product")
+		}
+
+		return qtx.DeleteSlugHistoryByResource(ctx, repository.DeleteSlugHistoryByResourceParams{
+			ResourceType: resourceProduct,
+			ResourceID:   int32(id),
+		})
+	})
+}
+
+// slugExists returns a check for product slugs used by products other than excludeID
+func (s *ProductService) slugExists(excludeID int32) slugExistsFunc {
+	return func(ctx context.Context, slug string) (bool, error) {
+		return s.queries.ProductSlugExists(ctx, repository.ProductSlugExistsParams{
+			Slug: slug,
+			ID:   excludeID,
+		})
 	}
-	return nil
 }
 
 // GetProductsByCategoryIDs retrieves products grouped by categories based on the website settings
 func (s *ProductService) GetProductsByCategoryIDs(ctx context.Context, categoryIDs []int) ([]model.CategoryProductsResponse, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.GetProductsByCategoryIDs")
+	defer span.End()
+
 	var result []model.CategoryProductsResponse
 
 	// Get categories with their products
@@ -264,7 +410,7 @@ func (s *ProductService) GetProductsByCategoryIDs(ctx context.Context, categoryI
 		// Get category details
 		category, err := s.queries.GetCategory(ctx, int32(categoryID))
 		if err != nil {
-			if err == sql.ErrNoRows {
+			if errors.Is(err, pgx.ErrNoRows) {
 				continue // Skip if category not found
 			}
 			return nil, fmt.Errorf("error getting category: %w", err)
@@ -288,18 +434,28 @@ func (s *ProductService) GetProductsByCategoryIDs(ctx context.Context, categoryI
 				ID:                int(p.ID),
 				Name:              p.Name,
 				Slug:              p.Slug,
-				Price:             float64(p.Price),
-				PriceSale:         float64(p.PriceSale),
+				Price:             p.Price,
+				PriceSale:         p.PriceSale,
 				ImageURL:          p.ImageUrl,
 				ThumbURL:          p.ThumbUrl,
 				CreatedAt:         p.CreatedAt.Time,
 				UnitOfMeasurement: p.UnitOfMeasurement,
 			}
 		}
+		if err := localizeProductList(ctx, s.queries, modelProducts); err != nil {
+			return nil, err
+		}
+		if err := priceProductList(ctx, s.queries, modelProducts); err != nil {
+			return nil, err
+		}
+		categoryName := category.Name
+		if err := localizeCategoryName(ctx, s.queries, category.ID, &categoryName); err != nil {
+			return nil, err
+		}
 
 		// Add to result
 		result = append(result, model.CategoryProductsResponse{
-			Name:     category.Name,
+			Name:     categoryName,
 			ImageURL: category.ImageUrl.String,
 			Slug:     category.Slug,
 			Products: modelProducts,
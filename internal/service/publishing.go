@@ -0,0 +1,59 @@
+package service
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"beef-db-be/internal/model"
+)
+
+// publishState is the stored publishing state of a blog post or page
+type publishState struct {
+	status      model.PublishStatus
+	publishedAt pgtype.Timestamp
+}
+
+// resolvePublishState returns the status and publish time to store. current holds the
+// stored values on update and is nil on create. Drafts are the default for new content,
+// published content without a date is published now and scheduled content needs a
+// future date.
+func resolvePublishState(status model.PublishStatus, publishedAt *time.Time, current *publishState) (publishState, error) {
+	if status == "" {
+		if current == nil {
+			status = model.PublishStatusDraft
+		} else {
+			status = current.status
+			if publishedAt == nil {
+				publishedAt = fromPgTimestamp(current.publishedAt)
+			}
+		}
+	}
+
+	now := time.Now()
+	switch status {
+	case model.PublishStatusPublished:
+		if publishedAt == nil {
+			publishedAt = &now
+		}
+	case model.PublishStatusScheduled:
+		if publishedAt == nil {
+			return publishState{}, NewValidationError("published_at", "Scheduled content requires a publish time")
+		}
+		if !publishedAt.After(now) && (current == nil || current.status != model.PublishStatusScheduled) {
+			return publishState{}, NewValidationError("published_at", "Must be in the future for scheduled content")
+		}
+	}
+
+	return publishState{status: status, publishedAt: toPgTimestamp(publishedAt)}, nil
+}
+
+// effectiveStatus reports scheduled content whose publish time has passed as published,
+// since no background job flips the stored status
+func effectiveStatus(status string, publishedAt pgtype.Timestamp) model.PublishStatus {
+	s := model.PublishStatus(status)
+	if s == model.PublishStatusScheduled && publishedAt.Valid && !publishedAt.Time.After(time.Now()) {
+		return model.PublishStatusPublished
+	}
+	return s
+}
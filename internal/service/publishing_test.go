@@ -0,0 +1,68 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"beef-db-be/internal/model"
+)
+
+func TestResolvePublishStateStoresUTC(t *testing.T) {
+	vietnam := time.FixedZone("ICT", 7*60*60)
+	publishAt := time.Now().Add(24 * time.Hour).Truncate(time.Second).In(vietnam)
+
+	state, err := resolvePublishState(model.PublishStatusScheduled, &publishAt, nil)
+	if err != nil {
+		t.Fatalf("resolvePublishState: %v", err)
+	}
+	// TIMESTAMP columns keep the wall clock only, so it has to be the UTC one
+	if got := state.publishedAt.Time; got.Location() != time.UTC || !got.Equal(publishAt) {
+		t.Errorf("published_at = %v, want %v in UTC", got, publishAt.UTC())
+	}
+	if got := effectiveStatus(string(state.status), state.publishedAt); got != model.PublishStatusScheduled {
+		t.Errorf("status = %q, want %q until the publish time", got, model.PublishStatusScheduled)
+	}
+}
+
+func TestResolvePublishState(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	scheduled := &publishState{status: model.PublishStatusScheduled, publishedAt: toPgTimestamp(&past)}
+
+	tests := []struct {
+		name        string
+		status      model.PublishStatus
+		publishedAt *time.Time
+		current     *publishState
+		want        model.PublishStatus
+		wantErr     bool
+	}{
+		{name: "new content is a draft", want: model.PublishStatusDraft},
+		{name: "publishes now", status: model.PublishStatusPublished, want: model.PublishStatusPublished},
+		{name: "schedules", status: model.PublishStatusScheduled, publishedAt: &future, want: model.PublishStatusScheduled},
+		{name: "schedule needs a time", status: model.PublishStatusScheduled, wantErr: true},
+		{name: "schedule needs a future time", status: model.PublishStatusScheduled, publishedAt: &past, wantErr: true},
+		{name: "keeps a passed schedule", status: model.PublishStatusScheduled, publishedAt: &past, current: scheduled, want: model.PublishStatusScheduled},
+		{name: "keeps the stored status", current: scheduled, want: model.PublishStatusScheduled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, err := resolvePublishState(tt.status, tt.publishedAt, tt.current)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("resolvePublishState = %+v, want an error", state)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolvePublishState: %v", err)
+			}
+			if state.status != tt.want {
+				t.Errorf("status = %q, want %q", state.status, tt.want)
+			}
+			if tt.want != model.PublishStatusDraft && !state.publishedAt.Valid {
+				t.Errorf("published_at is not set")
+			}
+		})
+	}
+}
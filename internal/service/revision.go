@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/repository"
+	"beef-db-be/internal/utils"
+)
+
+// revisionSnapshot is the versioned content of a blog post or page
+type revisionSnapshot struct {
+	title         string
+	slug          string
+	description   string
+	content       string
+	contentFormat string
+}
+
+// recordRevision stores snapshot as the newest revision of a resource. authorID is zero
+// when the change was not made by a signed-in user.
+func recordRevision(ctx context.Context, q repository.Querier, resourceType string, id int32, snapshot revisionSnapshot, authorID int64) error {
+	_, err := q.CreateContentRevision(ctx, repository.CreateContentRevisionParams{
+		ResourceType:  resourceType,
+		ResourceID:    id,
+		Title:         snapshot.title,
+		Slug:          snapshot.slug,
+		Description:   snapshot.description,
+		Content:       snapshot.content,
+		AuthorID:      pgtype.Int8{Int64: authorID, Valid: authorID != 0},
+		ContentFormat: snapshot.contentFormat,
+	})
+	return err
+}
+
+// listRevisions returns the revisions of a resource, newest first
+func listRevisions(ctx context.Context, q repository.Querier, resourceType string, id int32, pagination model.Pagination) ([]model.ContentRevision, int64, error) {
+	totalCount, err := q.GetTotalContentRevisions(ctx, repository.GetTotalContentRevisionsParams{
+		ResourceType: resourceType,
+		ResourceID:   id,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	revisions, err := q.ListContentRevisions(ctx, repository.ListContentRevisionsParams{
+		ResourceType: resourceType,
+		ResourceID:   id,
+		Limit:        int32(pagination.GetLimit()),
+		Offset:       int32(pagination.GetOffset()),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]model.ContentRevision, len(revisions))
+	for i, revision := range revisions {
+		result[i] = toRevisionModel(revision)
+	}
+	return result, totalCount, nil
+}
+
+// getRevision returns a revision only if it belongs to the given resource
+func getRevision(ctx context.Context, q repository.Querier, resourceType string, id, revisionID int32) (repository.ContentRevision, error) {
+	revision, err := q.GetContentRevision(ctx, repository.GetContentRevisionParams{
+		ID:           revisionID,
+		ResourceType: resourceType,
+		ResourceID:   id,
+	})
+	if err != nil {
+		return repository.ContentRevision{}, WrapDBError(err, "revision")
+	}
+	return revision, nil
+}
+
+// diffRevisions compares two revisions of a resource field by field. Only fields that
+// changed are included.
+func diffRevisions(ctx context.Context, q repository.Querier, resourceType string, id, fromID, toID int32) (*model.RevisionDiff, error) {
+	from, err := getRevision(ctx, q, resourceType, id, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := getRevision(ctx, q, resourceType, id, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{"title", from.Title, to.Title},
+		{"slug", from.Slug, to.Slug},
+		{"description", from.Description, to.Description},
+		{"content", from.Content, to.Content},
+		{"content_format", from.ContentFormat, to.ContentFormat},
+	}
+
+	diff := &model.RevisionDiff{
+		FromRevisionID: int64(from.ID),
+		ToRevisionID:   int64(to.ID),
+		Fields:         []model.FieldDiff{},
+	}
+	for _, field := range fields {
+		if field.from == field.to {
+			continue
+		}
+		diff.Fields = append(diff.Fields, model.FieldDiff{
+			Field:   field.name,
+			Changes: utils.DiffLines(field.from, field.to),
+		})
+	}
+	return diff, nil
+}
+
+func toRevisionModel(revision repository.ContentRevision) model.ContentRevision {
+	return model.ContentRevision{
+		ID:            int64(revision.ID),
+		ResourceType:  revision.ResourceType,
+		ResourceID:    int64(revision.ResourceID),
+		Title:         revision.Title,
+		Slug:          revision.Slug,
+		Description:   revision.Description,
+		Content:       revision.Content,
+		ContentFormat: model.ContentFormat(revision.ContentFormat),
+		AuthorID:      fromPgInt8(revision.AuthorID),
+		CreatedAt:     revision.CreatedAt.Time,
+	}
+}
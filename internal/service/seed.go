@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/repository"
+	"beef-db-be/internal/utils"
+)
+
+// showProductCategorySetting lists the category IDs shown on the home page
+const showProductCategorySetting = "show_product_category"
+
+// SeedService prepares a database for development and tests: it creates the initial
+// admin and loads the demo catalog. Every step can be run again, entries that already
+// exist are left as they are.
+type SeedService struct {
+	queries               repository.Querier
+	tx                    repository.TxRunner
+	categoryService       *CategoryService
+	productService        *ProductService
+	pageService           *PageService
+	blogPostService       *BlogPostService
+	websiteSettingService *WebsiteSettingService
+}
+
+func NewSeedService(
+	queries repository.Querier,
+	tx repository.TxRunner,
+	categoryService *CategoryService,
+	productService *ProductService,
+	pageService *PageService,
+	blogPostService *BlogPostService,
+	websiteSettingService *WebsiteSettingService,
+) *SeedService {
+	return &SeedService{
+		queries:               queries,
+		tx:                    tx,
+		categoryService:       categoryService,
+		productService:        productService,
+		pageService:           pageService,
+		blogPostService:       blogPostService,
+		websiteSettingService: websiteSettingService,
+	}
+}
+
+// EnsureAdmin makes sure an admin account exists for email. A missing account is
+// created with password; an existing one is promoted to admin and keeps its password.
+func (s *SeedService) EnsureAdmin(ctx context.Context, email, password string) (*model.SeedAdminResult, error) {
+	ctx, span := tracer.Start(ctx, "SeedService.EnsureAdmin")
+	defer span.End()
+
+	var result *model.SeedAdminResult
+	err := s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		result = &model.SeedAdminResult{}
+		existing, err := qtx.GetUserByEmail(ctx, email)
+		id := existing.ID
+		switch {
+		case err == nil:
+			if model.Role(existing.Role) != model.RoleAdmin {
+				if err := qtx.PromoteUserToAdmin(ctx, id); err != nil {
+					return WrapDBError(err, "user")
+				}
+				result.Promoted = true
+			}
+		case isNotFound(err):
+			// The admin password follows the same rules as a sign up
+			if errs := utils.ValidateStruct(model.SignUpRequest{Email: email, Password: password}); len(errs) > 0 {
+				return NewValidationError("admin_"+errs[0].Field, errs[0].Message)
+			}
+			hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+			if err != nil {
+				return err
+			}
+			id, err = qtx.CreateAdminUser(ctx, repository.CreateAdminUserParams{
+				Email:    email,
+				Password: string(hashedPassword),
+			})
+			if err != nil {
+				return WrapDBError(err, "user")
+			}
+			result.Created = true
+		default:
+			return WrapDBError(err, "user")
+		}
+
+		user, err := getUser(ctx, qtx, id)
+		if err != nil {
+			return err
+		}
+		result.User = *user
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// LoadDemo creates the entries of catalog whose slug, or name for settings, is not
+// in the database yet. Blog posts and pages are authored by authorID when it is set.
+// The show_product_category setting is created from the category slugs unless it
+// already exists. Every entry is created in a transaction of its own, so a failed run
+// keeps the entries before the failure and running it again completes the catalog.
+func (s *SeedService) LoadDemo(ctx context.Context, catalog model.DemoCatalog, authorID int64) (*model.SeedResult, error) {
+	ctx, span := tracer.Start(ctx, "SeedService.LoadDemo")
+	defer span.End()
+
+	result := &model.SeedResult{Version: catalog.Version}
+	count := func(created bool) {
+		if created {
+			result.Created++
+		} else {
+			result.Skipped++
+		}
+	}
+
+	categoryIDs := make(map[string]int, len(catalog.Categories))
+	for i, req := range catalog.Categories {
+		if err := validateFixture(fmt.Sprintf("categories[%d]", i), req.Slug, req); err != nil {
+			return nil, err
+		}
+		category, err := s.queries.GetCategoryBySlug(ctx, req.Slug)
+		switch {
+		case err == nil:
+			categoryIDs[req.Slug] = int(category.ID)
+			count(false)
+			continue
+		case !isNotFound(err):
+			return nil, WrapDBError(err, "category")
+		}
+		created, err := s.categoryService.CreateCategory(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		categoryIDs[req.Slug] = created.ID
+		count(true)
+	}
+
+	for i, p := range catalog.Products {
+		path := fmt.Sprintf("products[%d]", i)
+		categoryID, err := s.categoryID(ctx, categoryIDs, p.CategorySlug, path+".category")
+		if err != nil {
+			return nil, err
+		}
+		req := p.CreateProductRequest
+		req.CategoryID = categoryID
+		if err := validateFixture(path, req.Slug, req); err != nil {
+			return nil, err
+		}
+		if _, err := s.queries.GetProductBySlug(ctx, req.Slug); err == nil {
+			count(false)
+			continue
+		} else if !isNotFound(err) {
+			return nil, WrapDBError(err, "product")
+		}
+		if _, err := s.productService.CreateProduct(ctx, req); err != nil {
+			return nil, err
+		}
+		count(true)
+	}
+
+	for i, req := range catalog.Pages {
+		if err := validateFixture(fmt.Sprintf("pages[%d]", i), req.Slug, req); err != nil {
+			return nil, err
+		}
+		if _, err := s.queries.GetPageBySlug(ctx, req.Slug); err == nil {
+			count(false)
+			continue
+		} else if !isNotFound(err) {
+			return nil, WrapDBError(err, "page")
+		}
+		if _, err := s.pageService.CreatePage(ctx, req, authorID); err != nil {
+			return nil, err
+		}
+		count(true)
+	}
+
+	for i, req := range catalog.BlogPosts {
+		if err := validateFixture(fmt.Sprintf("blog_posts[%d]", i), req.Slug, req); err != nil {
+			return nil, err
+		}
+		if _, err := s.queries.GetBlogPostBySlug(ctx, req.Slug); err == nil {
+			count(false)
+			continue
+		} else if !isNotFound(err) {
+			return nil, WrapDBError(err, "blog post")
+		}
+		if _, err := s.blogPostService.Create(ctx, req, authorID); err != nil {
+			return nil, err
+		}
+		count(true)
+	}
+
+	settings := slices.Clone(catalog.Settings)
+	if len(catalog.ShowProductCategory) > 0 {
+		ids := make([]int, len(catalog.ShowProductCategory))
+		for i, slug := range catalog.ShowProductCategory {
+			id, err := s.categoryID(ctx, categoryIDs, slug, fmt.Sprintf("show_product_category[%d]", i))
+			if err != nil {
+				return nil, err
+			}
+			ids[i] = id
+		}
+		value, err := json.Marshal(ids)
+		if err != nil {
+			return nil, err
+		}
+		settings = append(settings, model.CreateWebsiteSettingRequest{
+			Name:  showProductCategorySetting,
+			Value: string(value),
+		})
+	}
+
+	for i, req := range settings {
+		if errs := utils.ValidateStruct(req); len(errs) > 0 {
+			return nil, NewValidationError(fmt.Sprintf("settings[%d].%s", i, errs[0].Field), errs[0].Message)
+		}
+		if _, err := s.queries.GetWebsiteSettingByName(ctx, req.Name); err == nil {
+			count(false)
+			continue
+		} else if !isNotFound(err) {
+			return nil, WrapDBError(err, "website setting")
+		}
+		if _, err := s.websiteSettingService.Create(ctx, req); err != nil {
+			return nil, err
+		}
+		count(true)
+	}
+
+	return result, nil
+}
+
+// Reset deletes every row of every table except the migration history, leaving an
+// empty database at the current schema version
+func (s *SeedService) Reset(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "SeedService.Reset")
+	defer span.End()
+
+	if err := s.queries.TruncateAllData(ctx); err != nil {
+		return WrapDBError(err, "database")
+	}
+	return nil
+}
+
+// categoryID looks up a category by slug, first among those loaded from the catalog.
+// field names the catalog entry referring to it in the error.
+func (s *SeedService) categoryID(ctx context.Context, loaded map[string]int, slug, field string) (int, error) {
+	if id, ok := loaded[slug]; ok {
+		return id, nil
+	}
+	category, err := s.queries.GetCategoryBySlug(ctx, slug)
+	if err != nil {
+		if isNotFound(err) {
+			return 0, NewValidationError(field, fmt.Sprintf("Category %q does not exist", slug))
+		}
+		return 0, WrapDBError(err, "category")
+	}
+	return int(category.ID), nil
+}
+
+// validateFixture checks a catalog entry like a request body. Entries need an
+// explicit slug, since it is how a later run recognises them.
+func validateFixture(path, slug string, req any) error {
+	if slug == "" {
+		return NewValidationError(path+".slug", "Demo entries need a slug so they are only created once")
+	}
+	if errs := utils.ValidateStruct(req); len(errs) > 0 {
+		return NewValidationError(path+"."+errs[0].Field, errs[0].Message)
+	}
+	return nil
+}
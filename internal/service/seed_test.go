@@ -0,0 +1,181 @@
+//go:build integration
+
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"beef-db-be/internal/config"
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/pgtest"
+	"beef-db-be/internal/repository"
+	"beef-db-be/internal/service"
+	"beef-db-be/seeds"
+)
+
+func newSeedService(pool *pgxpool.Pool) *service.SeedService {
+	auth := config.AuthConfig{JWTSecret: "test-secret", TokenExpiryHours: 1}
+	queries := repository.New(pool)
+	tx := repository.NewTxRunner(pool)
+	return service.NewSeedService(queries, tx,
+		service.NewCategoryService(queries, tx),
+		service.NewProductService(queries, tx),
+		service.NewPageService(queries, tx, auth),
+		service.NewBlogPostService(queries, tx, auth),
+		service.NewWebsiteSettingService(queries, tx),
+	)
+}
+
+func TestEnsureAdmin(t *testing.T) {
+	t.Parallel()
+	pool := pgtest.NewPool(t)
+	ctx := context.Background()
+	seed := newSeedService(pool)
+
+	created, err := seed.EnsureAdmin(ctx, "admin@example.com", "secret123")
+	if err != nil {
+		t.Fatalf("EnsureAdmin: %v", err)
+	}
+	if !created.Created || created.User.Role != model.RoleAdmin {
+		t.Fatalf("EnsureAdmin on an empty database = %+v, want a created admin", created)
+	}
+
+	again, err := seed.EnsureAdmin(ctx, "admin@example.com", "another-password")
+	if err != nil {
+		t.Fatalf("EnsureAdmin again: %v", err)
+	}
+	if again.Created || again.Promoted || again.User.ID != created.User.ID {
+		t.Fatalf("EnsureAdmin again = %+v, want the same admin untouched", again)
+	}
+
+	// The first password is kept
+	users := service.NewUserService(repository.New(pool), repository.NewTxRunner(pool), config.AuthConfig{JWTSecret: "test-secret", TokenExpiryHours: 1})
+	if _, err := users.Login(ctx, model.LoginRequest{Email: "admin@example.com", Password: "secret123"}); err != nil {
+		t.Fatalf("Login with the first password: %v", err)
+	}
+}
+
+func TestEnsureAdminPromotesExistingUser(t *testing.T) {
+	t.Parallel()
+	pool := pgtest.NewPool(t)
+	ctx := context.Background()
+	users := service.NewUserService(repository.New(pool), repository.NewTxRunner(pool), config.AuthConfig{JWTSecret: "test-secret", TokenExpiryHours: 1})
+
+	user, err := users.SignUp(ctx, model.SignUpRequest{Email: "editor@example.com", Password: "secret123"})
+	if err != nil {
+		t.Fatalf("SignUp: %v", err)
+	}
+	if user.Role != model.RoleUser {
+		t.Fatalf("SignUp role = %q, want %q", user.Role, model.RoleUser)
+	}
+
+	result, err := newSeedService(pool).EnsureAdmin(ctx, "editor@example.com", "")
+	if err != nil {
+		t.Fatalf("EnsureAdmin: %v", err)
+	}
+	if !result.Promoted || result.User.ID != user.ID || result.User.Role != model.RoleAdmin {
+		t.Fatalf("EnsureAdmin = %+v, want user %d promoted", result, user.ID)
+	}
+}
+
+func TestEnsureAdminValidatesNewAccount(t *testing.T) {
+	t.Parallel()
+	pool := pgtest.NewPool(t)
+
+	_, err := newSeedService(pool).EnsureAdmin(context.Background(), "admin@example.com", "short")
+	if !errors.Is(err, service.ErrInvalidInput) {
+		t.Fatalf("EnsureAdmin with a short password: err = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestLoadDemo(t *testing.T) {
+	t.Parallel()
+	pool := pgtest.NewPool(t)
+	ctx := context.Background()
+	seed := newSeedService(pool)
+
+	catalog, err := seeds.Demo()
+	if err != nil {
+		t.Fatalf("Demo: %v", err)
+	}
+	entries := len(catalog.Categories) + len(catalog.Products) + len(catalog.Pages) +
+		len(catalog.BlogPosts) + len(catalog.Settings) + 1
+
+	first, err := seed.LoadDemo(ctx, *catalog, 0)
+	if err != nil {
+		t.Fatalf("LoadDemo: %v", err)
+	}
+	if first.Created != entries || first.Skipped != 0 {
+		t.Fatalf("LoadDemo = %+v, want %d created", first, entries)
+	}
+
+	second, err := seed.LoadDemo(ctx, *catalog, 0)
+	if err != nil {
+		t.Fatalf("LoadDemo again: %v", err)
+	}
+	if second.Created != 0 || second.Skipped != entries {
+		t.Fatalf("LoadDemo again = %+v, want %d skipped", second, entries)
+	}
+
+	// The home page setting lists the seeded categories, which hold every product
+	setting, err := service.NewWebsiteSettingService(repository.New(pool), repository.NewTxRunner(pool)).GetByName(ctx, "show_product_category")
+	if err != nil {
+		t.Fatalf("GetByName: %v", err)
+	}
+	var ids []int
+	if err := json.Unmarshal([]byte(setting.Value), &ids); err != nil {
+		t.Fatalf("show_product_category = %q: %v", setting.Value, err)
+	}
+	categories := service.NewCategoryService(repository.New(pool), repository.NewTxRunner(pool))
+	for i, slug := range catalog.ShowProductCategory {
+		category, err := categories.GetCategoryBySlug(ctx, slug)
+		if err != nil {
+			t.Fatalf("GetCategoryBySlug(%q): %v", slug, err)
+		}
+		if i >= len(ids) || ids[i] != category.ID {
+			t.Fatalf("show_product_category = %v, want category %d at %d", ids, category.ID, i)
+		}
+	}
+	grouped, err := service.NewProductService(repository.New(pool), repository.NewTxRunner(pool)).GetProductsByCategoryIDs(ctx, ids)
+	if err != nil {
+		t.Fatalf("GetProductsByCategoryIDs: %v", err)
+	}
+	total := 0
+	for _, group := range grouped {
+		total += len(group.Products)
+	}
+	if total != len(catalog.Products) {
+		t.Fatalf("products of the home page categories = %d, want %d", total, len(catalog.Products))
+	}
+}
+
+func TestReset(t *testing.T) {
+	t.Parallel()
+	pool := pgtest.NewPool(t)
+	ctx := context.Background()
+	seed := newSeedService(pool)
+
+	catalog, err := seeds.Demo()
+	if err != nil {
+		t.Fatalf("Demo: %v", err)
+	}
+	if _, err := seed.LoadDemo(ctx, *catalog, 0); err != nil {
+		t.Fatalf("LoadDemo: %v", err)
+	}
+	if err := seed.Reset(ctx); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+
+	result, err := seed.LoadDemo(ctx, *catalog, 0)
+	if err != nil {
+		t.Fatalf("LoadDemo after Reset: %v", err)
+	}
+	if result.Skipped != 0 {
+		t.Fatalf("LoadDemo after Reset = %+v, want nothing left to skip", result)
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/repository"
+)
+
+// Website settings that describe the public site
+const (
+	siteURLSetting         = "site_url"
+	siteNameSetting        = "site_name"
+	siteDescriptionSetting = "site_description"
+	robotsTxtSetting       = "robots_txt"
+)
+
+// errSiteURLNotSet is returned when absolute links are needed but neither the
+// site_url setting nor SITE_URL is set
+var errSiteURLNotSet = errors.New("site URL is not configured, set the site_url setting or SITE_URL")
+
+// toSEOModel groups the SEO columns shared by products, categories, blog posts and pages
+func toSEOModel(metaTitle, metaDescription, canonicalURL, ogImageURL string) model.SEOMetadata {
+	return model.SEOMetadata{
+		MetaTitle:       metaTitle,
+		MetaDescription: metaDescription,
+		CanonicalURL:    canonicalURL,
+		OGImageURL:      ogImageURL,
+	}
+}
+
+// settingValue returns the trimmed value of a website setting, or "" when it is not set
+func settingValue(ctx context.Context, q repository.Querier, name string) (string, error) {
+	setting, err := q.GetWebsiteSettingByName(ctx, name)
+	if err != nil {
+		if isNotFound(err) {
+			return "", nil
+		}
+		return "", WrapDBError(err, "website setting")
+	}
+	return strings.TrimSpace(setting.Value), nil
+}
+
+// resolveSiteURL returns the site_url setting without a trailing slash, or configured
+// (SITE_URL) when it is not set. Absolute links in sitemaps and feeds start with it.
+// The request's Host is never used, as these responses are cached publicly.
+func resolveSiteURL(ctx context.Context, q repository.Querier, configured string) (string, error) {
+	siteURL, err := settingValue(ctx, q, siteURLSetting)
+	if err != nil {
+		return "", err
+	}
+	if siteURL == "" {
+		siteURL = configured
+	}
+	if siteURL == "" {
+		return "", errSiteURLNotSet
+	}
+	return strings.TrimRight(siteURL, "/"), nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+
+	"beef-db-be/internal/config"
+	"beef-db-be/internal/repository"
+)
+
+// settingsQuerier serves website settings from a map; the other queries panic
+type settingsQuerier struct {
+	repository.Querier
+	settings map[string]string
+}
+
+func (q settingsQuerier) GetWebsiteSettingByName(ctx context.Context, name string) (repository.WebsiteSetting, error) {
+	value, ok := q.settings[name]
+	if !ok {
+		return repository.WebsiteSetting{}, pgx.ErrNoRows
+	}
+	return repository.WebsiteSetting{Name: name, Value: value}, nil
+}
+
+func TestResolveSiteURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		setting    string
+		configured string
+		want       string
+		wantErr    error
+	}{
+		{name: "setting wins", setting: "https://beefsupplier.store/", configured: "http://localhost:3000", want: "https://beefsupplier.store"},
+		{name: "falls back to SITE_URL", configured: "https://beefsupplier.store/", want: "https://beefsupplier.store"},
+		{name: "fails without either", wantErr: errSiteURLNotSet},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := settingsQuerier{settings: map[string]string{}}
+			if tt.setting != "" {
+				q.settings[siteURLSetting] = tt.setting
+			}
+			got, err := resolveSiteURL(context.Background(), q, tt.configured)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Errorf("resolveSiteURL = %q, %v, want %q, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetRobotsTxtPointsAtTheConfiguredSite(t *testing.T) {
+	q := settingsQuerier{settings: map[string]string{}}
+
+	robots, err := NewSitemapService(q, config.SiteConfig{URL: "https://beefsupplier.store"}).GetRobotsTxt(context.Background())
+	if err != nil {
+		t.Fatalf("GetRobotsTxt: %v", err)
+	}
+	if want := "Sitemap: https://beefsupplier.store/sitemap.xml"; !strings.Contains(robots, want) {
+		t.Errorf("robots.txt = %q, want it to contain %q", robots, want)
+	}
+
+	if _, err := NewSitemapService(q, config.SiteConfig{}).GetRobotsTxt(context.Background()); !errors.Is(err, errSiteURLNotSet) {
+		t.Errorf("GetRobotsTxt without a site URL: err = %v, want %v", err, errSiteURLNotSet)
+	}
+}
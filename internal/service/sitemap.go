@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"beef-db-be/internal/config"
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/repository"
+)
+
+// sitemapMaxURLs is the most URLs the sitemap protocol allows in one file
+const sitemapMaxURLs = 50000
+
+// Sitemap sections, in the order they are listed
+const (
+	sitemapSectionProducts   = "products"
+	sitemapSectionCategories = "categories"
+	sitemapSectionBlog       = "blog"
+	sitemapSectionPages      = "pages"
+)
+
+var sitemapSections = []string{
+	sitemapSectionProducts,
+	sitemapSectionCategories,
+	sitemapSectionBlog,
+	sitemapSectionPages,
+}
+
+type SitemapService struct {
+	queries repository.Querier
+	site    config.SiteConfig
+}
+
+func NewSitemapService(queries repository.Querier, site config.SiteConfig) *SitemapService {
+	return &SitemapService{
+		queries: queries,
+		site:    site,
+	}
+}
+
+// GetSitemap returns every public URL of the site as one sitemap. When there are more
+// URLs than fit in a sitemap, it returns an index of per-section sitemap files instead.
+// URLs start with the site_url setting, or SITE_URL when it is not set.
+func (s *SitemapService) GetSitemap(ctx context.Context) (*model.SitemapURLSet, *model.SitemapIndex, error) {
+	ctx, span := tracer.Start(ctx, "SitemapService.GetSitemap")
+	defer span.End()
+
+	siteURL, err := resolveSiteURL(ctx, s.queries, s.site.URL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	counts, err := s.queries.GetSitemapCounts(ctx)
+	if err != nil {
+		return nil, nil, WrapDBError(err, "sitemap")
+	}
+	sectionCounts := map[string]int64{
+		sitemapSectionProducts:   counts.ProductCount,
+		sitemapSectionCategories: counts.CategoryCount,
+		sitemapSectionBlog:       counts.BlogPostCount,
+		sitemapSectionPages:      counts.PageCount,
+	}
+
+	var total int64
+	for _, count := range sectionCounts {
+		total += count
+	}
+
+	if total <= sitemapMaxURLs {
+		urlset := &model.SitemapURLSet{Xmlns: model.SitemapNamespace, URLs: []model.SitemapURL{}}
+		for _, section := range sitemapSections {
+			urls, err := s.listSection(ctx, siteURL, section, sitemapMaxURLs, 0)
+			if err != nil {
+				return nil, nil, err
+			}
+			urlset.URLs = append(urlset.URLs, urls...)
+		}
+		return urlset, nil, nil
+	}
+
+	index := &model.SitemapIndex{Xmlns: model.SitemapNamespace, Sitemaps: []model.SitemapRef{}}
+	for _, section := range sitemapSections {
+		files := (sectionCounts[section] + sitemapMaxURLs - 1) / sitemapMaxURLs
+		for page := int64(1); page <= files; page++ {
+			index.Sitemaps = append(index.Sitemaps, model.SitemapRef{
+				Loc: fmt.Sprintf("%s/sitemaps/%s-%d.xml", siteURL, section, page),
+			})
+		}
+	}
+	return nil, index, nil
+}
+
+// GetSitemapSection returns one file of a section listed in the sitemap index. Pages start at 1.
+func (s *SitemapService) GetSitemapSection(ctx context.Context, section string, page int) (*model.SitemapURLSet, error) {
+	ctx, span := tracer.Start(ctx, "SitemapService.GetSitemapSection")
+	defer span.End()
+
+	if page < 1 {
+		return nil, NewNotFoundError("sitemap")
+	}
+
+	siteURL, err := resolveSiteURL(ctx, s.queries, s.site.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	urls, err := s.listSection(ctx, siteURL, section, sitemapMaxURLs, int32((page-1)*sitemapMaxURLs))
+	if err != nil {
+		return nil, err
+	}
+	if len(urls) == 0 && page > 1 {
+		return nil, NewNotFoundError("sitemap")
+	}
+
+	return &model.SitemapURLSet{Xmlns: model.SitemapNamespace, URLs: urls}, nil
+}
+
+// GetRobotsTxt returns the robots_txt setting. Without it, crawlers are kept out of the
+// API and pointed at the sitemap.
+func (s *SitemapService) GetRobotsTxt(ctx context.Context) (string, error) {
+	ctx, span := tracer.Start(ctx, "SitemapService.GetRobotsTxt")
+	defer span.End()
+
+	robots, err := settingValue(ctx, s.queries, robotsTxtSetting)
+	if err != nil {
+		return "", err
+	}
+	if robots != "" {
+		return robots + "\n", nil
+	}
+
+	siteURL, err := resolveSiteURL(ctx, s.queries, s.site.URL)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("User-agent: *\nDisallow: /api/\n\nSitemap: %s/sitemap.xml\n", siteURL), nil
+}
+
+func (s *SitemapService) listSection(ctx context.Context, siteURL, section string, limit, offset int32) ([]model.SitemapURL, error) {
+	urls := []model.SitemapURL{}
+
+	switch section {
+	case sitemapSectionProducts:
+		rows, err := s.queries.ListSitemapProducts(ctx, repository.ListSitemapProductsParams{Limit: limit, Offset: offset})
+		if err != nil {
+			return nil, WrapDBError(err, "sitemap")
+		}
+		for _, row := range rows {
+			urls = append(urls, toSitemapURL(siteURL+"/products/", row.Slug, row.UpdatedAt))
+		}
+	case sitemapSectionCategories:
+		rows, err := s.queries.ListSitemapCategories(ctx, repository.ListSitemapCategoriesParams{Limit: limit, Offset: offset})
+		if err != nil {
+			return nil, WrapDBError(err, "sitemap")
+		}
+		for _, row := range rows {
+			urls = append(urls, toSitemapURL(siteURL+"/categories/", row.Slug, row.UpdatedAt))
+		}
+	case sitemapSectionBlog:
+		rows, err := s.queries.ListSitemapBlogPosts(ctx, repository.ListSitemapBlogPostsParams{Limit: limit, Offset: offset})
+		if err != nil {
+			return nil, WrapDBError(err, "sitemap")
+		}
+		for _, row := range rows {
+			urls = append(urls, toSitemapURL(siteURL+"/blog/", row.Slug, row.UpdatedAt))
+		}
+	case sitemapSectionPages:
+		rows, err := s.queries.ListSitemapPages(ctx, repository.ListSitemapPagesParams{Limit: limit, Offset: offset})
+		if err != nil {
+			return nil, WrapDBError(err, "sitemap")
+		}
+		for _, row := range rows {
+			urls = append(urls, toSitemapURL(siteURL+"/pages/", row.Slug, row.UpdatedAt))
+		}
+	default:
+		return nil, NewNotFoundError("sitemap")
+	}
+
+	return urls, nil
+}
+
+func toSitemapURL(prefix, slug string, updatedAt pgtype.Timestamp) model.SitemapURL {
+	url := model.SitemapURL{Loc: prefix + slug}
+	if updatedAt.Valid {
+		url.LastMod = updatedAt.Time.Format("2006-01-02")
+	}
+	return url
+}
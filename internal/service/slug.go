@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+
+	"beef-db-be/internal/repository"
+	"beef-db-be/internal/utils"
+)
+
+// Resource types stored in the slug_history and content_revisions tables and in preview
+// tokens, and reported by the missing translations query
+const (
+	resourceProduct  = "product"
+	resourceCategory = "category"
+	resourceBlogPost = "blog_post"
+	resourcePage     = "page"
+)
+
+// maxSlugSuffix is the highest numeric suffix tried before giving up on a generated slug
+const maxSlugSuffix = 100
+
+// slugExistsFunc reports whether slug is already used by another resource
+type slugExistsFunc func(ctx context.Context, slug string) (bool, error)
+
+// resolveSlug returns the slug to store for a resource. A slug supplied by the client is
+// used as-is so a duplicate surfaces as a conflict. Otherwise one is generated from
+// source and, if taken, suffixed with -2, -3, ... until a free one is found.
+func resolveSlug(ctx context.Context, requested, source string, maxLen int, exists slugExistsFunc) (string, error) {
+	if requested != "" {
+		return requested, nil
+	}
+
+	base := utils.TruncateSlug(utils.Slugify(source), maxLen)
+	if base == "" {
+		return "", NewValidationError("slug", "A slug could not be generated, please provide one")
+	}
+
+	for n := 1; n <= maxSlugSuffix; n++ {
+		candidate := base
+		if n > 1 {
+			suffix := "-" + strconv.Itoa(n)
+			candidate = utils.TruncateSlug(base, maxLen-len(suffix)) + suffix
+		}
+
+		taken, err := exists(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+	}
+
+	return "", NewConflictError("slug_taken", "No free slug could be generated, please provide one")
+}
+
+// recordSlugChange keeps oldSlug as a redirect to the resource and drops any
+// history entry for newSlug, which now belongs to the resource directly
+func recordSlugChange(ctx context.Context, q repository.Querier, resourceType string, id int32, oldSlug, newSlug string) error {
+	if oldSlug == newSlug {
+		return nil
+	}
+
+	if err := q.CreateSlugHistory(ctx, repository.CreateSlugHistoryParams{
+		ResourceType: resourceType,
+		ResourceID:   id,
+		Slug:         oldSlug,
+	}); err != nil {
+		return err
+	}
+
+	return q.DeleteSlugHistory(ctx, repository.DeleteSlugHistoryParams{
+		ResourceType: resourceType,
+		Slug:         newSlug,
+	})
+}
+
+// lookupSlugHistory returns the ID of the resource that previously used slug,
+// or a not found error for resource
+func lookupSlugHistory(ctx context.Context, q repository.Querier, resourceType, resource, slug string) (int32, error) {
+	id, err := q.GetSlugHistory(ctx, repository.GetSlugHistoryParams{
+		ResourceType: resourceType,
+		Slug:         slug,
+	})
+	if err != nil {
+		return 0, WrapDBError(err, resource)
+	}
+	return id, nil
+}
+
+// isNotFound reports whether err means the row does not exist
+func isNotFound(err error) bool {
+	return errors.Is(err, pgx.ErrNoRows) || errors.Is(err, ErrNotFound)
+}
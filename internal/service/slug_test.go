@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// takenSlugs reports the slugs in the set as taken
+func takenSlugs(slugs ...string) slugExistsFunc {
+	return func(ctx context.Context, slug string) (bool, error) {
+		for _, s := range slugs {
+			if s == slug {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+func TestResolveSlug(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested string
+		source    string
+		maxLen    int
+		taken     []string
+		want      string
+	}{
+		{name: "keeps a requested slug", requested: "taken", source: "Ribeye", taken: []string{"taken"}, want: "taken"},
+		{name: "generates from the source", source: "Thịt bò Úc nhập khẩu", want: "thit-bo-uc-nhap-khau"},
+		{name: "suffixes a taken slug", source: "Ribeye", taken: []string{"ribeye"}, want: "ribeye-2"},
+		{name: "skips taken suffixes", source: "Ribeye", taken: []string{"ribeye", "ribeye-2", "ribeye-3"}, want: "ribeye-4"},
+		{name: "truncates to fit the suffix", source: "Wagyu ribeye", maxLen: 12, taken: []string{"wagyu-ribeye"}, want: "wagyu-ribe-2"},
+		{name: "drops the hyphen left by truncating", source: "Wagyu ribeye", maxLen: 8, taken: []string{"wagyu-ri"}, want: "wagyu-2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxLen := tt.maxLen
+			if maxLen == 0 {
+				maxLen = 255
+			}
+			got, err := resolveSlug(context.Background(), tt.requested, tt.source, maxLen, takenSlugs(tt.taken...))
+			if err != nil {
+				t.Fatalf("resolveSlug: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveSlug = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveSlugErrors(t *testing.T) {
+	ctx := context.Background()
+
+	if _, err := resolveSlug(ctx, "", "!!!", 255, takenSlugs()); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("slug from punctuation: err = %v, want a validation error", err)
+	}
+
+	allTaken := func(ctx context.Context, slug string) (bool, error) {
+		return strings.HasPrefix(slug, "ribeye"), nil
+	}
+	if _, err := resolveSlug(ctx, "", "Ribeye", 255, allTaken); !errors.Is(err, ErrConflict) {
+		t.Errorf("every suffix taken: err = %v, want a conflict", err)
+	}
+
+	dbErr := errors.New("connection reset")
+	failing := func(ctx context.Context, slug string) (bool, error) { return false, dbErr }
+	if _, err := resolveSlug(ctx, "", "Ribeye", 255, failing); !errors.Is(err, dbErr) {
+		t.Errorf("lookup failure: err = %v, want %v", err, dbErr)
+	}
+}
@@ -0,0 +1,7 @@
+package service
+
+import "go.opentelemetry.io/otel"
+
+// tracer starts a span for each exported service method, so a request trace shows
+// which method its time and queries belong to
+var tracer = otel.Tracer("beef-db-be/internal/service")
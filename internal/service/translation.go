@@ -0,0 +1,528 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"beef-db-be/internal/model"
+	"beef-db-be/internal/repository"
+	"beef-db-be/internal/utils"
+)
+
+// Longest translated names, matching the name columns of the translation tables
+const (
+	maxProductNameLength  = 150
+	maxCategoryNameLength = 100
+)
+
+// TranslationService manages the translations of products, categories, blog posts and
+// pages. Translated text is served by the other services through the localize helpers.
+type TranslationService struct {
+	queries repository.Querier
+	locales utils.Locales
+}
+
+func NewTranslationService(queries repository.Querier, locales utils.Locales) *TranslationService {
+	return &TranslationService{
+		queries: queries,
+		locales: locales,
+	}
+}
+
+// ListTranslations lists every translation of a resource, ordered by locale
+func (s *TranslationService) ListTranslations(ctx context.Context, resourceType string, id int64) ([]model.Translation, error) {
+	ctx, span := tracer.Start(ctx, "TranslationService.ListTranslations")
+	defer span.End()
+
+	result := []model.Translation{}
+
+	switch resourceType {
+	case resourceProduct:
+		rows, err := s.queries.ListProductTranslations(ctx, int32(id))
+		if err != nil {
+			return nil, WrapDBError(err, "translation")
+		}
+		for _, row := range rows {
+			result = append(result, toProductTranslationModel(row))
+		}
+	case resourceCategory:
+		rows, err := s.queries.ListCategoryTranslations(ctx, int32(id))
+		if err != nil {
+			return nil, WrapDBError(err, "translation")
+		}
+		for _, row := range rows {
+			result = append(result, toCategoryTranslationModel(row))
+		}
+	case resourceBlogPost:
+		rows, err := s.queries.ListBlogPostTranslations(ctx, int32(id))
+		if err != nil {
+			return nil, WrapDBError(err, "translation")
+		}
+		for _, row := range rows {
+			result = append(result, toBlogPostTranslationModel(row))
+		}
+	case resourcePage:
+		rows, err := s.queries.ListPageTranslations(ctx, int32(id))
+		if err != nil {
+			return nil, WrapDBError(err, "translation")
+		}
+		for _, row := range rows {
+			result = append(result, toPageTranslationModel(row))
+		}
+	default:
+		return nil, invalidTranslationResource()
+	}
+
+	return result, nil
+}
+
+// UpsertTranslation adds or replaces the translation of a resource in locale. The
+// default locale cannot be translated; it is the resource's own text.
+func (s *TranslationService) UpsertTranslation(ctx context.Context, resourceType string, id int64, locale string, req model.UpsertTranslationRequest) (*model.Translation, error) {
+	ctx, span := tracer.Start(ctx, "TranslationService.UpsertTranslation")
+	defer span.End()
+
+	locale, err := s.translationLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	var result model.Translation
+	switch resourceType {
+	case resourceProduct:
+		if err := requireTranslationName(req.Name, maxProductNameLength); err != nil {
+			return nil, err
+		}
+		row, err := s.queries.UpsertProductTranslation(ctx, repository.UpsertProductTranslationParams{
+			ProductID:   int32(id),
+			Locale:      locale,
+			Name:        req.Name,
+			Description: req.Description,
+		})
+		if err != nil {
+			return nil, WrapDBError(err, "translation")
+		}
+		result = toProductTranslationModel(row)
+	case resourceCategory:
+		if err := requireTranslationName(req.Name, maxCategoryNameLength); err != nil {
+			return nil, err
+		}
+		row, err := s.queries.UpsertCategoryTranslation(ctx, repository.UpsertCategoryTranslationParams{
+			CategoryID:  int32(id),
+			Locale:      locale,
+			Name:        req.Name,
+			Description: req.Description,
+		})
+		if err != nil {
+			return nil, WrapDBError(err, "translation")
+		}
+		result = toCategoryTranslationModel(row)
+	case resourceBlogPost:
+		if err := requireTranslationContent(req); err != nil {
+			return nil, err
+		}
+		row, err := s.queries.UpsertBlogPostTranslation(ctx, repository.UpsertBlogPostTranslationParams{
+			BlogPostID:  int32(id),
+			Locale:      locale,
+			Title:       req.Title,
+			Description: req.Description,
+			Content:     req.Content,
+		})
+		if err != nil {
+			return nil, WrapDBError(err, "translation")
+		}
+		result = toBlogPostTranslationModel(row)
+	case resourcePage:
+		if err := requireTranslationContent(req); err != nil {
+			return nil, err
+		}
+		row, err := s.queries.UpsertPageTranslation(ctx, repository.UpsertPageTranslationParams{
+			PageID:  int32(id),
+			Locale:  locale,
+			Title:   req.Title,
+			Content: req.Content,
+		})
+		if err != nil {
+			return nil, WrapDBError(err, "translation")
+		}
+		result = toPageTranslationModel(row)
+	default:
+		return nil, invalidTranslationResource()
+	}
+
+	return &result, nil
+}
+
+// DeleteTranslation removes the translation of a resource in locale
+func (s *TranslationService) DeleteTranslation(ctx context.Context, resourceType string, id int64, locale string) error {
+	ctx, span := tracer.Start(ctx, "TranslationService.DeleteTranslation")
+	defer span.End()
+
+	locale = strings.ToLower(locale)
+
+	var rows int64
+	var err error
+	switch resourceType {
+	case resourceProduct:
+		rows, err = s.queries.DeleteProductTranslation(ctx, repository.DeleteProductTranslationParams{ProductID: int32(id), Locale: locale})
+	case resourceCategory:
+		rows, err = s.queries.DeleteCategoryTranslation(ctx, repository.DeleteCategoryTranslationParams{CategoryID: int32(id), Locale: locale})
+	case resourceBlogPost:
+		rows, err = s.queries.DeleteBlogPostTranslation(ctx, repository.DeleteBlogPostTranslationParams{BlogPostID: int32(id), Locale: locale})
+	case resourcePage:
+		rows, err = s.queries.DeletePageTranslation(ctx, repository.DeletePageTranslationParams{PageID: int32(id), Locale: locale})
+	default:
+		return invalidTranslationResource()
+	}
+	if err != nil {
+		return WrapDBError(err, "translation")
+	}
+	if rows == 0 {
+		return NewNotFoundError("translation")
+	}
+	return nil
+}
+
+// ListMissingTranslations reports the resources that have no translation in locale,
+// or in any supported locale other than the default when locale is empty. An empty
+// resourceType covers every kind of resource.
+func (s *TranslationService) ListMissingTranslations(ctx context.Context, locale, resourceType string, pagination model.Pagination) ([]model.MissingTranslation, int64, error) {
+	ctx, span := tracer.Start(ctx, "TranslationService.ListMissingTranslations")
+	defer span.End()
+
+	var locales []string
+	if locale == "" {
+		locales = s.locales.Supported()[1:]
+	} else {
+		l, err := s.translationLocale(locale)
+		if err != nil {
+			return nil, 0, err
+		}
+		locales = []string{l}
+	}
+
+	switch resourceType {
+	case "", resourceProduct, resourceCategory, resourceBlogPost, resourcePage:
+	default:
+		return nil, 0, invalidTranslationResource()
+	}
+	resource := pgtype.Text{String: resourceType, Valid: resourceType != ""}
+
+	totalCount, err := s.queries.GetTotalMissingTranslations(ctx, repository.GetTotalMissingTranslationsParams{
+		Locales:      locales,
+		ResourceType: resource,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.queries.ListMissingTranslations(ctx, repository.ListMissingTranslationsParams{
+		Locales:      locales,
+		ResourceType: resource,
+		Limit:        int32(pagination.GetLimit()),
+		Offset:       int32(pagination.GetOffset()),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]model.MissingTranslation, len(rows))
+	for i, row := range rows {
+		result[i] = model.MissingTranslation{
+			ResourceType: row.ResourceType,
+			ResourceID:   int64(row.ResourceID),
+			Slug:         row.Slug,
+			Label:        row.Label,
+			Locale:       row.Locale,
+		}
+	}
+
+	return result, totalCount, nil
+}
+
+// translationLocale normalizes locale and checks it is a supported locale other than the default
+func (s *TranslationService) translationLocale(locale string) (string, error) {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if !s.locales.IsSupported(locale) {
+		return "", NewValidationError("locale", "Must be one of "+strings.Join(s.locales.Supported(), ", "))
+	}
+	if locale == s.locales.Default() {
+		return "", NewValidationError("locale", "The default locale is edited on the resource itself")
+	}
+	return locale, nil
+}
+
+func requireTranslationName(name string, maxLen int) error {
+	if strings.TrimSpace(name) == "" {
+		return NewValidationError("name", "Name is required")
+	}
+	if len([]rune(name)) > maxLen {
+		return NewValidationError("name", "Name is too long")
+	}
+	return nil
+}
+
+func requireTranslationContent(req model.UpsertTranslationRequest) error {
+	if strings.TrimSpace(req.Title) == "" {
+		return NewValidationError("title", "Title is required")
+	}
+	if strings.TrimSpace(req.Content) == "" {
+		return NewValidationError("content", "Content is required")
+	}
+	return nil
+}
+
+func invalidTranslationResource() error {
+	return NewValidationError("resource", "Must be one of product, category, blog_post, page")
+}
+
+// localizeProducts replaces product names and descriptions, and their category names,
+// with the translations of the request locale. Untranslated text stays in the default locale.
+func localizeProducts(ctx context.Context, q repository.Querier, products ...*model.Product) error {
+	locale := utils.TranslationLocale(ctx)
+	if locale == "" || len(products) == 0 {
+		return nil
+	}
+
+	productIDs := make([]int32, len(products))
+	categoryIDs := make([]int32, 0, len(products))
+	for i, p := range products {
+		productIDs[i] = int32(p.ID)
+		if p.CategoryID != 0 {
+			categoryIDs = append(categoryIDs, int32(p.CategoryID))
+		}
+	}
+
+	translations, err := q.ListProductTranslationsByLocale(ctx, repository.ListProductTranslationsByLocaleParams{
+		ProductIds: productIDs,
+		Locale:     locale,
+	})
+	if err != nil {
+		return err
+	}
+	byID := make(map[int32]repository.ProductTranslation, len(translations))
+	for _, t := range translations {
+		byID[t.ProductID] = t
+	}
+
+	categoryNames, err := localizedCategoryNames(ctx, q, locale, categoryIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range products {
+		if t, ok := byID[int32(p.ID)]; ok {
+			p.Name = t.Name
+			if t.Description != "" {
+				p.Description = t.Description
+			}
+		}
+		if name, ok := categoryNames[int32(p.CategoryID)]; ok {
+			p.CategoryName = name
+		}
+	}
+	return nil
+}
+
+// localizeProductList localizes every product of a list in place
+func localizeProductList(ctx context.Context, q repository.Querier, products []model.Product) error {
+	refs := make([]*model.Product, len(products))
+	for i := range products {
+		refs[i] = &products[i]
+	}
+	return localizeProducts(ctx, q, refs...)
+}
+
+// localizeCategories replaces category names and descriptions with the translations of
+// the request locale
+func localizeCategories(ctx context.Context, q repository.Querier, categories ...*model.Category) error {
+	locale := utils.TranslationLocale(ctx)
+	if locale == "" || len(categories) == 0 {
+		return nil
+	}
+
+	ids := make([]int32, len(categories))
+	for i, c := range categories {
+		ids[i] = int32(c.ID)
+	}
+	translations, err := q.ListCategoryTranslationsByLocale(ctx, repository.ListCategoryTranslationsByLocaleParams{
+		CategoryIds: ids,
+		Locale:      locale,
+	})
+	if err != nil {
+		return err
+	}
+	byID := make(map[int32]repository.CategoryTranslation, len(translations))
+	for _, t := range translations {
+		byID[t.CategoryID] = t
+	}
+
+	for _, c := range categories {
+		if t, ok := byID[int32(c.ID)]; ok {
+			c.Name = t.Name
+			if t.Description != "" {
+				c.Description = t.Description
+			}
+		}
+	}
+	return nil
+}
+
+// localizedCategoryNames returns the translated names of the given categories in locale
+func localizedCategoryNames(ctx context.Context, q repository.Querier, locale string, ids []int32) (map[int32]string, error) {
+	names := map[int32]string{}
+	if len(ids) == 0 {
+		return names, nil
+	}
+
+	translations, err := q.ListCategoryTranslationsByLocale(ctx, repository.ListCategoryTranslationsByLocaleParams{
+		CategoryIds: ids,
+		Locale:      locale,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range translations {
+		names[t.CategoryID] = t.Name
+	}
+	return names, nil
+}
+
+// localizeCategoryName replaces name with the translated name of a category in the request locale
+func localizeCategoryName(ctx context.Context, q repository.Querier, categoryID int32, name *string) error {
+	locale := utils.TranslationLocale(ctx)
+	if locale == "" {
+		return nil
+	}
+
+	names, err := localizedCategoryNames(ctx, q, locale, []int32{categoryID})
+	if err != nil {
+		return err
+	}
+	if translated, ok := names[categoryID]; ok {
+		*name = translated
+	}
+	return nil
+}
+
+// localizeBlogPosts replaces blog post text with the translations of the request locale
+// and renders the translated content in the post's content format
+func localizeBlogPosts(ctx context.Context, q repository.Querier, posts ...*model.BlogPost) error {
+	locale := utils.TranslationLocale(ctx)
+	if locale == "" || len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]int32, len(posts))
+	for i, p := range posts {
+		ids[i] = int32(p.ID)
+	}
+	translations, err := q.ListBlogPostTranslationsByLocale(ctx, repository.ListBlogPostTranslationsByLocaleParams{
+		BlogPostIds: ids,
+		Locale:      locale,
+	})
+	if err != nil {
+		return err
+	}
+	byID := make(map[int32]repository.BlogPostTranslation, len(translations))
+	for _, t := range translations {
+		byID[t.BlogPostID] = t
+	}
+
+	for _, p := range posts {
+		t, ok := byID[int32(p.ID)]
+		if !ok {
+			continue
+		}
+		p.Title = t.Title
+		if t.Description != "" {
+			p.Description = t.Description
+		}
+		p.Content = t.Content
+		p.ContentHTML = utils.RenderContent(t.Content, p.ContentFormat)
+		text := utils.PlainText(p.ContentHTML)
+		p.Excerpt = utils.Excerpt(text, blogPostExcerptLength)
+		p.ReadingTime = utils.ReadingTime(text)
+	}
+	return nil
+}
+
+// localizePages replaces page titles and content with the translations of the request locale
+func localizePages(ctx context.Context, q repository.Querier, pages ...*model.Page) error {
+	locale := utils.TranslationLocale(ctx)
+	if locale == "" || len(pages) == 0 {
+		return nil
+	}
+
+	ids := make([]int32, len(pages))
+	for i, p := range pages {
+		ids[i] = int32(p.ID)
+	}
+	translations, err := q.ListPageTranslationsByLocale(ctx, repository.ListPageTranslationsByLocaleParams{
+		PageIds: ids,
+		Locale:  locale,
+	})
+	if err != nil {
+		return err
+	}
+	byID := make(map[int32]repository.PageTranslation, len(translations))
+	for _, t := range translations {
+		byID[t.PageID] = t
+	}
+
+	for _, p := range pages {
+		if t, ok := byID[int32(p.ID)]; ok {
+			p.Title = t.Title
+			p.Content = t.Content
+			p.ContentHTML = utils.RenderContent(t.Content, p.ContentFormat)
+		}
+	}
+	return nil
+}
+
+func toProductTranslationModel(t repository.ProductTranslation) model.Translation {
+	return model.Translation{
+		ResourceType: resourceProduct,
+		ResourceID:   int64(t.ProductID),
+		Locale:       t.Locale,
+		Name:         t.Name,
+		Description:  t.Description,
+		UpdatedAt:    t.UpdatedAt.Time,
+	}
+}
+
+func toCategoryTranslationModel(t repository.CategoryTranslation) model.Translation {
+	return model.Translation{
+		ResourceType: resourceCategory,
+		ResourceID:   int64(t.CategoryID),
+		Locale:       t.Locale,
+		Name:         t.Name,
+		Description:  t.Description,
+		UpdatedAt:    t.UpdatedAt.Time,
+	}
+}
+
+func toBlogPostTranslationModel(t repository.BlogPostTranslation) model.Translation {
+	return model.Translation{
+		ResourceType: resourceBlogPost,
+		ResourceID:   int64(t.BlogPostID),
+		Locale:       t.Locale,
+		Title:        t.Title,
+		Description:  t.Description,
+		Content:      t.Content,
+		UpdatedAt:    t.UpdatedAt.Time,
+	}
+}
+
+func toPageTranslationModel(t repository.PageTranslation) model.Translation {
+	return model.Translation{
+		ResourceType: resourcePage,
+		ResourceID:   int64(t.PageID),
+		Locale:       t.Locale,
+		Title:        t.Title,
+		Content:      t.Content,
+		UpdatedAt:    t.UpdatedAt.Time,
+	}
+}
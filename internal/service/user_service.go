@@ -3,79 +3,101 @@ package service
 import (
 	"context"
 	"errors"
-	"os"
-	"time"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"golang.org/x/crypto/bcrypt"
 
+	"beef-db-be/internal/config"
+	"beef-db-be/internal/logger"
+	"beef-db-be/internal/metrics"
 	"beef-db-be/internal/model"
 	"beef-db-be/internal/repository"
+	"beef-db-be/internal/utils"
 )
 
 type UserService struct {
-	queries *repository.Queries
-	pool    *pgxpool.Pool
+	queries repository.Querier
+	tx      repository.TxRunner
+	auth    config.AuthConfig
 }
 
-func NewUserService(pool *pgxpool.Pool) *UserService {
+func NewUserService(queries repository.Querier, tx repository.TxRunner, auth config.AuthConfig) *UserService {
 	return &UserService{
-		queries: repository.New(pool),
-		pool:    pool,
+		queries: queries,
+		tx:      tx,
+		auth:    auth,
 	}
 }
 
 func (s *UserService) SignUp(ctx context.Context, req model.SignUpRequest) (*model.User, error) {
-	// Check if user already exists
-	_, err := s.queries.GetUserByEmail(ctx, req.Email)
-	if err == nil {
-		return nil, errors.New("email already exists")
-	}
-	if !errors.Is(err, pgx.ErrNoRows) {
-		return nil, err
-	}
+	ctx, span := tracer.Start(ctx, "UserService.SignUp")
+	defer span.End()
 
-	// Hash password
+	// Hash password, before the transaction since it is slow on purpose
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create user
-	result, err := s.queries.CreateUser(ctx, repository.CreateUserParams{
-		Email:    req.Email,
-		Password: string(hashedPassword),
+	var user *model.User
+	err = s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		// Check if user already exists
+		_, err := qtx.GetUserByEmail(ctx, req.Email)
+		if err == nil {
+			return NewConflictError("email_taken", "An account with this email already exists")
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		// Create user
+		id, err := qtx.CreateUser(ctx, repository.CreateUserParams{
+			Email:    req.Email,
+			Password: string(hashedPassword),
+		})
+		if err != nil {
+			return WrapDBError(err, "user")
+		}
+
+		user, err = getUser(ctx, qtx, id)
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return s.GetUser(ctx, result)
+	return user, nil
 }
 
 func (s *UserService) Login(ctx context.Context, req model.LoginRequest) (*model.LoginResponse, error) {
+	ctx, span := tracer.Start(ctx, "UserService.Login")
+	defer span.End()
+
 	// Get user by email
 	user, err := s.queries.GetUserByEmail(ctx, req.Email)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, errors.New("invalid email or password")
+			logger.FromContext(ctx).Warn("Login failed", "reason", "unknown_email")
+			metrics.LoginsTotal.WithLabelValues("failure").Inc()
+			return nil, NewUnauthorizedError("invalid_credentials", "Invalid email or password")
 		}
 		return nil, err
 	}
 
 	// Check password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		return nil, errors.New("invalid email or password")
+		logger.FromContext(ctx).Warn("Login failed", "reason", "wrong_password", "user_id", user.ID)
+		metrics.LoginsTotal.WithLabelValues("failure").Inc()
+		return nil, NewUnauthorizedError("invalid_credentials", "Invalid email or password")
 	}
 
 	// Generate JWT token
-	token, err := generateJWT(user.ID)
+	token, err := utils.GenerateJWT(s.auth, user.ID)
 	if err != nil {
 		return nil, err
 	}
 
+	metrics.LoginsTotal.WithLabelValues("success").Inc()
 	return &model.LoginResponse{
 		Token: token,
 		User: model.User{
@@ -89,12 +111,17 @@ func (s *UserService) Login(ctx context.Context, req model.LoginRequest) (*model
 }
 
 func (s *UserService) GetUser(ctx context.Context, id int64) (*model.User, error) {
-	user, err := s.queries.GetUser(ctx, id)
+	ctx, span := tracer.Start(ctx, "UserService.GetUser")
+	defer span.End()
+
+	return getUser(ctx, s.queries, id)
+}
+
+// getUser loads a user through q
+func getUser(ctx context.Context, q repository.Querier, id int64) (*model.User, error) {
+	user, err := q.GetUser(ctx, id)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, errors.New("user not found")
-		}
-		return nil, err
+		return nil, WrapDBError(err, "user")
 	}
 
 	return &model.User{
@@ -107,6 +134,9 @@ func (s *UserService) GetUser(ctx context.Context, id int64) (*model.User, error
 }
 
 func (s *UserService) ListUsers(ctx context.Context) ([]model.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.ListUsers")
+	defer span.End()
+
 	users, err := s.queries.ListUsers(ctx)
 	if err != nil {
 		return nil, err
@@ -125,31 +155,3 @@ func (s *UserService) ListUsers(ctx context.Context) ([]model.User, error) {
 
 	return result, nil
 }
-
-func generateJWT(userID int64) (string, error) {
-	// Get JWT secret from environment variable
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return "", errors.New("JWT_SECRET environment variable is not set")
-	}
-
-	// Get expiry hours from environment variable
-	expiryHours := 24 // Default to 24 hours
-	if envExpiry := os.Getenv("JWT_EXPIRY_HOURS"); envExpiry != "" {
-		if parsed, err := time.ParseDuration(envExpiry + "h"); err == nil {
-			expiryHours = int(parsed.Hours())
-		}
-	}
-
-	// Create claims
-	claims := jwt.MapClaims{
-		"user_id": userID,
-		"exp":     time.Now().Add(time.Duration(expiryHours) * time.Hour).Unix(),
-	}
-
-	// Create token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	// Sign and get the complete encoded token as a string
-	return token.SignedString([]byte(secret))
-}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"errors"
 
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5"
 
 	"beef-db-be/internal/model"
 	"beef-db-be/internal/repository"
@@ -12,37 +12,47 @@ import (
 
 // WebsiteSettingService handles business logic for website settings
 type WebsiteSettingService struct {
-	db      *pgxpool.Pool
-	queries *repository.Queries
+	queries repository.Querier
+	tx      repository.TxRunner
 }
 
 // NewWebsiteSettingService creates a new website setting service
-func NewWebsiteSettingService(db *pgxpool.Pool) *WebsiteSettingService {
+func NewWebsiteSettingService(queries repository.Querier, tx repository.TxRunner) *WebsiteSettingService {
 	return &WebsiteSettingService{
-		db:      db,
-		queries: repository.New(db),
+		queries: queries,
+		tx:      tx,
 	}
 }
 
 // Create creates a new website setting
 func (s *WebsiteSettingService) Create(ctx context.Context, req model.CreateWebsiteSettingRequest) (*model.WebsiteSettingResponse, error) {
-	// Check if setting with same name already exists
-	_, err := s.queries.GetWebsiteSettingByName(ctx, req.Name)
-	if err == nil {
-		return nil, errors.New("setting with this name already exists")
-	}
+	ctx, span := tracer.Start(ctx, "WebsiteSettingService.Create")
+	defer span.End()
+
+	var setting repository.WebsiteSetting
+	err := s.tx.InTx(ctx, func(qtx repository.Querier) error {
+		// Check if setting with same name already exists
+		_, err := qtx.GetWebsiteSettingByName(ctx, req.Name)
+		if err == nil {
+			return NewConflictError("website_setting_name_taken", "A website setting with this name already exists")
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
 
-	// Create new setting
-	id, err := s.queries.CreateWebsiteSetting(ctx, repository.CreateWebsiteSettingParams{
-		Name:  req.Name,
-		Value: req.Value,
-	})
-	if err != nil {
-		return nil, err
-	}
+		// Create new setting
+		id, err := qtx.CreateWebsiteSetting(ctx, repository.CreateWebsiteSettingParams{
+			Name:  req.Name,
+			Value: req.Value,
+		})
+		if err != nil {
+			return WrapDBError(err, "website setting")
+		}
 
-	// Get created setting
-	setting, err := s.queries.GetWebsiteSetting(ctx, id)
+		// Get created setting
+		setting, err = qtx.GetWebsiteSetting(ctx, id)
+		return WrapDBError(err, "website setting")
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -56,9 +66,12 @@ func (s *WebsiteSettingService) Create(ctx context.Context, req model.CreateWebs
 
 // Get retrieves a website setting by ID
 func (s *WebsiteSettingService) Get(ctx context.Context, id int32) (*model.WebsiteSettingResponse, error) {
+	ctx, span := tracer.Start(ctx, "WebsiteSettingService.Get")
+	defer span.End()
+
 	setting, err := s.queries.GetWebsiteSetting(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, WrapDBError(err, "website setting")
 	}
 
 	return &model.WebsiteSettingResponse{
@@ -70,9 +83,12 @@ func (s *WebsiteSettingService) Get(ctx context.Context, id int32) (*model.Websi
 
 // GetByName retrieves a website setting by name
 func (s *WebsiteSettingService) GetByName(ctx context.Context, name string) (*model.WebsiteSettingResponse, error) {
+	ctx, span := tracer.Start(ctx, "WebsiteSettingService.GetByName")
+	defer span.End()
+
 	setting, err := s.queries.GetWebsiteSettingByName(ctx, name)
 	if err != nil {
-		return nil, err
+		return nil, WrapDBError(err, "website setting")
 	}
 
 	return &model.WebsiteSettingResponse{
@@ -84,6 +100,9 @@ func (s *WebsiteSettingService) GetByName(ctx context.Context, name string) (*mo
 
 // List retrieves all website settings
 func (s *WebsiteSettingService) List(ctx context.Context) (*model.WebsiteSettingsResponse, error) {
+	ctx, span := tracer.Start(ctx, "WebsiteSettingService.List")
+	defer span.End()
+
 	settings, err := s.queries.ListWebsiteSettings(ctx)
 	if err != nil {
 		return nil, err
@@ -106,13 +125,33 @@ func (s *WebsiteSettingService) List(ctx context.Context) (*model.WebsiteSetting
 
 // Update updates a website setting
 func (s *WebsiteSettingService) Update(ctx context.Context, name string, req model.UpdateWebsiteSettingRequest) error {
-	return s.queries.UpdateWebsiteSetting(ctx, repository.UpdateWebsiteSettingParams{
+	ctx, span := tracer.Start(ctx, "WebsiteSettingService.Update")
+	defer span.End()
+
+	rows, err := s.queries.UpdateWebsiteSetting(ctx, repository.UpdateWebsiteSettingParams{
 		Value: req.Value,
 		Name:  name,
 	})
+	if err != nil {
+		return WrapDBError(err, "website setting")
+	}
+	if rows == 0 {
+		return NewNotFoundError("website setting")
+	}
+	return nil
 }
 
 // Delete deletes a website setting
 func (s *WebsiteSettingService) Delete(ctx context.Context, id int32) error {
-	return s.queries.DeleteWebsiteSetting(ctx, id)
+	ctx, span := tracer.Start(ctx, "WebsiteSettingService.Delete")
+	defer span.End()
+
+	rows, err := s.queries.DeleteWebsiteSetting(ctx, id)
+	if err != nil {
+		return WrapDBError(err, "website setting")
+	}
+	if rows == 0 {
+		return NewNotFoundError("website setting")
+	}
+	return nil
 }
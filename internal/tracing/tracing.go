@@ -0,0 +1,91 @@
+// Package tracing sets up OpenTelemetry tracing for the API.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultServiceName names the service in traces when OTEL_SERVICE_NAME is not set
+const DefaultServiceName = "beef-db-be"
+
+// Exporters accepted by Config.Exporter
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+// Config selects where spans are sent
+type Config struct {
+	// Exporter is otlp, stdout (or console) or none. With none, spans are still
+	// created so trace IDs reach logs and responses, but they are not exported.
+	Exporter string
+	// ServiceName is the service.name resource attribute
+	ServiceName string
+}
+
+// Setup installs the global tracer provider and W3C trace context propagation. The
+// OTLP exporter sends over HTTP and reads its endpoint and headers from the standard
+// OTEL_EXPORTER_OTLP_* variables. The returned function flushes and stops tracing.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = DefaultServiceName
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("error creating trace resource: %w", err)
+	}
+
+	opts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+
+	switch strings.ToLower(cfg.Exporter) {
+	case "", ExporterNone:
+	case ExporterOTLP:
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error creating OTLP exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	case ExporterStdout, "console":
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, fmt.Errorf("error creating stdout exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithSyncer(exporter))
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", cfg.Exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return provider.Shutdown, nil
+}
+
+// TraceID returns the trace ID of the span in ctx, or "" when there is none
+func TraceID(ctx context.Context) string {
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		return sc.TraceID().String()
+	}
+	return ""
+}
@@ -0,0 +1,142 @@
+package utils
+
+import (
+	"bytes"
+	"html"
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+	xhtml "golang.org/x/net/html"
+
+	"beef-db-be/internal/model"
+)
+
+// wordsPerMinute is the reading speed used for reading time estimates
+const wordsPerMinute = 200
+
+var (
+	// Raw HTML inside markdown is kept here and removed by the sanitizer instead
+	markdown = goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithRendererOptions(goldmarkhtml.WithUnsafe()),
+	)
+
+	contentPolicy = newContentPolicy()
+	textPolicy    = newTextPolicy()
+	whitespace    = regexp.MustCompile(`\s+`)
+)
+
+// newContentPolicy builds the allowlist of tags and attributes that may reach clients.
+// Anything not listed, including scripts, event handlers and inline styles, is removed.
+func newContentPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+
+	p.AllowElements(
+		"p", "br", "hr", "h1", "h2", "h3", "h4", "h5", "h6",
+		"blockquote", "pre", "code", "em", "strong", "b", "i", "u", "s", "del", "sub", "sup",
+		"ul", "ol", "li", "figure", "figcaption",
+		"table", "thead", "tbody", "tfoot", "tr", "th", "td",
+	)
+
+	p.AllowStandardURLs()
+	p.AllowURLSchemes("http", "https", "mailto")
+	p.AllowAttrs("href", "title").OnElements("a")
+	p.RequireNoFollowOnFullyQualifiedLinks(true)
+	p.AllowAttrs("src", "alt", "title", "width", "height").OnElements("img")
+
+	p.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+-]+$`)).OnElements("code")
+	p.AllowStyles("text-align").MatchingEnum("left", "center", "right").OnElements("th", "td")
+	// Task list checkboxes. Inputs of other types are removed by stripInputs.
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+
+	return p
+}
+
+// newTextPolicy strips every tag, leaving a space so words in adjacent blocks stay apart
+func newTextPolicy() *bluemonday.Policy {
+	p := bluemonday.StrictPolicy()
+	p.AddSpaceWhenStrippingTag(true)
+	return p
+}
+
+// RenderContent converts content stored in format into sanitized HTML
+func RenderContent(content string, format model.ContentFormat) string {
+	if format == model.ContentFormatMarkdown {
+		var buf bytes.Buffer
+		// Converting into a bytes.Buffer cannot fail, goldmark only returns writer errors
+		_ = markdown.Convert([]byte(content), &buf)
+		content = buf.String()
+	}
+	return stripInputs(contentPolicy.Sanitize(content))
+}
+
+// stripInputs removes the input elements of sanitized HTML that are not checkboxes.
+// The policy cannot require type="checkbox", so an input whose type it dropped would
+// otherwise reach clients as a text field.
+func stripInputs(sanitized string) string {
+	if !strings.Contains(sanitized, "<input") {
+		return sanitized
+	}
+
+	var out strings.Builder
+	z := xhtml.NewTokenizer(strings.NewReader(sanitized))
+	for {
+		tt := z.Next()
+		if tt == xhtml.ErrorToken {
+			// The input is a string, so the only error is io.EOF
+			return out.String()
+		}
+		raw := z.Raw()
+		if tt == xhtml.StartTagToken || tt == xhtml.SelfClosingTagToken {
+			if token := z.Token(); token.Data == "input" && !isCheckbox(token) {
+				continue
+			}
+		}
+		out.Write(raw)
+	}
+}
+
+func isCheckbox(token xhtml.Token) bool {
+	for _, attr := range token.Attr {
+		if attr.Key == "type" {
+			return attr.Val == "checkbox"
+		}
+	}
+	return false
+}
+
+// PlainText strips all markup from rendered HTML and collapses whitespace
+func PlainText(renderedHTML string) string {
+	text := html.UnescapeString(textPolicy.Sanitize(renderedHTML))
+	return strings.TrimSpace(whitespace.ReplaceAllString(text, " "))
+}
+
+// Excerpt shortens text to at most maxRunes characters, cutting at a word boundary
+func Excerpt(text string, maxRunes int) string {
+	if utf8.RuneCountInString(text) <= maxRunes {
+		return text
+	}
+
+	runes := []rune(text)[:maxRunes]
+	if i := strings.LastIndexByte(string(runes), ' '); i > 0 {
+		return strings.TrimRight(string(runes)[:i], " ,.;:") + "…"
+	}
+	return string(runes) + "…"
+}
+
+// ReadingTime estimates the minutes needed to read text, rounded up
+func ReadingTime(text string) int {
+	words := len(strings.Fields(text))
+	if words == 0 {
+		return 0
+	}
+	return int(math.Ceil(float64(words) / wordsPerMinute))
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"testing"
+
+	"beef-db-be/internal/model"
+)
+
+func TestRenderContentInputs(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		format  model.ContentFormat
+		want    string
+	}{
+		{
+			name:    "task list",
+			content: "- [x] done\n- [ ] todo",
+			format:  model.ContentFormatMarkdown,
+			want:    "<ul>\n<li><input checked=\"\" disabled=\"\" type=\"checkbox\"> done</li>\n<li><input disabled=\"\" type=\"checkbox\"> todo</li>\n</ul>\n",
+		},
+		{name: "text input", content: `<p><input type="text" checked>name</p>`, want: "<p>name</p>"},
+		{name: "input without a type", content: `<input checked disabled>`, want: ""},
+		{name: "checkbox keeps only its allowed attributes", content: `<input type="checkbox" name="a" checked>`, want: `<input type="checkbox" checked="">`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format := tt.format
+			if format == "" {
+				format = model.ContentFormatHTML
+			}
+			if got := RenderContent(tt.content, format); got != tt.want {
+				t.Errorf("RenderContent(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderContentSanitizes(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		format  model.ContentFormat
+		want    string
+	}{
+		{name: "scripts and event handlers", content: `<p onclick="steal()">hi<script>alert(1)</script></p>`, want: "<p>hi</p>"},
+		{name: "javascript links", content: `<a href="javascript:alert(1)">x</a>`, want: "x"},
+		{name: "external links get nofollow", content: `<a href="https://example.com" target="_blank">x</a>`, want: `<a href="https://example.com" rel="nofollow">x</a>`},
+		{name: "inline styles", content: `<p style="color:red">x</p>`, want: "<p>x</p>"},
+		{name: "table alignment", content: `<td style="text-align: center">x</td>`, want: `<td style="text-align: center">x</td>`},
+		{name: "image handlers", content: `<img src="https://example.com/a.png" onerror="steal()">`, want: `<img src="https://example.com/a.png">`},
+		{name: "iframes", content: `<iframe src="https://example.com"></iframe>`, want: ""},
+		{name: "code classes other than languages", content: `<code class="evil">x</code>`, want: "<code>x</code>"},
+		{
+			name:    "raw HTML in markdown",
+			content: "# Title\n\n<script>alert(1)</script>\n\n[x](javascript:alert(1))",
+			format:  model.ContentFormatMarkdown,
+			want:    "<h1>Title</h1>\n\n<p>x</p>\n",
+		},
+		{
+			name:    "markdown code blocks keep their language",
+			content: "```go\nfmt.Println()\n```",
+			format:  model.ContentFormatMarkdown,
+			want:    "<pre><code class=\"language-go\">fmt.Println()\n</code></pre>\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format := tt.format
+			if format == "" {
+				format = model.ContentFormatHTML
+			}
+			if got := RenderContent(tt.content, format); got != tt.want {
+				t.Errorf("RenderContent(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
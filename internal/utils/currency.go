@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"context"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// FallbackBaseCurrency is the currency prices are stored in when the base_currency
+// website setting is not set
+const FallbackBaseCurrency = "VND"
+
+// currencyDecimals lists the ISO 4217 currencies whose minor unit is not 2 decimals
+var currencyDecimals = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+type currencyContextKey struct{}
+
+// NormalizeCurrency uppercases and trims a currency code
+func NormalizeCurrency(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// IsCurrencyCode reports whether code looks like an ISO 4217 code: three letters A-Z
+func IsCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// CurrencyDecimals is how many decimals amounts in the currency are rounded to, e.g. 0 for VND
+func CurrencyDecimals(code string) int {
+	if decimals, ok := currencyDecimals[code]; ok {
+		return decimals
+	}
+	return 2
+}
+
+// ConvertAmount converts an amount in the base currency to a currency worth rate base
+// units each, rounded half away from zero to the currency's decimals
+func ConvertAmount(amount, rate decimal.Decimal, currency string) decimal.Decimal {
+	return amount.DivRound(rate, int32(CurrencyDecimals(currency)))
+}
+
+// RoundAmount rounds an amount half away from zero to the currency's decimals
+func RoundAmount(amount decimal.Decimal, currency string) decimal.Decimal {
+	return amount.Round(int32(CurrencyDecimals(currency)))
+}
+
+// WithCurrency returns a context carrying the currency prices were requested in
+func WithCurrency(ctx context.Context, currency string) context.Context {
+	return context.WithValue(ctx, currencyContextKey{}, currency)
+}
+
+// CurrencyFromContext returns the currency set by WithCurrency, or "" for the base currency
+func CurrencyFromContext(ctx context.Context) string {
+	currency, _ := ctx.Value(currencyContextKey{}).(string)
+	return currency
+}
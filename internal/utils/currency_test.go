@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestCurrencyDecimals(t *testing.T) {
+	tests := map[string]int{"VND": 0, "JPY": 0, "USD": 2, "EUR": 2, "KWD": 3, "XYZ": 2}
+	for code, want := range tests {
+		if got := CurrencyDecimals(code); got != want {
+			t.Errorf("CurrencyDecimals(%q) = %d, want %d", code, got, want)
+		}
+	}
+}
+
+func TestConvertAmount(t *testing.T) {
+	tests := []struct {
+		amount, rate string
+		currency     string
+		want         string
+	}{
+		{amount: "250000", rate: "25000", currency: "USD", want: "10"},
+		{amount: "100000", rate: "24000", currency: "USD", want: "4.17"},
+		{amount: "100000", rate: "27000", currency: "EUR", want: "3.7"},
+		// Half away from zero: 125 / 1000 is 0.125
+		{amount: "125", rate: "1000", currency: "USD", want: "0.13"},
+		{amount: "-125", rate: "1000", currency: "USD", want: "-0.13"},
+		{amount: "100000", rate: "170", currency: "JPY", want: "588"},
+		{amount: "100000", rate: "80000", currency: "KWD", want: "1.25"},
+		{amount: "100001", rate: "3", currency: "KWD", want: "33333.667"},
+	}
+	for _, tt := range tests {
+		got := ConvertAmount(decimal.RequireFromString(tt.amount), decimal.RequireFromString(tt.rate), tt.currency)
+		if !got.Equal(decimal.RequireFromString(tt.want)) {
+			t.Errorf("ConvertAmount(%s, %s, %s) = %s, want %s", tt.amount, tt.rate, tt.currency, got, tt.want)
+		}
+	}
+}
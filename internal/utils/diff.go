@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"strings"
+
+	"beef-db-be/internal/model"
+)
+
+// DiffLines returns a line-based diff turning a into b, built from the longest common
+// subsequence of their lines. Common leading and trailing lines are stripped first so
+// small edits to long content stay cheap.
+func DiffLines(a, b string) []model.DiffLine {
+	x := strings.Split(a, "\n")
+	y := strings.Split(b, "\n")
+
+	prefix := 0
+	for prefix < len(x) && prefix < len(y) && x[prefix] == y[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(x)-prefix && suffix < len(y)-prefix &&
+		x[len(x)-1-suffix] == y[len(y)-1-suffix] {
+		suffix++
+	}
+
+	diff := make([]model.DiffLine, 0, len(x)+len(y))
+	for _, line := range x[:prefix] {
+		diff = append(diff, model.DiffLine{Op: model.DiffOpEqual, Text: line})
+	}
+	diff = append(diff, diffMiddle(x[prefix:len(x)-suffix], y[prefix:len(y)-suffix])...)
+	for _, line := range x[len(x)-suffix:] {
+		diff = append(diff, model.DiffLine{Op: model.DiffOpEqual, Text: line})
+	}
+	return diff
+}
+
+// maxDiffCells caps the LCS table at 16 MiB. Larger changes, e.g. two unrelated
+// revisions of a long post, are shown as the old lines replaced by the new ones.
+const maxDiffCells = 4 << 20
+
+// diffMiddle diffs the lines left after trimming with a classic LCS table
+func diffMiddle(x, y []string) []model.DiffLine {
+	if (len(x)+1)*(len(y)+1) > maxDiffCells {
+		return replaceLines(x, y)
+	}
+
+	// lcs[i][j] is the LCS length of x[i:] and y[j:]
+	lcs := make([][]int32, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	diff := make([]model.DiffLine, 0, len(x)+len(y))
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			diff = append(diff, model.DiffLine{Op: model.DiffOpEqual, Text: x[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, model.DiffLine{Op: model.DiffOpDelete, Text: x[i]})
+			i++
+		default:
+			diff = append(diff, model.DiffLine{Op: model.DiffOpInsert, Text: y[j]})
+			j++
+		}
+	}
+	for ; i < len(x); i++ {
+		diff = append(diff, model.DiffLine{Op: model.DiffOpDelete, Text: x[i]})
+	}
+	for ; j < len(y); j++ {
+		diff = append(diff, model.DiffLine{Op: model.DiffOpInsert, Text: y[j]})
+	}
+	return diff
+}
+
+// replaceLines is the diff deleting every line of x, then inserting every line of y
+func replaceLines(x, y []string) []model.DiffLine {
+	diff := make([]model.DiffLine, 0, len(x)+len(y))
+	for _, line := range x {
+		diff = append(diff, model.DiffLine{Op: model.DiffOpDelete, Text: line})
+	}
+	for _, line := range y {
+		diff = append(diff, model.DiffLine{Op: model.DiffOpInsert, Text: line})
+	}
+	return diff
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"beef-db-be/internal/model"
+)
+
+// render writes a diff as lines prefixed by " ", "+" or "-"
+func render(diff []model.DiffLine) string {
+	prefixes := map[model.DiffOp]string{model.DiffOpEqual: " ", model.DiffOpInsert: "+", model.DiffOpDelete: "-"}
+	var b strings.Builder
+	for _, line := range diff {
+		b.WriteString(prefixes[line.Op] + line.Text + "\n")
+	}
+	return b.String()
+}
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{name: "equal", a: "a\nb", b: "a\nb", want: " a\n b\n"},
+		{name: "insert", a: "a\nc", b: "a\nb\nc", want: " a\n+b\n c\n"},
+		{name: "delete", a: "a\nb\nc", b: "a\nc", want: " a\n-b\n c\n"},
+		{name: "change", a: "a\nb\nc", b: "a\nx\nc", want: " a\n-b\n+x\n c\n"},
+		{name: "keeps common lines in the middle", a: "x\na\nb\ny", b: "z\na\nb\nw", want: "-x\n+z\n a\n b\n-y\n+w\n"},
+		{name: "from empty", a: "", b: "a", want: "-\n+a\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := render(DiffLines(tt.a, tt.b)); got != tt.want {
+				t.Errorf("DiffLines(%q, %q) =\n%s\nwant\n%s", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffMiddleReplacesLargeChanges(t *testing.T) {
+	// Just above the cap, with one line in common that a full diff would keep
+	n := 2100
+	x := make([]string, n)
+	y := make([]string, n)
+	for i := range x {
+		x[i] = "old " + strconv.Itoa(i)
+		y[i] = "new " + strconv.Itoa(i)
+	}
+	x[n/2], y[n/2] = "same", "same"
+
+	diff := diffMiddle(x, y)
+	if len(diff) != 2*n {
+		t.Fatalf("len(diff) = %d, want %d", len(diff), 2*n)
+	}
+	for i, line := range diff {
+		want := model.DiffOpDelete
+		if i >= n {
+			want = model.DiffOpInsert
+		}
+		if line.Op != want {
+			t.Fatalf("diff[%d].Op = %s, want %s", i, line.Op, want)
+		}
+	}
+}
@@ -3,103 +3,71 @@ package utils
 import (
 	"fmt"
 	"net/http"
-	"os"
-	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
-)
 
-const (
-	// TokenCookieName is the name of the cookie that stores the JWT token
-	TokenCookieName = "auth_token"
-	// TokenExpiry is the duration for which the token is valid
-	TokenExpiry = 24 * time.Hour
+	"beef-db-be/internal/config"
 )
 
+// TokenCookieName is the name of the cookie that stores the JWT token
+const TokenCookieName = "auth_token"
+
 // Claims represents the JWT claims structure
 type Claims struct {
 	UserID int64 `json:"user_id"`
 	jwt.RegisteredClaims
 }
 
-// isProduction returns true if the application is running in production mode
-func isProduction() bool {
-	return strings.Contains(os.Getenv("ALLOWED_ORIGINS"), "beefsupplier.store")
-}
-
-// GenerateJWT creates a new JWT token for a user
-func GenerateJWT(userID int64) (string, error) {
-	// Get JWT secret from environment variable
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return "", fmt.Errorf("JWT_SECRET environment variable is not set")
-	}
-
+// GenerateJWT creates a new JWT token for a user, valid for the configured token expiry
+func GenerateJWT(cfg config.AuthConfig, userID int64) (string, error) {
 	claims := Claims{
 		UserID: userID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(TokenExpiry)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(cfg.TokenExpiry())),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
+	return token.SignedString([]byte(cfg.JWTSecret))
 }
 
-// SetJWTCookie sets the JWT token as an HTTP-only cookie
-func SetJWTCookie(w http.ResponseWriter, token string) {
-	isProd := isProduction()
-	fmt.Println("isProd", isProd)
+// SetJWTCookie sets the JWT token as an HTTP-only cookie on the configured domain
+func SetJWTCookie(w http.ResponseWriter, cfg config.AuthConfig, token string) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     TokenCookieName,
 		Value:    token,
 		Path:     "/",
 		HttpOnly: true,
-		Secure:   isProd,               // Enable Secure flag in production (HTTPS)
-		SameSite: http.SameSiteLaxMode, // Use Strict in production, None in development
-		Domain:   getDomain(isProd),    // Set domain in production
-		MaxAge:   int(TokenExpiry.Seconds()),
+		Secure:   cfg.CookieSecure, // Enable in production (HTTPS)
+		SameSite: http.SameSiteLaxMode,
+		Domain:   cfg.CookieDomain, // e.g. the root domain to share the cookie between subdomains
+		MaxAge:   int(cfg.TokenExpiry().Seconds()),
 	})
 }
 
-// getDomain returns the appropriate domain based on the environment
-func getDomain(isProd bool) string {
-	if isProd {
-		return ".beefsupplier.store" // Root domain for sharing cookies between subdomains
-	}
-	return "localhost" // For local development
-}
-
 // ClearJWTCookie removes the JWT cookie
-func ClearJWTCookie(w http.ResponseWriter) {
-	isProd := isProduction()
-
+func ClearJWTCookie(w http.ResponseWriter, cfg config.AuthConfig) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     TokenCookieName,
 		Value:    "",
 		Path:     "/",
 		HttpOnly: true,
-		Secure:   isProd,
+		Secure:   cfg.CookieSecure,
 		SameSite: http.SameSiteLaxMode,
-		Domain:   getDomain(isProd),
+		Domain:   cfg.CookieDomain,
 		MaxAge:   -1,
 	})
 }
 
 // ValidateJWT validates the JWT token and returns the claims
-func ValidateJWT(tokenString string) (*Claims, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return nil, fmt.Errorf("JWT_SECRET environment variable is not set")
-	}
-
+func ValidateJWT(cfg config.AuthConfig, tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return []byte(secret), nil
+		return []byte(cfg.JWTSecret), nil
 	})
 
 	if err != nil {
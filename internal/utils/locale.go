@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"beef-db-be/internal/config"
+)
+
+type localeContextKey struct{}
+
+// Locales holds the supported content locales. The default locale is the one stored in
+// the entity tables themselves; the others are served from translations.
+type Locales struct {
+	supported []string
+}
+
+// NewLocales normalizes the configured locales, always listing the default locale first
+func NewLocales(cfg config.LocaleConfig) Locales {
+	locales := []string{normalizeLocale(cfg.Default)}
+	for _, part := range cfg.Supported {
+		locale := normalizeLocale(part)
+		if locale != "" && !slices.Contains(locales, locale) {
+			locales = append(locales, locale)
+		}
+	}
+	return Locales{supported: locales}
+}
+
+// Default returns the default locale
+func (l Locales) Default() string {
+	return l.supported[0]
+}
+
+// Supported lists every supported locale, starting with the default locale
+func (l Locales) Supported() []string {
+	return slices.Clone(l.supported)
+}
+
+// IsSupported reports whether locale is one of the supported locales
+func (l Locales) IsSupported(locale string) bool {
+	return slices.Contains(l.supported, normalizeLocale(locale))
+}
+
+// Negotiate picks the locale of a request: lang when it is supported, then the
+// supported Accept-Language entry with the highest quality, then the default locale.
+// Regions are ignored, so en-US matches en.
+func (l Locales) Negotiate(lang, acceptLanguage string) string {
+	if locale := normalizeLocale(lang); locale != "" && l.IsSupported(locale) {
+		return locale
+	}
+
+	type candidate struct {
+		locale  string
+		quality float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if locale := normalizeLocale(tag); locale != "" && quality > 0 {
+			candidates = append(candidates, candidate{locale: locale, quality: quality})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	for _, c := range candidates {
+		if l.IsSupported(c.locale) {
+			return c.locale
+		}
+	}
+	return l.Default()
+}
+
+// requestLocale is the locale of a request and whether it is the default locale
+type requestLocale struct {
+	locale    string
+	isDefault bool
+}
+
+// WithLocale returns a context carrying the locale content should be served in
+func WithLocale(ctx context.Context, locales Locales, locale string) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, requestLocale{
+		locale:    locale,
+		isDefault: locale == locales.Default(),
+	})
+}
+
+// LocaleFromContext returns the locale set by WithLocale, or "" when none was set
+func LocaleFromContext(ctx context.Context) string {
+	rl, _ := ctx.Value(localeContextKey{}).(requestLocale)
+	return rl.locale
+}
+
+// TranslationLocale returns the locale set by WithLocale when content has to be
+// translated into it, or "" when content is served in the default locale
+func TranslationLocale(ctx context.Context) string {
+	if rl, ok := ctx.Value(localeContextKey{}).(requestLocale); ok && !rl.isDefault {
+		return rl.locale
+	}
+	return ""
+}
+
+// normalizeLocale lowercases a language tag and drops its region, e.g. en-US becomes en
+func normalizeLocale(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if base, _, found := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-"); found {
+		tag = base
+	}
+	if tag == "*" {
+		return ""
+	}
+	return tag
+}
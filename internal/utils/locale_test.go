@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"testing"
+
+	"beef-db-be/internal/config"
+)
+
+func TestNegotiate(t *testing.T) {
+	locales := NewLocales(config.LocaleConfig{Default: "vi", Supported: []string{"vi", "en", "ja"}})
+
+	tests := []struct {
+		name           string
+		lang           string
+		acceptLanguage string
+		want           string
+	}{
+		{name: "default without preferences", want: "vi"},
+		{name: "lang wins", lang: "en", acceptLanguage: "ja", want: "en"},
+		{name: "lang ignores the region and case", lang: "EN_us", want: "en"},
+		{name: "unsupported lang falls back to the header", lang: "fr", acceptLanguage: "ja", want: "ja"},
+		{name: "highest quality", acceptLanguage: "en;q=0.5, ja;q=0.8", want: "ja"},
+		{name: "order breaks ties", acceptLanguage: "ja, en", want: "ja"},
+		{name: "skips unsupported entries", acceptLanguage: "fr-FR, de;q=0.9, en-GB;q=0.8", want: "en"},
+		{name: "q=0 rules a locale out", acceptLanguage: "en;q=0, fr", want: "vi"},
+		{name: "malformed quality is ignored", acceptLanguage: "en;q=high, ja;q=0.1", want: "ja"},
+		{name: "wildcard is the default", acceptLanguage: "*", want: "vi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := locales.Negotiate(tt.lang, tt.acceptLanguage); got != tt.want {
+				t.Errorf("Negotiate(%q, %q) = %q, want %q", tt.lang, tt.acceptLanguage, got, tt.want)
+			}
+		})
+	}
+}
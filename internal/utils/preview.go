@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"beef-db-be/internal/config"
+)
+
+const (
+	// PreviewTokenExpiry is how long a preview link for unpublished content stays valid
+	PreviewTokenExpiry = 7 * 24 * time.Hour
+
+	// previewAudience separates preview tokens from login tokens signed with the same secret
+	previewAudience = "preview"
+)
+
+// PreviewClaims identifies the content a preview token grants access to
+type PreviewClaims struct {
+	Resource   string `json:"resource"`
+	ResourceID int64  `json:"resource_id"`
+	jwt.RegisteredClaims
+}
+
+// GeneratePreviewToken creates a signed token that allows viewing one unpublished
+// resource, e.g. ("blog_post", 12), until it expires
+func GeneratePreviewToken(cfg config.AuthConfig, resource string, resourceID int64) (string, time.Time, error) {
+	expiresAt := time.Now().Add(PreviewTokenExpiry)
+	claims := PreviewClaims{
+		Resource:   resource,
+		ResourceID: resourceID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{previewAudience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(cfg.JWTSecret))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// ValidatePreviewToken validates a preview token and returns its claims
+func ValidatePreviewToken(cfg config.AuthConfig, tokenString string) (*PreviewClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &PreviewClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(cfg.JWTSecret), nil
+	}, jwt.WithAudience(previewAudience))
+	if err != nil {
+		return nil, err
+	}
+
+	if claims, ok := token.Claims.(*PreviewClaims); ok && token.Valid {
+		return claims, nil
+	}
+
+	return nil, fmt.Errorf("invalid preview token")
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Slugify converts a name or title into a URL slug. Diacritics are removed so
+// Vietnamese text is transliterated, e.g. "Thịt bò Úc nhập khẩu" -> "thit-bo-uc-nhap-khau".
+func Slugify(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	hyphen := false
+	for _, r := range norm.NFD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Combining marks left over from decomposition, e.g. the accents in "ệ"
+			continue
+		case r == 'đ' || r == 'Đ':
+			// đ has no decomposition so it is mapped explicitly
+			r = 'd'
+		}
+
+		r = unicode.ToLower(r)
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			hyphen = false
+			b.WriteRune(r)
+			continue
+		}
+		hyphen = true
+	}
+
+	return b.String()
+}
+
+// TruncateSlug shortens slug to at most maxLen bytes without leaving a trailing hyphen
+func TruncateSlug(slug string, maxLen int) string {
+	if len(slug) <= maxLen {
+		return slug
+	}
+	return strings.TrimRight(slug[:maxLen], "-")
+}
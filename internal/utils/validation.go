@@ -0,0 +1,268 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
+
+	"beef-db-be/internal/model"
+)
+
+// MaxRequestBodySize is the largest JSON body accepted by DecodeAndValidate
+const MaxRequestBodySize = 1 << 20 // 1 MB
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	// Report JSON field names instead of Go struct field names
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	// slug: lowercase letters, digits and single hyphens, e.g. "ribeye-steak"
+	v.RegisterValidation("slug", func(fl validator.FieldLevel) bool {
+		return slugPattern.MatchString(fl.Field().String())
+	})
+
+	// weburl: an absolute http(s) URL or a root-relative path such as "/uploads/beef.jpg"
+	v.RegisterValidation("weburl", func(fl validator.FieldLevel) bool {
+		return IsWebURL(fl.Field().String())
+	})
+
+	// Money: rules such as gt=0 and ltfield=Price compare decimals by their numeric value
+	v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		if d, ok := field.Interface().(decimal.Decimal); ok {
+			return d.InexactFloat64()
+		}
+		return nil
+	}, decimal.Decimal{})
+
+	return v
+}
+
+// IsWebURL reports whether s is an absolute http(s) URL or a root-relative path
+func IsWebURL(s string) bool {
+	if strings.HasPrefix(s, "/") && !strings.HasPrefix(s, "//") {
+		_, err := url.ParseRequestURI(s)
+		return err == nil
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// IsSlug reports whether s is a valid slug
+func IsSlug(s string) bool {
+	return slugPattern.MatchString(s)
+}
+
+// DecodeAndValidate decodes the JSON request body into dst and validates it using its
+// `validate` struct tags. Unknown fields and bodies larger than MaxRequestBodySize are
+// rejected. On failure an error response is written and false is returned.
+func DecodeAndValidate(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxRequestBodySize)
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		status, verr := decodeError(err)
+		code := "invalid_body"
+		if status == http.StatusRequestEntityTooLarge {
+			code = "body_too_large"
+		}
+		SendResponse(w, status,
+			model.NewCodedErrorResponse(code, "Invalid request body", []model.ValidationError{verr}))
+		return false
+	}
+
+	// Only a single JSON value is allowed in the body
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		SendResponse(w, http.StatusBadRequest,
+			model.NewCodedErrorResponse("invalid_body", "Invalid request body", []model.ValidationError{
+				model.NewValidationError("body", "Request body must contain a single JSON object"),
+			}))
+		return false
+	}
+
+	if errs := ValidateStruct(dst); len(errs) > 0 {
+		SendResponse(w, http.StatusBadRequest,
+			model.NewCodedErrorResponse("validation_failed", "Validation failed", errs))
+		return false
+	}
+
+	return true
+}
+
+// ValidateStruct validates s using its `validate` struct tags and returns one
+// ValidationError per failing field, or nil if s is valid
+func ValidateStruct(s interface{}) []model.ValidationError {
+	err := validate.Struct(s)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return []model.ValidationError{model.NewValidationError("body", err.Error())}
+	}
+
+	result := make([]model.ValidationError, len(fieldErrs))
+	for i, fe := range fieldErrs {
+		result[i] = model.NewValidationError(fieldPath(fe), fieldMessage(fe))
+	}
+	return result
+}
+
+// fieldPath returns the JSON path of the failing field without the root struct name,
+// e.g. "items[0].quantity"
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		return ns[i+1:]
+	}
+	return fe.Field()
+}
+
+// fieldMessage returns a human readable message for a validation failure
+func fieldMessage(fe validator.FieldError) string {
+	param := fe.Param()
+	isString := fe.Kind() == reflect.String
+	isCollection := fe.Kind() == reflect.Slice || fe.Kind() == reflect.Map
+
+	switch fe.Tag() {
+	case "required", "required_if", "required_with", "required_without":
+		return "This field is required"
+	case "email":
+		return "Must be a valid email address"
+	case "slug":
+		return "Must contain only lowercase letters, numbers and hyphens"
+	case "url", "weburl":
+		return "Must be a valid URL"
+	case "oneof":
+		return fmt.Sprintf("Must be one of: %s", strings.Join(strings.Fields(param), ", "))
+	case "min":
+		if isString {
+			return fmt.Sprintf("Must be at least %s characters long", param)
+		}
+		if isCollection {
+			return fmt.Sprintf("Must contain at least %s items", param)
+		}
+		return fmt.Sprintf("Must be at least %s", param)
+	case "max":
+		if isString {
+			return fmt.Sprintf("Must be at most %s characters long", param)
+		}
+		if isCollection {
+			return fmt.Sprintf("Must contain at most %s items", param)
+		}
+		return fmt.Sprintf("Must be at most %s", param)
+	case "len":
+		return fmt.Sprintf("Must be exactly %s characters long", param)
+	case "gt":
+		return fmt.Sprintf("Must be greater than %s", param)
+	case "gte":
+		return fmt.Sprintf("Must be greater than or equal to %s", param)
+	case "lt":
+		return fmt.Sprintf("Must be less than %s", param)
+	case "lte":
+		return fmt.Sprintf("Must be less than or equal to %s", param)
+	case "gtfield":
+		return fmt.Sprintf("Must be greater than %s", toSnakeCase(param))
+	case "gtefield":
+		return fmt.Sprintf("Must be greater than or equal to %s", toSnakeCase(param))
+	case "ltfield":
+		return fmt.Sprintf("Must be less than %s", toSnakeCase(param))
+	case "ltefield":
+		return fmt.Sprintf("Must be less than or equal to %s", toSnakeCase(param))
+	default:
+		return fmt.Sprintf("Failed on the '%s' rule", fe.Tag())
+	}
+}
+
+// toSnakeCase converts a Go field name such as "PriceSale" to its JSON form "price_sale"
+func toSnakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// jsonTypeName describes a Go type using JSON terminology
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// decodeError converts a JSON decoding error into a status code and a field-level error
+func decodeError(err error) (int, model.ValidationError) {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return http.StatusRequestEntityTooLarge, model.NewValidationError("body",
+			fmt.Sprintf("Request body must not be larger than %d bytes", maxBytesErr.Limit))
+	case errors.As(err, &syntaxErr):
+		return http.StatusBadRequest, model.NewValidationError("body",
+			fmt.Sprintf("Malformed JSON at position %d", syntaxErr.Offset))
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return http.StatusBadRequest, model.NewValidationError("body", "Malformed JSON")
+	case errors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			field = "body"
+		}
+		return http.StatusBadRequest, model.NewValidationError(field,
+			fmt.Sprintf("Must be a %s", jsonTypeName(typeErr.Type)))
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return http.StatusBadRequest, model.NewValidationError(field, "Unknown field")
+	case errors.Is(err, io.EOF):
+		return http.StatusBadRequest, model.NewValidationError("body", "Request body must not be empty")
+	default:
+		return http.StatusBadRequest, model.NewValidationError("body", "Invalid JSON format")
+	}
+}
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS update_coupons_updated_at ON coupons;
+
+DROP TABLE IF EXISTS coupon_redemptions;
+
+DROP TABLE IF EXISTS coupon_products;
+
+DROP TABLE IF EXISTS coupon_categories;
+
+DROP TABLE IF EXISTS coupons;
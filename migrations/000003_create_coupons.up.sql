@@ -0,0 +1,51 @@
+-- Coupons Table
+CREATE TABLE coupons (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    discount_type VARCHAR(10) NOT NULL CHECK (discount_type IN ('percentage', 'fixed')),
+    discount_value DECIMAL(10, 2) NOT NULL CHECK (discount_value > 0),
+    max_discount DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    min_order_value DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    usage_limit INTEGER NOT NULL DEFAULT 0,
+    usage_limit_per_user INTEGER NOT NULL DEFAULT 0,
+    starts_at TIMESTAMP,
+    expires_at TIMESTAMP,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_coupons_code ON coupons (code);
+
+CREATE TRIGGER update_coupons_updated_at
+    BEFORE UPDATE ON coupons
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Coupon category restrictions
+CREATE TABLE coupon_categories (
+    coupon_id INTEGER NOT NULL REFERENCES coupons (id) ON DELETE CASCADE,
+    category_id INTEGER NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    PRIMARY KEY (coupon_id, category_id)
+);
+
+-- Coupon product restrictions
+CREATE TABLE coupon_products (
+    coupon_id INTEGER NOT NULL REFERENCES coupons (id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    PRIMARY KEY (coupon_id, product_id)
+);
+
+-- Coupon redemptions, used to enforce global and per-user usage limits
+CREATE TABLE coupon_redemptions (
+    id SERIAL PRIMARY KEY,
+    coupon_id INTEGER NOT NULL REFERENCES coupons (id) ON DELETE CASCADE,
+    user_id BIGINT REFERENCES users (id) ON DELETE SET NULL,
+    order_reference VARCHAR(100) NOT NULL DEFAULT '',
+    discount_amount DECIMAL(10, 2) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_coupon_redemptions_coupon_id ON coupon_redemptions (coupon_id);
+CREATE INDEX idx_coupon_redemptions_user_id ON coupon_redemptions (user_id);
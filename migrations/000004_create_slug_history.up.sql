@@ -0,0 +1,12 @@
+-- Slug History Table
+-- Keeps previous slugs of products, blog posts and pages so old links can be redirected
+CREATE TABLE slug_history (
+    id SERIAL PRIMARY KEY,
+    resource_type VARCHAR(20) NOT NULL CHECK (resource_type IN ('product', 'blog_post', 'page')),
+    resource_id INTEGER NOT NULL,
+    slug VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (resource_type, slug)
+);
+
+CREATE INDEX idx_slug_history_resource ON slug_history (resource_type, resource_id);
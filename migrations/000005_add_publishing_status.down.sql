@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_pages_status_published_at;
+
+ALTER TABLE pages
+    DROP COLUMN IF EXISTS published_at,
+    DROP COLUMN IF EXISTS status;
+
+DROP INDEX IF EXISTS idx_blog_posts_status_published_at;
+
+ALTER TABLE blog_posts
+    DROP COLUMN IF EXISTS published_at,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,17 @@
+-- Publishing workflow for blog posts and pages
+-- Existing content was already public, so it is marked as published
+ALTER TABLE blog_posts
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'scheduled', 'published', 'archived')),
+    ADD COLUMN published_at TIMESTAMP;
+
+UPDATE blog_posts SET status = 'published', published_at = created_at;
+
+CREATE INDEX idx_blog_posts_status_published_at ON blog_posts (status, published_at);
+
+ALTER TABLE pages
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'scheduled', 'published', 'archived')),
+    ADD COLUMN published_at TIMESTAMP;
+
+UPDATE pages SET status = 'published', published_at = created_at;
+
+CREATE INDEX idx_pages_status_published_at ON pages (status, published_at);
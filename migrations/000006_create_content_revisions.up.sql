@@ -0,0 +1,22 @@
+-- Content Revisions Table
+-- Stores every saved version of blog posts and pages so edits can be reviewed and undone
+CREATE TABLE content_revisions (
+    id SERIAL PRIMARY KEY,
+    resource_type VARCHAR(20) NOT NULL CHECK (resource_type IN ('blog_post', 'page')),
+    resource_id INTEGER NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    content TEXT NOT NULL,
+    author_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_content_revisions_resource ON content_revisions (resource_type, resource_id);
+
+-- Existing content starts with one revision holding its current version
+INSERT INTO content_revisions (resource_type, resource_id, title, slug, description, content, created_at)
+SELECT 'blog_post', id, title, slug, description, content, created_at FROM blog_posts;
+
+INSERT INTO content_revisions (resource_type, resource_id, title, slug, content, created_at)
+SELECT 'page', id, title, slug, content, created_at FROM pages;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS blog_post_tags;
+DROP TABLE IF EXISTS blog_post_categories;
+DROP TABLE IF EXISTS blog_tags;
+DROP TABLE IF EXISTS blog_categories;
+
+DROP INDEX IF EXISTS idx_blog_posts_author_id;
+ALTER TABLE blog_posts DROP COLUMN IF EXISTS author_id;
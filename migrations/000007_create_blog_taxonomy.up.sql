@@ -0,0 +1,39 @@
+-- Blog post authors
+ALTER TABLE blog_posts
+    ADD COLUMN author_id BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_blog_posts_author_id ON blog_posts (author_id);
+
+-- Blog Categories Table
+CREATE TABLE blog_categories (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(150) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Blog Tags Table
+CREATE TABLE blog_tags (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(150) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Blog post to category and tag links
+CREATE TABLE blog_post_categories (
+    blog_post_id INTEGER NOT NULL REFERENCES blog_posts(id) ON DELETE CASCADE,
+    category_id INTEGER NOT NULL REFERENCES blog_categories(id) ON DELETE CASCADE,
+    PRIMARY KEY (blog_post_id, category_id)
+);
+
+CREATE TABLE blog_post_tags (
+    blog_post_id INTEGER NOT NULL REFERENCES blog_posts(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES blog_tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (blog_post_id, tag_id)
+);
+
+CREATE INDEX idx_blog_post_categories_category_id ON blog_post_categories (category_id);
+CREATE INDEX idx_blog_post_tags_tag_id ON blog_post_tags (tag_id);
@@ -0,0 +1,3 @@
+ALTER TABLE content_revisions DROP COLUMN IF EXISTS content_format;
+ALTER TABLE pages DROP COLUMN IF EXISTS content_format;
+ALTER TABLE blog_posts DROP COLUMN IF EXISTS content_format;
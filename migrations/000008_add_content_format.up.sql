@@ -0,0 +1,10 @@
+-- Content format of blog posts, pages and their revisions
+-- Existing content was entered as HTML
+ALTER TABLE blog_posts
+    ADD COLUMN content_format VARCHAR(10) NOT NULL DEFAULT 'html' CHECK (content_format IN ('markdown', 'html'));
+
+ALTER TABLE pages
+    ADD COLUMN content_format VARCHAR(10) NOT NULL DEFAULT 'html' CHECK (content_format IN ('markdown', 'html'));
+
+ALTER TABLE content_revisions
+    ADD COLUMN content_format VARCHAR(10) NOT NULL DEFAULT 'html' CHECK (content_format IN ('markdown', 'html'));
@@ -0,0 +1,29 @@
+DROP TRIGGER IF EXISTS update_categories_updated_at ON categories;
+DROP TRIGGER IF EXISTS update_products_updated_at ON products;
+
+ALTER TABLE categories DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE products DROP COLUMN IF EXISTS updated_at;
+
+ALTER TABLE pages
+    DROP COLUMN IF EXISTS og_image_url,
+    DROP COLUMN IF EXISTS canonical_url,
+    DROP COLUMN IF EXISTS meta_description,
+    DROP COLUMN IF EXISTS meta_title;
+
+ALTER TABLE blog_posts
+    DROP COLUMN IF EXISTS og_image_url,
+    DROP COLUMN IF EXISTS canonical_url,
+    DROP COLUMN IF EXISTS meta_description,
+    DROP COLUMN IF EXISTS meta_title;
+
+ALTER TABLE categories
+    DROP COLUMN IF EXISTS og_image_url,
+    DROP COLUMN IF EXISTS canonical_url,
+    DROP COLUMN IF EXISTS meta_description,
+    DROP COLUMN IF EXISTS meta_title;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS og_image_url,
+    DROP COLUMN IF EXISTS canonical_url,
+    DROP COLUMN IF EXISTS meta_description,
+    DROP COLUMN IF EXISTS meta_title;
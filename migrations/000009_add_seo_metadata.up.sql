@@ -0,0 +1,44 @@
+-- SEO metadata for products, categories, blog posts and pages
+ALTER TABLE products
+    ADD COLUMN meta_title VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN meta_description VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN canonical_url VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN og_image_url VARCHAR(500) NOT NULL DEFAULT '';
+
+ALTER TABLE categories
+    ADD COLUMN meta_title VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN meta_description VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN canonical_url VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN og_image_url VARCHAR(500) NOT NULL DEFAULT '';
+
+ALTER TABLE blog_posts
+    ADD COLUMN meta_title VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN meta_description VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN canonical_url VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN og_image_url VARCHAR(500) NOT NULL DEFAULT '';
+
+ALTER TABLE pages
+    ADD COLUMN meta_title VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN meta_description VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN canonical_url VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN og_image_url VARCHAR(500) NOT NULL DEFAULT '';
+
+-- Products and categories get updated_at for sitemap lastmod
+ALTER TABLE products
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+ALTER TABLE categories
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+UPDATE products SET updated_at = created_at;
+UPDATE categories SET updated_at = created_at;
+
+CREATE TRIGGER update_products_updated_at
+    BEFORE UPDATE ON products
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_categories_updated_at
+    BEFORE UPDATE ON categories
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
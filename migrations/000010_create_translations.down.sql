@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS page_translations;
+DROP TABLE IF EXISTS blog_post_translations;
+DROP TABLE IF EXISTS category_translations;
+DROP TABLE IF EXISTS product_translations;
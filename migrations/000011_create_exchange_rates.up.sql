@@ -0,0 +1,13 @@
+-- Exchange rates against the base currency (the base_currency website setting).
+-- rate is how many base currency units one unit of currency is worth, e.g. 1 USD = 25400 VND.
+-- Every change adds a row so past rates are kept; the current rate is the latest one in effect.
+CREATE TABLE exchange_rates (
+    id SERIAL PRIMARY KEY,
+    currency VARCHAR(3) NOT NULL,
+    rate NUMERIC(18, 8) NOT NULL CHECK (rate > 0),
+    effective_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_exchange_rates_currency ON exchange_rates (currency, effective_at DESC);
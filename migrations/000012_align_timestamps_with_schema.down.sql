@@ -0,0 +1,11 @@
+ALTER TABLE pages
+    ALTER COLUMN created_at DROP NOT NULL,
+    ALTER COLUMN updated_at DROP NOT NULL;
+
+ALTER TABLE blog_posts
+    ALTER COLUMN created_at DROP NOT NULL,
+    ALTER COLUMN updated_at DROP NOT NULL;
+
+ALTER TABLE contact_messages ALTER COLUMN created_at DROP NOT NULL;
+ALTER TABLE products ALTER COLUMN created_at DROP NOT NULL;
+ALTER TABLE categories ALTER COLUMN created_at DROP NOT NULL;
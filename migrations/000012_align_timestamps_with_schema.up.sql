@@ -0,0 +1,21 @@
+-- 000001 created these timestamps as nullable while sqlc/schema.sql declares them NOT NULL
+UPDATE categories SET created_at = CURRENT_TIMESTAMP WHERE created_at IS NULL;
+ALTER TABLE categories ALTER COLUMN created_at SET NOT NULL;
+
+UPDATE products SET created_at = CURRENT_TIMESTAMP WHERE created_at IS NULL;
+ALTER TABLE products ALTER COLUMN created_at SET NOT NULL;
+
+UPDATE contact_messages SET created_at = CURRENT_TIMESTAMP WHERE created_at IS NULL;
+ALTER TABLE contact_messages ALTER COLUMN created_at SET NOT NULL;
+
+UPDATE blog_posts SET created_at = COALESCE(created_at, CURRENT_TIMESTAMP), updated_at = COALESCE(updated_at, created_at, CURRENT_TIMESTAMP)
+WHERE created_at IS NULL OR updated_at IS NULL;
+ALTER TABLE blog_posts
+    ALTER COLUMN created_at SET NOT NULL,
+    ALTER COLUMN updated_at SET NOT NULL;
+
+UPDATE pages SET created_at = COALESCE(created_at, CURRENT_TIMESTAMP), updated_at = COALESCE(updated_at, created_at, CURRENT_TIMESTAMP)
+WHERE created_at IS NULL OR updated_at IS NULL;
+ALTER TABLE pages
+    ALTER COLUMN created_at SET NOT NULL,
+    ALTER COLUMN updated_at SET NOT NULL;
@@ -0,0 +1,10 @@
+// Package migrations embeds the SQL migrations so the API binary can apply them
+// without the migrate CLI.
+package migrations
+
+import "embed"
+
+// FS holds the NNNNNN_name.up.sql and NNNNNN_name.down.sql migration files
+//
+//go:embed *.sql
+var FS embed.FS
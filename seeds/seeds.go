@@ -0,0 +1,31 @@
+// Package seeds embeds the fixture files loaded by the seed command.
+package seeds
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+
+	"beef-db-be/internal/model"
+)
+
+// DemoFile is the demo catalog fixture. Its version field is bumped whenever the
+// fixture changes.
+const DemoFile = "demo.json"
+
+//go:embed *.json
+var FS embed.FS
+
+// Demo returns the embedded demo catalog
+func Demo() (*model.DemoCatalog, error) {
+	data, err := FS.ReadFile(DemoFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", DemoFile, err)
+	}
+
+	var catalog model.DemoCatalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", DemoFile, err)
+	}
+	return &catalog, nil
+}
@@ -0,0 +1,104 @@
+-- Blog Category Queries
+-- name: CreateBlogCategory :one
+INSERT INTO blog_categories (name, slug, description)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetBlogCategory :one
+SELECT *
+FROM blog_categories
+WHERE id = $1;
+
+-- name: ListBlogCategories :many
+SELECT
+    c.id,
+    c.name,
+    c.slug,
+    c.description,
+    c.created_at,
+    COUNT(p.id) AS post_count
+FROM blog_categories c
+LEFT JOIN blog_post_categories bpc ON bpc.category_id = c.id
+LEFT JOIN blog_posts p ON p.id = bpc.blog_post_id
+    AND p.status IN ('published', 'scheduled') AND p.published_at <= CURRENT_TIMESTAMP
+GROUP BY c.id
+ORDER BY c.name;
+
+-- name: UpdateBlogCategory :execrows
+UPDATE blog_categories
+SET
+    name = $1,
+    slug = $2,
+    description = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $4;
+
+-- name: DeleteBlogCategory :execrows
+DELETE FROM blog_categories
+WHERE id = $1;
+
+-- Blog Tag Queries
+-- name: UpsertBlogTag :one
+INSERT INTO blog_tags (name, slug)
+VALUES ($1, $2)
+ON CONFLICT (slug) DO UPDATE SET slug = EXCLUDED.slug
+RETURNING id;
+
+-- name: ListBlogTags :many
+SELECT
+    t.id,
+    t.name,
+    t.slug,
+    COUNT(p.id) AS post_count
+FROM blog_tags t
+LEFT JOIN blog_post_tags bpt ON bpt.tag_id = t.id
+LEFT JOIN blog_posts p ON p.id = bpt.blog_post_id
+    AND p.status IN ('published', 'scheduled') AND p.published_at <= CURRENT_TIMESTAMP
+GROUP BY t.id
+ORDER BY post_count DESC, t.name;
+
+-- name: DeleteBlogTag :execrows
+DELETE FROM blog_tags
+WHERE id = $1;
+
+-- Blog Post Taxonomy Queries
+-- name: AddBlogPostCategory :exec
+INSERT INTO blog_post_categories (blog_post_id, category_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteBlogPostCategories :exec
+DELETE FROM blog_post_categories
+WHERE blog_post_id = $1;
+
+-- name: ListBlogPostCategories :many
+SELECT
+    bpc.blog_post_id,
+    c.id,
+    c.name,
+    c.slug,
+    c.description
+FROM blog_post_categories bpc
+JOIN blog_categories c ON c.id = bpc.category_id
+WHERE bpc.blog_post_id = ANY(@post_ids::int[])
+ORDER BY c.name;
+
+-- name: AddBlogPostTag :exec
+INSERT INTO blog_post_tags (blog_post_id, tag_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteBlogPostTags :exec
+DELETE FROM blog_post_tags
+WHERE blog_post_id = $1;
+
+-- name: ListBlogPostTags :many
+SELECT
+    bpt.blog_post_id,
+    t.id,
+    t.name,
+    t.slug
+FROM blog_post_tags bpt
+JOIN blog_tags t ON t.id = bpt.tag_id
+WHERE bpt.blog_post_id = ANY(@post_ids::int[])
+ORDER BY t.name;
@@ -0,0 +1,109 @@
+-- Coupon Queries
+-- name: CreateCoupon :one
+INSERT INTO coupons (
+    code,
+    description,
+    discount_type,
+    discount_value,
+    max_discount,
+    min_order_value,
+    usage_limit,
+    usage_limit_per_user,
+    starts_at,
+    expires_at,
+    is_active
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id;
+
+-- name: GetCoupon :one
+SELECT *
+FROM coupons
+WHERE id = $1;
+
+-- name: GetCouponByCode :one
+SELECT *
+FROM coupons
+WHERE code = $1;
+
+-- name: GetCouponByCodeForUpdate :one
+-- Locks the coupon until the transaction ends, so concurrent redemptions see each other
+SELECT *
+FROM coupons
+WHERE code = $1
+FOR UPDATE;
+
+-- name: ListCoupons :many
+SELECT *
+FROM coupons
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: GetTotalCoupons :one
+SELECT COUNT(*) AS total_count
+FROM coupons;
+
+-- name: UpdateCoupon :execrows
+UPDATE coupons
+SET
+    code = $1,
+    description = $2,
+    discount_type = $3,
+    discount_value = $4,
+    max_discount = $5,
+    min_order_value = $6,
+    usage_limit = $7,
+    usage_limit_per_user = $8,
+    starts_at = $9,
+    expires_at = $10,
+    is_active = $11
+WHERE id = $12;
+
+-- name: DeleteCoupon :execrows
+DELETE FROM coupons
+WHERE id = $1;
+
+-- name: AddCouponCategory :exec
+INSERT INTO coupon_categories (coupon_id, category_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteCouponCategories :exec
+DELETE FROM coupon_categories
+WHERE coupon_id = $1;
+
+-- name: ListCouponCategoryIDs :many
+SELECT category_id
+FROM coupon_categories
+WHERE coupon_id = $1
+ORDER BY category_id;
+
+-- name: AddCouponProduct :exec
+INSERT INTO coupon_products (coupon_id, product_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteCouponProducts :exec
+DELETE FROM coupon_products
+WHERE coupon_id = $1;
+
+-- name: ListCouponProductIDs :many
+SELECT product_id
+FROM coupon_products
+WHERE coupon_id = $1
+ORDER BY product_id;
+
+-- name: CreateCouponRedemption :one
+INSERT INTO coupon_redemptions (coupon_id, user_id, order_reference, discount_amount)
+VALUES ($1, $2, $3, $4)
+RETURNING id;
+
+-- name: CountCouponRedemptions :one
+SELECT COUNT(*) AS total_count
+FROM coupon_redemptions
+WHERE coupon_id = $1;
+
+-- name: CountCouponRedemptionsByUser :one
+SELECT COUNT(*) AS total_count
+FROM coupon_redemptions
+WHERE coupon_id = $1 AND user_id = $2;
@@ -0,0 +1,32 @@
+-- Exchange Rate Queries
+-- name: CreateExchangeRate :one
+INSERT INTO exchange_rates (currency, rate, effective_at, created_by)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetCurrentExchangeRate :one
+SELECT * FROM exchange_rates
+WHERE currency = $1 AND effective_at <= CURRENT_TIMESTAMP
+ORDER BY effective_at DESC, id DESC
+LIMIT 1;
+
+-- name: ListCurrentExchangeRates :many
+SELECT DISTINCT ON (currency) *
+FROM exchange_rates
+WHERE effective_at <= CURRENT_TIMESTAMP
+ORDER BY currency, effective_at DESC, id DESC;
+
+-- name: ListExchangeRateHistory :many
+SELECT * FROM exchange_rates
+WHERE currency = $1
+ORDER BY effective_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: GetTotalExchangeRateHistory :one
+SELECT COUNT(*) AS total_count
+FROM exchange_rates
+WHERE currency = $1;
+
+-- name: DeleteExchangeRate :execrows
+DELETE FROM exchange_rates
+WHERE id = $1;
@@ -31,8 +31,8 @@ SELECT COUNT(*)
 FROM users;
 
 -- name: CreateCategory :one
-INSERT INTO categories (name, slug, description, image_url)
-VALUES ($1, $2, $3, $4)
+INSERT INTO categories (name, slug, description, image_url, meta_title, meta_description, canonical_url, og_image_url)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 RETURNING id;
 
 -- name: GetCategory :one
@@ -50,12 +50,13 @@ SELECT *
 FROM categories
 ORDER BY created_at DESC;
 
--- name: UpdateCategory :exec
+-- name: UpdateCategory :execrows
 UPDATE categories
-SET name = $1, slug = $2, description = $3, image_url = $4
-WHERE id = $5;
+SET name = $1, slug = $2, description = $3, image_url = $4,
+    meta_title = $5, meta_description = $6, canonical_url = $7, og_image_url = $8
+WHERE id = $9;
 
--- name: DeleteCategory :exec
+-- name: DeleteCategory :execrows
 DELETE FROM categories
 WHERE id = $1;
 
@@ -69,9 +70,13 @@ INSERT INTO products (
     price_sale,
     unit_of_measurement,
     image_url,
-    thumb_url
+    thumb_url,
+    meta_title,
+    meta_description,
+    canonical_url,
+    og_image_url
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 RETURNING id;
 
 -- name: GetProduct :one
@@ -87,6 +92,11 @@ SELECT
     p.image_url,
     p.thumb_url,
     p.created_at,
+    p.meta_title,
+    p.meta_description,
+    p.canonical_url,
+    p.og_image_url,
+    p.updated_at,
     c.name as category_name,
     c.slug as category_slug
 FROM products p
@@ -106,6 +116,11 @@ SELECT
     p.image_url,
     p.thumb_url,
     p.created_at,
+    p.meta_title,
+    p.meta_description,
+    p.canonical_url,
+    p.og_image_url,
+    p.updated_at,
     c.name as category_name,
     c.slug as category_slug
 FROM products p
@@ -161,7 +176,7 @@ WHERE c.id = $1 OR c.slug = $2
 ORDER BY p.created_at DESC
 LIMIT $3 OFFSET $4;
 
--- name: UpdateProduct :exec
+-- name: UpdateProduct :execrows
 UPDATE products
 SET
     category_id = $1,
@@ -172,10 +187,14 @@ SET
     price_sale = $6,
     unit_of_measurement = $7,
     image_url = $8,
-    thumb_url = $9
-WHERE id = $10;
-
--- name: DeleteProduct :exec
+    thumb_url = $9,
+    meta_title = $10,
+    meta_description = $11,
+    canonical_url = $12,
+    og_image_url = $13
+WHERE id = $14;
+
+-- name: DeleteProduct :execrows
 DELETE FROM products
 WHERE id = $1;
 
@@ -213,12 +232,12 @@ WHERE name = $1;
 SELECT *
 FROM website_settings;
 
--- name: UpdateWebsiteSetting :exec
+-- name: UpdateWebsiteSetting :execrows
 UPDATE website_settings
 SET value = $1
 WHERE name = $2;
 
--- name: DeleteWebsiteSetting :exec
+-- name: DeleteWebsiteSetting :execrows
 DELETE FROM website_settings
 WHERE id = $1;
 
@@ -272,9 +291,17 @@ INSERT INTO blog_posts (
     content,
     slug,
     image_url,
+    status,
+    published_at,
+    author_id,
+    content_format,
+    meta_title,
+    meta_description,
+    canonical_url,
+    og_image_url,
     created_at
 )
-VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, CURRENT_TIMESTAMP)
 RETURNING *;
 
 -- name: GetBlogPost :one
@@ -293,17 +320,70 @@ FROM blog_posts
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2;
 
--- name: UpdateBlogPost :exec
+-- name: GetPublishedBlogPost :one
+SELECT *
+FROM blog_posts
+WHERE id = $1 AND status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP;
+
+-- name: GetPublishedBlogPostBySlug :one
+SELECT *
+FROM blog_posts
+WHERE slug = $1 AND status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP;
+
+-- name: ListPublishedBlogPosts :many
+SELECT *
+FROM blog_posts
+WHERE status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP
+    AND (sqlc.narg('tag')::text IS NULL OR id IN (
+        SELECT bpt.blog_post_id
+        FROM blog_post_tags bpt
+        JOIN blog_tags t ON t.id = bpt.tag_id
+        WHERE t.slug = sqlc.narg('tag')
+    ))
+    AND (sqlc.narg('category')::text IS NULL OR id IN (
+        SELECT bpc.blog_post_id
+        FROM blog_post_categories bpc
+        JOIN blog_categories c ON c.id = bpc.category_id
+        WHERE c.slug = sqlc.narg('category')
+    ))
+ORDER BY published_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: GetTotalPublishedBlogPosts :one
+SELECT COUNT(*) as total_count
+FROM blog_posts
+WHERE status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP
+    AND (sqlc.narg('tag')::text IS NULL OR id IN (
+        SELECT bpt.blog_post_id
+        FROM blog_post_tags bpt
+        JOIN blog_tags t ON t.id = bpt.tag_id
+        WHERE t.slug = sqlc.narg('tag')
+    ))
+    AND (sqlc.narg('category')::text IS NULL OR id IN (
+        SELECT bpc.blog_post_id
+        FROM blog_post_categories bpc
+        JOIN blog_categories c ON c.id = bpc.category_id
+        WHERE c.slug = sqlc.narg('category')
+    ));
+
+-- name: UpdateBlogPost :execrows
 UPDATE blog_posts
 SET 
     title = $1,
     description = $2,
     content = $3,
     image_url = $4,
-    slug = $5
-WHERE id = $6;
-
--- name: DeleteBlogPost :exec
+    slug = $5,
+    status = $6,
+    published_at = $7,
+    content_format = $8,
+    meta_title = $9,
+    meta_description = $10,
+    canonical_url = $11,
+    og_image_url = $12
+WHERE id = $13;
+
+-- name: DeleteBlogPost :execrows
 DELETE FROM blog_posts
 WHERE id = $1;
 
@@ -326,10 +406,17 @@ INSERT INTO pages (
     slug,
     title,
     content,
+    status,
+    published_at,
+    content_format,
+    meta_title,
+    meta_description,
+    canonical_url,
+    og_image_url,
     created_at,
     updated_at
 )
-VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
 RETURNING *;
 
 -- name: GetPage :one
@@ -348,19 +435,104 @@ FROM pages
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2;
 
--- name: UpdatePage :exec
+-- name: GetPublishedPage :one
+SELECT *
+FROM pages
+WHERE id = $1 AND status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP;
+
+-- name: GetPublishedPageBySlug :one
+SELECT *
+FROM pages
+WHERE slug = $1 AND status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP;
+
+-- name: ListPublishedPages :many
+SELECT *
+FROM pages
+WHERE status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP
+ORDER BY published_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: GetTotalPublishedPages :one
+SELECT COUNT(*) as total_count
+FROM pages
+WHERE status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP;
+
+-- name: UpdatePage :execrows
 UPDATE pages
 SET 
     slug = $1,
     title = $2,
     content = $3,
+    status = $4,
+    published_at = $5,
+    content_format = $6,
+    meta_title = $7,
+    meta_description = $8,
+    canonical_url = $9,
+    og_image_url = $10,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $4;
+WHERE id = $11;
 
--- name: DeletePage :exec
+-- name: DeletePage :execrows
 DELETE FROM pages
 WHERE id = $1;
 
 -- name: GetTotalPages :one
 SELECT COUNT(*) as total_count
 FROM pages;
+
+-- name: ListProductsByIDs :many
+SELECT
+    p.id,
+    p.category_id,
+    p.name,
+    p.slug,
+    p.price,
+    p.price_sale,
+    c.slug as category_slug
+FROM products p
+JOIN categories c ON p.category_id = c.id
+WHERE p.id = ANY(@ids::int[]);
+
+-- name: ListProductsForExport :many
+SELECT
+    p.id,
+    c.slug as category_slug,
+    p.name,
+    p.slug,
+    p.description,
+    p.price,
+    p.price_sale,
+    p.unit_of_measurement,
+    p.image_url,
+    p.thumb_url
+FROM products p
+JOIN categories c ON p.category_id = c.id
+WHERE p.id > @after_id
+ORDER BY p.id
+LIMIT @row_limit;
+
+-- name: UpsertProductBySlug :one
+INSERT INTO products (
+    category_id,
+    name,
+    slug,
+    description,
+    price,
+    price_sale,
+    unit_of_measurement,
+    image_url,
+    thumb_url
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (slug) DO UPDATE
+SET
+    category_id = EXCLUDED.category_id,
+    name = EXCLUDED.name,
+    description = EXCLUDED.description,
+    price = EXCLUDED.price,
+    price_sale = EXCLUDED.price_sale,
+    unit_of_measurement = EXCLUDED.unit_of_measurement,
+    image_url = EXCLUDED.image_url,
+    thumb_url = EXCLUDED.thumb_url
+RETURNING id, (xmax = 0)::boolean AS inserted;
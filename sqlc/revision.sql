@@ -0,0 +1,26 @@
+-- Content Revision Queries
+-- name: CreateContentRevision :one
+INSERT INTO content_revisions (
+    resource_type, resource_id, title, slug, description, content, author_id, content_format
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: GetContentRevision :one
+SELECT * FROM content_revisions
+WHERE id = $1 AND resource_type = $2 AND resource_id = $3;
+
+-- name: ListContentRevisions :many
+SELECT * FROM content_revisions
+WHERE resource_type = $1 AND resource_id = $2
+ORDER BY id DESC
+LIMIT $3 OFFSET $4;
+
+-- name: GetTotalContentRevisions :one
+SELECT COUNT(*) FROM content_revisions
+WHERE resource_type = $1 AND resource_id = $2;
+
+-- name: DeleteContentRevisionsByResource :exec
+DELETE FROM content_revisions
+WHERE resource_type = $1 AND resource_id = $2;
@@ -120,4 +120,267 @@ CREATE INDEX idx_pages_slug ON pages (slug);
 CREATE TRIGGER update_pages_updated_at
     BEFORE UPDATE ON pages
     FOR EACH ROW
-    EXECUTE FUNCTION update_updated_at_column();
\ No newline at end of file
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Coupons Table
+CREATE TABLE coupons (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    discount_type VARCHAR(10) NOT NULL CHECK (discount_type IN ('percentage', 'fixed')),
+    discount_value DECIMAL(10, 2) NOT NULL CHECK (discount_value > 0),
+    max_discount DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    min_order_value DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    usage_limit INTEGER NOT NULL DEFAULT 0,
+    usage_limit_per_user INTEGER NOT NULL DEFAULT 0,
+    starts_at TIMESTAMP,
+    expires_at TIMESTAMP,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_coupons_code ON coupons (code);
+
+CREATE TRIGGER update_coupons_updated_at
+    BEFORE UPDATE ON coupons
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Coupon category restrictions
+CREATE TABLE coupon_categories (
+    coupon_id INTEGER NOT NULL REFERENCES coupons (id) ON DELETE CASCADE,
+    category_id INTEGER NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    PRIMARY KEY (coupon_id, category_id)
+);
+
+-- Coupon product restrictions
+CREATE TABLE coupon_products (
+    coupon_id INTEGER NOT NULL REFERENCES coupons (id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    PRIMARY KEY (coupon_id, product_id)
+);
+
+-- Coupon redemptions, used to enforce global and per-user usage limits
+CREATE TABLE coupon_redemptions (
+    id SERIAL PRIMARY KEY,
+    coupon_id INTEGER NOT NULL REFERENCES coupons (id) ON DELETE CASCADE,
+    user_id BIGINT REFERENCES users (id) ON DELETE SET NULL,
+    order_reference VARCHAR(100) NOT NULL DEFAULT '',
+    discount_amount DECIMAL(10, 2) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_coupon_redemptions_coupon_id ON coupon_redemptions (coupon_id);
+CREATE INDEX idx_coupon_redemptions_user_id ON coupon_redemptions (user_id);
+
+-- Slug History Table
+-- Keeps previous slugs of products, blog posts and pages so old links can be redirected
+CREATE TABLE slug_history (
+    id SERIAL PRIMARY KEY,
+    resource_type VARCHAR(20) NOT NULL CHECK (resource_type IN ('product', 'blog_post', 'page')),
+    resource_id INTEGER NOT NULL,
+    slug VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (resource_type, slug)
+);
+
+CREATE INDEX idx_slug_history_resource ON slug_history (resource_type, resource_id);
+
+-- Publishing workflow for blog posts and pages
+ALTER TABLE blog_posts
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'scheduled', 'published', 'archived')),
+    ADD COLUMN published_at TIMESTAMP;
+
+CREATE INDEX idx_blog_posts_status_published_at ON blog_posts (status, published_at);
+
+ALTER TABLE pages
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'scheduled', 'published', 'archived')),
+    ADD COLUMN published_at TIMESTAMP;
+
+CREATE INDEX idx_pages_status_published_at ON pages (status, published_at);
+
+-- Content Revisions Table
+CREATE TABLE content_revisions (
+    id SERIAL PRIMARY KEY,
+    resource_type VARCHAR(20) NOT NULL CHECK (resource_type IN ('blog_post', 'page')),
+    resource_id INTEGER NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    content TEXT NOT NULL,
+    author_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_content_revisions_resource ON content_revisions (resource_type, resource_id);
+
+-- Blog post authors
+ALTER TABLE blog_posts
+    ADD COLUMN author_id BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_blog_posts_author_id ON blog_posts (author_id);
+
+-- Blog Categories Table
+CREATE TABLE blog_categories (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(150) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Blog Tags Table
+CREATE TABLE blog_tags (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(150) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Blog post to category and tag links
+CREATE TABLE blog_post_categories (
+    blog_post_id INTEGER NOT NULL REFERENCES blog_posts(id) ON DELETE CASCADE,
+    category_id INTEGER NOT NULL REFERENCES blog_categories(id) ON DELETE CASCADE,
+    PRIMARY KEY (blog_post_id, category_id)
+);
+
+CREATE TABLE blog_post_tags (
+    blog_post_id INTEGER NOT NULL REFERENCES blog_posts(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES blog_tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (blog_post_id, tag_id)
+);
+
+CREATE INDEX idx_blog_post_categories_category_id ON blog_post_categories (category_id);
+CREATE INDEX idx_blog_post_tags_tag_id ON blog_post_tags (tag_id);
+
+-- Content format of blog posts, pages and their revisions
+-- Existing content was entered as HTML
+ALTER TABLE blog_posts
+    ADD COLUMN content_format VARCHAR(10) NOT NULL DEFAULT 'html' CHECK (content_format IN ('markdown', 'html'));
+
+ALTER TABLE pages
+    ADD COLUMN content_format VARCHAR(10) NOT NULL DEFAULT 'html' CHECK (content_format IN ('markdown', 'html'));
+
+ALTER TABLE content_revisions
+    ADD COLUMN content_format VARCHAR(10) NOT NULL DEFAULT 'html' CHECK (content_format IN ('markdown', 'html'));
+
+-- SEO metadata for products, categories, blog posts and pages
+ALTER TABLE products
+    ADD COLUMN meta_title VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN meta_description VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN canonical_url VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN og_image_url VARCHAR(500) NOT NULL DEFAULT '';
+
+ALTER TABLE categories
+    ADD COLUMN meta_title VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN meta_description VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN canonical_url VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN og_image_url VARCHAR(500) NOT NULL DEFAULT '';
+
+ALTER TABLE blog_posts
+    ADD COLUMN meta_title VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN meta_description VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN canonical_url VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN og_image_url VARCHAR(500) NOT NULL DEFAULT '';
+
+ALTER TABLE pages
+    ADD COLUMN meta_title VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN meta_description VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN canonical_url VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN og_image_url VARCHAR(500) NOT NULL DEFAULT '';
+
+-- Products and categories get updated_at for sitemap lastmod
+ALTER TABLE products
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+ALTER TABLE categories
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+CREATE TRIGGER update_products_updated_at
+    BEFORE UPDATE ON products
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_categories_updated_at
+    BEFORE UPDATE ON categories
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Translations of products, categories, blog posts and pages. The entity tables keep
+-- the default locale; each row here holds the text of one other locale.
+CREATE TABLE product_translations (
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL,
+    name VARCHAR(150) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (product_id, locale)
+);
+
+CREATE TABLE category_translations (
+    category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (category_id, locale)
+);
+
+CREATE TABLE blog_post_translations (
+    blog_post_id INTEGER NOT NULL REFERENCES blog_posts(id) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    content TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (blog_post_id, locale)
+);
+
+CREATE TABLE page_translations (
+    page_id INTEGER NOT NULL REFERENCES pages(id) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (page_id, locale)
+);
+
+CREATE TRIGGER update_product_translations_updated_at
+    BEFORE UPDATE ON product_translations
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_category_translations_updated_at
+    BEFORE UPDATE ON category_translations
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_blog_post_translations_updated_at
+    BEFORE UPDATE ON blog_post_translations
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_page_translations_updated_at
+    BEFORE UPDATE ON page_translations
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Exchange rates against the base currency (the base_currency website setting).
+-- rate is how many base currency units one unit of currency is worth, e.g. 1 USD = 25400 VND.
+-- Every change adds a row so past rates are kept; the current rate is the latest one in effect.
+CREATE TABLE exchange_rates (
+    id SERIAL PRIMARY KEY,
+    currency VARCHAR(3) NOT NULL,
+    rate NUMERIC(18, 8) NOT NULL CHECK (rate > 0),
+    effective_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_exchange_rates_currency ON exchange_rates (currency, effective_at DESC);
@@ -0,0 +1,19 @@
+-- Seed Queries
+-- name: CreateAdminUser :one
+INSERT INTO users (email, password, role)
+VALUES ($1, $2, 'admin')
+RETURNING id;
+
+-- name: PromoteUserToAdmin :exec
+UPDATE users
+SET role = 'admin'
+WHERE id = $1;
+
+-- name: TruncateAllData :exec
+TRUNCATE
+    users, categories, products, website_settings, contact_messages, blog_posts, pages,
+    coupons, coupon_categories, coupon_products, coupon_redemptions, slug_history,
+    content_revisions, blog_categories, blog_tags, blog_post_categories, blog_post_tags,
+    product_translations, category_translations, blog_post_translations, page_translations,
+    exchange_rates
+RESTART IDENTITY CASCADE;
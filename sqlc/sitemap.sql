@@ -0,0 +1,35 @@
+-- Sitemap Queries
+-- name: GetSitemapCounts :one
+SELECT
+    (SELECT COUNT(*) FROM products) AS product_count,
+    (SELECT COUNT(*) FROM categories) AS category_count,
+    (SELECT COUNT(*) FROM blog_posts
+        WHERE status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP) AS blog_post_count,
+    (SELECT COUNT(*) FROM pages
+        WHERE status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP) AS page_count;
+
+-- name: ListSitemapProducts :many
+SELECT slug, updated_at
+FROM products
+ORDER BY id
+LIMIT $1 OFFSET $2;
+
+-- name: ListSitemapCategories :many
+SELECT slug, updated_at
+FROM categories
+ORDER BY id
+LIMIT $1 OFFSET $2;
+
+-- name: ListSitemapBlogPosts :many
+SELECT slug, updated_at
+FROM blog_posts
+WHERE status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP
+ORDER BY id
+LIMIT $1 OFFSET $2;
+
+-- name: ListSitemapPages :many
+SELECT slug, updated_at
+FROM pages
+WHERE status IN ('published', 'scheduled') AND published_at <= CURRENT_TIMESTAMP
+ORDER BY id
+LIMIT $1 OFFSET $2;
@@ -0,0 +1,45 @@
+-- Slug Queries
+-- name: ProductSlugExists :one
+SELECT EXISTS (
+    SELECT 1 FROM products WHERE slug = $1 AND id <> $2
+);
+
+-- name: CategorySlugExists :one
+SELECT EXISTS (
+    SELECT 1 FROM categories WHERE slug = $1 AND id <> $2
+);
+
+-- name: BlogPostSlugExists :one
+SELECT EXISTS (
+    SELECT 1 FROM blog_posts WHERE slug = $1 AND id <> $2
+);
+
+-- name: PageSlugExists :one
+SELECT EXISTS (
+    SELECT 1 FROM pages WHERE slug = $1 AND id <> $2
+);
+
+-- Slug History Queries
+-- name: CreateSlugHistory :exec
+INSERT INTO slug_history (resource_type, resource_id, slug)
+VALUES ($1, $2, $3)
+ON CONFLICT (resource_type, slug)
+DO UPDATE SET resource_id = EXCLUDED.resource_id, created_at = CURRENT_TIMESTAMP;
+
+-- name: GetSlugHistory :one
+SELECT resource_id
+FROM slug_history
+WHERE resource_type = $1 AND slug = $2;
+
+-- name: DeleteSlugHistory :exec
+DELETE FROM slug_history
+WHERE resource_type = $1 AND slug = $2;
+
+-- name: DeleteSlugHistoryByResource :exec
+DELETE FROM slug_history
+WHERE resource_type = $1 AND resource_id = $2;
+
+-- name: BlogCategorySlugExists :one
+SELECT EXISTS (
+    SELECT 1 FROM blog_categories WHERE slug = $1 AND id <> $2
+);
@@ -0,0 +1,134 @@
+-- Translation Queries
+-- name: UpsertProductTranslation :one
+INSERT INTO product_translations (product_id, locale, name, description)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (product_id, locale) DO UPDATE
+SET name = EXCLUDED.name, description = EXCLUDED.description
+RETURNING *;
+
+-- name: ListProductTranslations :many
+SELECT * FROM product_translations
+WHERE product_id = $1
+ORDER BY locale;
+
+-- name: ListProductTranslationsByLocale :many
+SELECT * FROM product_translations
+WHERE product_id = ANY(@product_ids::int[]) AND locale = @locale;
+
+-- name: DeleteProductTranslation :execrows
+DELETE FROM product_translations
+WHERE product_id = $1 AND locale = $2;
+
+-- name: UpsertCategoryTranslation :one
+INSERT INTO category_translations (category_id, locale, name, description)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (category_id, locale) DO UPDATE
+SET name = EXCLUDED.name, description = EXCLUDED.description
+RETURNING *;
+
+-- name: ListCategoryTranslations :many
+SELECT * FROM category_translations
+WHERE category_id = $1
+ORDER BY locale;
+
+-- name: ListCategoryTranslationsByLocale :many
+SELECT * FROM category_translations
+WHERE category_id = ANY(@category_ids::int[]) AND locale = @locale;
+
+-- name: DeleteCategoryTranslation :execrows
+DELETE FROM category_translations
+WHERE category_id = $1 AND locale = $2;
+
+-- name: UpsertBlogPostTranslation :one
+INSERT INTO blog_post_translations (blog_post_id, locale, title, description, content)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (blog_post_id, locale) DO UPDATE
+SET title = EXCLUDED.title, description = EXCLUDED.description, content = EXCLUDED.content
+RETURNING *;
+
+-- name: ListBlogPostTranslations :many
+SELECT * FROM blog_post_translations
+WHERE blog_post_id = $1
+ORDER BY locale;
+
+-- name: ListBlogPostTranslationsByLocale :many
+SELECT * FROM blog_post_translations
+WHERE blog_post_id = ANY(@blog_post_ids::int[]) AND locale = @locale;
+
+-- name: DeleteBlogPostTranslation :execrows
+DELETE FROM blog_post_translations
+WHERE blog_post_id = $1 AND locale = $2;
+
+-- name: UpsertPageTranslation :one
+INSERT INTO page_translations (page_id, locale, title, content)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (page_id, locale) DO UPDATE
+SET title = EXCLUDED.title, content = EXCLUDED.content
+RETURNING *;
+
+-- name: ListPageTranslations :many
+SELECT * FROM page_translations
+WHERE page_id = $1
+ORDER BY locale;
+
+-- name: ListPageTranslationsByLocale :many
+SELECT * FROM page_translations
+WHERE page_id = ANY(@page_ids::int[]) AND locale = @locale;
+
+-- name: DeletePageTranslation :execrows
+DELETE FROM page_translations
+WHERE page_id = $1 AND locale = $2;
+
+-- name: ListMissingTranslations :many
+SELECT r.resource_type::text AS resource_type, r.resource_id, r.slug, r.label, l.locale::text AS locale
+FROM (
+    SELECT 'product' AS resource_type, id AS resource_id, slug, name AS label FROM products
+    UNION ALL
+    SELECT 'category', id, slug, name FROM categories
+    UNION ALL
+    SELECT 'blog_post', id, slug, title FROM blog_posts
+    UNION ALL
+    SELECT 'page', id, slug, title FROM pages
+) r
+CROSS JOIN unnest(@locales::text[]) AS l(locale)
+WHERE (sqlc.narg('resource_type')::text IS NULL OR r.resource_type = sqlc.narg('resource_type')::text)
+  AND NOT EXISTS (
+    SELECT 1 FROM (
+        SELECT 'product' AS resource_type, product_id AS resource_id, locale FROM product_translations
+        UNION ALL
+        SELECT 'category', category_id, locale FROM category_translations
+        UNION ALL
+        SELECT 'blog_post', blog_post_id, locale FROM blog_post_translations
+        UNION ALL
+        SELECT 'page', page_id, locale FROM page_translations
+    ) t
+    WHERE t.resource_type = r.resource_type AND t.resource_id = r.resource_id AND t.locale = l.locale
+  )
+ORDER BY r.resource_type, r.resource_id, l.locale
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: GetTotalMissingTranslations :one
+SELECT COUNT(*) AS total_count
+FROM (
+    SELECT 'product' AS resource_type, id AS resource_id, slug, name AS label FROM products
+    UNION ALL
+    SELECT 'category', id, slug, name FROM categories
+    UNION ALL
+    SELECT 'blog_post', id, slug, title FROM blog_posts
+    UNION ALL
+    SELECT 'page', id, slug, title FROM pages
+) r
+CROSS JOIN unnest(@locales::text[]) AS l(locale)
+WHERE (sqlc.narg('resource_type')::text IS NULL OR r.resource_type = sqlc.narg('resource_type')::text)
+  AND NOT EXISTS (
+    SELECT 1 FROM (
+        SELECT 'product' AS resource_type, product_id AS resource_id, locale FROM product_translations
+        UNION ALL
+        SELECT 'category', category_id, locale FROM category_translations
+        UNION ALL
+        SELECT 'blog_post', blog_post_id, locale FROM blog_post_translations
+        UNION ALL
+        SELECT 'page', page_id, locale FROM page_translations
+    ) t
+    WHERE t.resource_type = r.resource_type AND t.resource_id = r.resource_id AND t.locale = l.locale
+  );